@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/brand"
+)
+
+// brandWarning describes a well-known brand the domain could be confused
+// with, or returns "" when there is none
+func brandWarning(domain string) string {
+	m, ok := brand.Similar(domain)
+	if !ok {
+		return ""
+	}
+	if m.Distance == 0 {
+		return orange + "(matches brand " + m.Brand + ")" + reset
+	}
+	return orange + "(similar to " + m.Brand + ")" + reset
+}
+
+// joinNotes joins the non-empty notes with spaces
+func joinNotes(notes ...string) string {
+	var out []string
+	for _, n := range notes {
+		if n != "" {
+			out = append(out, n)
+		}
+	}
+	return strings.Join(out, " ")
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/tld"
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check [domain...]",
+	Short: "Check keywords across TLDs, or full domain names",
+	Long: "Check every keyword given with -k in the TLDs selected with -e or -E, and the given domains as\n" +
+		"they are. Pass - to read keywords or full domains from stdin, one per line.",
+	Example: "  gofindadomain check -k mycompany -E tlds.txt -x\n" +
+		"  gofindadomain check example.io foo.dev\n" +
+		"  cat names.txt | gofindadomain check -e .com -",
+	RunE: runCheck,
+}
+
+func init() {
+	addKeywordFlags(checkCmd)
+	addCheckFlags(checkCmd, "strategy")
+	rootCmd.AddCommand(checkCmd)
+}
+
+// addKeywordFlags registers the keyword and TLD selection flags of check
+func addKeywordFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVarP(&keywords, "keyword", "k", nil, "Keywords to check, repeated or comma-separated (e.g., mycompany or foo,bar)")
+	cmd.Flags().StringVarP(&singleTLD, "tld", "e", "", "Single TLD to check (e.g., .com)")
+	cmd.Flags().StringVarP(&tldFile, "tld-file", "E", "", "File containing TLDs to check")
+	addTLDFilterFlags(cmd)
+}
+
+// addTLDFilterFlags registers the flags narrowing the TLDs loaded with -e,
+// -E or a profile
+func addTLDFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&includeTLDs, "include-tlds", nil, "Only check TLDs matching these globs (e.g., '.c*,.io')")
+	cmd.Flags().StringSliceVar(&excludeTLDs, "exclude-tlds", nil, "Skip TLDs matching these globs (e.g., .xxx,.adult)")
+}
+
+// keywordDomains pairs a keyword with each TLD; an invalid keyword is
+// skipped with a warning
+func keywordDomains(keyword string, tlds []string) []string {
+	if err := tld.ValidateLabel(keyword); err != nil {
+		fmt.Fprintf(os.Stderr, "[%sskipped%s] %v\n", orange, reset, err)
+		return nil
+	}
+	domains := make([]string, 0, len(tlds))
+	for _, t := range tlds {
+		domains = append(domains, keyword+t)
+	}
+	return allowedDomains(domains)
+}
+
+// allowedDomains drops, with a warning, the domains shorter than their
+// registry registers
+func allowedDomains(domains []string) []string {
+	var allowed []string
+	for _, d := range domains {
+		if err := tld.CheckMinLength(d); err != nil {
+			fmt.Fprintf(os.Stderr, "[%sskipped%s] %v\n", orange, reset, err)
+			continue
+		}
+		allowed = append(allowed, d)
+	}
+	return allowed
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	var fullDomains []string
+	for _, arg := range args {
+		if arg == "-" {
+			names, domains, err := readNames(os.Stdin)
+			if err != nil {
+				return err
+			}
+			keywords = append(keywords, names...)
+			fullDomains = append(fullDomains, domains...)
+			continue
+		}
+
+		domain, err := tld.ToASCII(strings.TrimSuffix(strings.TrimSpace(arg), "."))
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(domain, ".") {
+			return fmt.Errorf("%q is not a full domain (use -k for keywords)", arg)
+		}
+		fullDomains = append(fullDomains, domain)
+	}
+	if len(keywords) == 0 && len(fullDomains) == 0 {
+		return fmt.Errorf("keyword is required (-k). Use -h for help")
+	}
+
+	// Reject names no registry would have before querying anything
+	var labels []string
+	for _, k := range keywords {
+		k, err := tld.ToASCII(strings.TrimSpace(k))
+		if err != nil {
+			return err
+		}
+		if k == "" {
+			continue
+		}
+		if err := tld.ValidateLabel(k); err != nil {
+			return fmt.Errorf("invalid keyword: %w", err)
+		}
+		labels = append(labels, k)
+	}
+	for _, d := range fullDomains {
+		if err := tld.ValidateDomain(d); err != nil {
+			return err
+		}
+	}
+
+	var tlds []string
+	tldSource := "domains"
+	if len(labels) > 0 {
+		var err error
+		if tlds, tldSource, err = resolveTLDs(singleTLD, tldFile); err != nil {
+			return err
+		}
+	}
+
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	// Build the keyword x TLD matrix, grouped per keyword, followed by the
+	// full domains
+	var domains []string
+	var groups []checkGroup
+	for _, k := range labels {
+		g := checkGroup{title: k, keyword: k, domains: keywordDomains(k, tlds)}
+		domains = append(domains, g.domains...)
+		groups = append(groups, g)
+	}
+	if full := allowedDomains(fullDomains); len(full) > 0 {
+		domains = append(domains, full...)
+		groups = append(groups, checkGroup{title: "domains", domains: full})
+	}
+	if len(domains) == 0 {
+		return fmt.Errorf("nothing left to check")
+	}
+
+	in := checkInputs{keyword: strings.Join(keywords, ","), tldSource: tldSource, tlds: tlds}
+	if len(groups) > 1 {
+		in.groups = groups
+	}
+	return sess.check(cmd, domains, in)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// Windows consoles only interpret ANSI escapes once virtual terminal
+// processing is enabled. Older consoles that refuse it get plain output.
+func init() {
+	handle := windows.Handle(os.Stdout.Fd())
+
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		// Not a console (redirected to a file or pipe): leave colors as they are
+		return
+	}
+	if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+		reset, red, green, orange, bold, bGreen, bRed = "", "", "", "", "", "", ""
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	combineList1   string
+	combineList2   string
+	combineJoiners []string
+	// labelMin and labelMax bound generated label lengths in combine and
+	// generate
+	labelMin, labelMax  int
+	combineMinPronounce float64
+)
+
+var combineCmd = &cobra.Command{
+	Use:   "combine",
+	Short: "Check every pairing of two wordlists",
+	Long: "Generate every word1+word2 pairing from two wordlists and check their availability.\n" +
+		"Use --joiner to also try pairings joined by a hyphen. Without --list1 and --list2 the built-in\n" +
+		"adjective and noun lists are paired (swift+panda -> swiftpanda).",
+	Args: cobra.NoArgs,
+	RunE: runCombine,
+}
+
+func init() {
+	builtins := generate.BuiltinPrefix + strings.Join(generate.Wordlists(), ", "+generate.BuiltinPrefix)
+	combineCmd.Flags().StringVar(&combineList1, "list1", generate.BuiltinPrefix+"adjectives", "First wordlist file, or one of "+builtins)
+	combineCmd.Flags().StringVar(&combineList2, "list2", generate.BuiltinPrefix+"nouns", "Second wordlist file, or one of "+builtins)
+	combineCmd.Flags().StringArrayVar(&combineJoiners, "joiner", []string{""}, `Joiner placed between words: "" or "-" (repeatable)`)
+	combineCmd.Flags().IntVar(&labelMin, "min-length", 0, "Minimum label length, joiner included (0 for none)")
+	combineCmd.Flags().IntVar(&labelMax, "max-length", 0, "Maximum label length, joiner included (0 for none)")
+	combineCmd.Flags().Float64Var(&combineMinPronounce, "min-pronounce", 0, "Minimum pronounceability of a pairing (0-1)")
+	combineCmd.Flags().StringVarP(&singleTLD, "tld", "e", "", "Single TLD to check (e.g., .com)")
+	combineCmd.Flags().StringVarP(&tldFile, "tld-file", "E", "", "File containing TLDs to check")
+	addTLDFilterFlags(combineCmd)
+	addCheckFlags(combineCmd, "strategy")
+	rootCmd.AddCommand(combineCmd)
+}
+
+func runCombine(cmd *cobra.Command, args []string) error {
+	for _, j := range combineJoiners {
+		if j != "" && j != "-" {
+			return fmt.Errorf("invalid joiner %q (use \"\" or \"-\")", j)
+		}
+	}
+
+	if labelMax > 0 && labelMax < labelMin {
+		return fmt.Errorf("invalid --max-length %d (below --min-length %d)", labelMax, labelMin)
+	}
+
+	first, err := generate.LoadList(combineList1)
+	if err != nil {
+		return err
+	}
+	second, err := generate.LoadList(combineList2)
+	if err != nil {
+		return err
+	}
+
+	tlds, tldSource, err := resolveTLDs(singleTLD, tldFile)
+	if err != nil {
+		return err
+	}
+
+	filter := generate.Filter{MinPronounce: combineMinPronounce, MinLength: labelMin, MaxLength: labelMax}
+	labels := generate.FilterLabels(generate.Combine(first, second, combineJoiners), filter)
+	if len(labels) == 0 {
+		return fmt.Errorf("no pairings pass the length and pronounceability filters")
+	}
+	var domains []string
+	for _, label := range labels {
+		domains = append(domains, keywordDomains(label, tlds)...)
+	}
+
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	keyword := strings.Join([]string{combineList1, combineList2}, "+")
+	return sess.check(cmd, domains, checkInputs{keyword: keyword, tldSource: tldSource, tlds: tlds})
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/james-see/gofindadomain/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var diffJSON bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old> <new>",
+	Short: "Show domains that changed state between two runs",
+	Long: "Compare two runs and report domains that became available, were taken, became premium or\n" +
+		"reserved, or had their expiry date changed. Each run is a run ID from `gofindadomain history runs` or a file of JSON lines\n" +
+		"written by --json.",
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffJSON, "json", false, "Print changes as JSON lines")
+	rootCmd.AddCommand(diffCmd)
+}
+
+// loadRun reads a run's results from the history when arg is a run ID, or
+// from a JSON export otherwise. It also returns a label for the run.
+func loadRun(arg string) ([]history.Record, string, error) {
+	if id, err := strconv.ParseInt(arg, 10, 64); err == nil {
+		if _, statErr := os.Stat(arg); os.IsNotExist(statErr) {
+			db, err := history.Open()
+			if err != nil {
+				return nil, "", err
+			}
+			defer db.Close()
+
+			run, err := db.Run(id)
+			if err != nil {
+				return nil, "", err
+			}
+			records, err := db.Records(history.Filter{RunID: id})
+			if err != nil {
+				return nil, "", err
+			}
+			return records, fmt.Sprintf("run %d (%s)", id, run.Started.Local().Format("2006-01-02 15:04")), nil
+		}
+	}
+
+	f, err := os.Open(arg)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open %s: %w", arg, err)
+	}
+	defer f.Close()
+	records, err := history.ReadJSON(f)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", arg, err)
+	}
+	return records, arg, nil
+}
+
+// diffJSONChange is a change as printed by diff --json
+type diffJSONChange struct {
+	Domain    string `json:"domain"`
+	Change    string `json:"change"`
+	OldExpiry string `json:"old_expiry,omitempty"`
+	NewExpiry string `json:"new_expiry,omitempty"`
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	old, oldLabel, err := loadRun(args[0])
+	if err != nil {
+		return err
+	}
+	cur, curLabel, err := loadRun(args[1])
+	if err != nil {
+		return err
+	}
+	changes := history.Diff(old, cur)
+
+	if diffJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, c := range changes {
+			enc.Encode(diffJSONChange{Domain: c.Domain, Change: c.Kind, OldExpiry: c.OldExpiry, NewExpiry: c.NewExpiry})
+		}
+		return nil
+	}
+
+	fmt.Printf("%s%s -> %s%s\n", bold, oldLabel, curLabel, reset)
+	if len(changes) == 0 {
+		fmt.Println("  no changes")
+	}
+	for _, c := range changes {
+		switch c.Kind {
+		case history.NewlyAvailable:
+			fmt.Printf("  [%snewly available%s] %s\n", bGreen, reset, c.Domain)
+		case history.NewlyTaken:
+			fmt.Printf("  [%snewly taken%s] %s\n", bRed, reset, c.Domain)
+		case history.NewlyPremium, history.NewlyReserved:
+			fmt.Printf("  [%s%s%s] %s\n", orange, c.Kind, reset, c.Domain)
+		case history.ExpiryChanged:
+			fmt.Printf("  [%sexpiry changed%s] %s - %s -> %s%s%s\n", orange, reset, c.Domain, c.OldExpiry, orange, c.NewExpiry, reset)
+		}
+	}
+	return nil
+}
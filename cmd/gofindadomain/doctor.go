@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/tld"
+	"github.com/spf13/cobra"
+)
+
+// doctorTimeout bounds each probe of a backend
+const doctorTimeout = 30 * time.Second
+
+var (
+	doctorBackends string
+	doctorTLDs     []string
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Test the health of the checker backends",
+	Long: "Check a registered domain (nic.<tld>) and a random unregistered one with every backend, and report\n" +
+		"which backends answer correctly and how fast. The per-TLD chains of the config are tested for their TLDs.",
+	Example: "  gofindadomain doctor\n  gofindadomain doctor --backend rdap,whois --tld .com,.io",
+	Args:    cobra.NoArgs,
+	RunE:    runDoctor,
+}
+
+func init() {
+	doctorCmd.Flags().StringVar(&doctorBackends, "backend", "", "Comma-separated backends to test (default whois, rdap, dns and system-whois when installed)")
+	doctorCmd.Flags().StringSliceVar(&doctorTLDs, "tld", []string{".com"}, "TLDs to test the backends on")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// doctorProbe is the outcome of testing one backend on one TLD
+type doctorProbe struct {
+	backend string
+	tld     string
+	latency time.Duration
+	// problem is empty for a healthy backend
+	problem string
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+	if err := configureWhois(cfg.Whois); err != nil {
+		return err
+	}
+
+	names := []string{"whois", "rdap", "dns"}
+	if _, err := exec.LookPath("whois"); err == nil {
+		names = append(names, "system-whois")
+	}
+	if doctorBackends != "" {
+		names = splitBackends(doctorBackends)
+	}
+
+	// Each TLD is tested with the default backends, or its configured chain
+	var probes []doctorProbe
+	for _, t := range doctorTLDs {
+		suffix, err := tld.ToASCII(strings.TrimPrefix(strings.TrimSpace(t), "."))
+		if err != nil {
+			return err
+		}
+		if _, ok := cfg.Backends[suffix]; ok {
+			continue
+		}
+		for _, name := range names {
+			probes = append(probes, doctorProbe{backend: name, tld: suffix})
+		}
+	}
+	suffixes := make([]string, 0, len(cfg.Backends))
+	for suffix := range cfg.Backends {
+		suffixes = append(suffixes, suffix)
+	}
+	sort.Strings(suffixes)
+	for _, suffix := range suffixes {
+		ascii, err := tld.ToASCII(strings.TrimPrefix(suffix, "."))
+		if err != nil {
+			return fmt.Errorf("invalid backend chain TLD %q: %w", suffix, err)
+		}
+		for _, name := range splitBackends(cfg.Backends[suffix]) {
+			probes = append(probes, doctorProbe{backend: name, tld: ascii})
+		}
+	}
+
+	// Backends are started once, however many TLDs they are tested on
+	backends := make(map[string]checker.Backend)
+	startErrs := make(map[string]error)
+	for _, p := range probes {
+		if _, ok := backends[p.backend]; ok || startErrs[p.backend] != nil {
+			continue
+		}
+		b, closeBackend, err := newSingleBackend(p.backend, cfg)
+		if err != nil {
+			startErrs[p.backend] = err
+			continue
+		}
+		defer closeBackend()
+		backends[p.backend] = b
+	}
+
+	var wg sync.WaitGroup
+	for i := range probes {
+		p := &probes[i]
+		if err := startErrs[p.backend]; err != nil {
+			p.problem = err.Error()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.latency, p.problem = probeBackend(backends[p.backend], p.tld)
+		}()
+	}
+	wg.Wait()
+
+	cmd.SilenceUsage = true
+	fmt.Printf("%s%-14s %-10s %-8s %-9s %s%s\n", bold, "BACKEND", "TLD", "STATUS", "LATENCY", "PROBLEM", reset)
+	failed := 0
+	for _, p := range probes {
+		status, latency := bGreen+"ok"+reset+"    ", p.latency.Round(time.Millisecond).String()
+		if p.problem != "" {
+			failed++
+			status = bRed + "FAIL" + reset + "  "
+		}
+		if p.latency == 0 {
+			latency = "-"
+		}
+		fmt.Printf("%-14s %-10s %s   %-9s %s\n", p.backend, tld.ToUnicode("."+p.tld), status, latency, p.problem)
+	}
+	fmt.Println()
+	if failed > 0 {
+		return fmt.Errorf("%d of %d backend checks failed", failed, len(probes))
+	}
+	fmt.Printf("All %d backend checks passed\n", len(probes))
+	return nil
+}
+
+// probeBackend checks nic.<suffix>, which registries keep registered, and a
+// random name that is not, and returns their mean latency and what went
+// wrong, if anything
+func probeBackend(b checker.Backend, suffix string) (time.Duration, string) {
+	buf := make([]byte, 6)
+	rand.Read(buf)
+	taken := "nic." + suffix
+	free := "gofindadomain-doctor-" + hex.EncodeToString(buf) + "." + suffix
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*doctorTimeout)
+	defer cancel()
+	start := time.Now()
+	rt := b.Check(ctx, taken)
+	rf := b.Check(ctx, free)
+	latency := time.Since(start) / 2
+
+	switch {
+	case rt.Error != nil:
+		return 0, fmt.Sprintf("%s: %v", taken, rt.Error)
+	case rf.Error != nil:
+		return 0, fmt.Sprintf("%s: %v", free, rf.Error)
+	case rt.Available:
+		return latency, fmt.Sprintf("reported the registered %s available", taken)
+	case !rf.Available && rf.Restriction() == "":
+		return latency, fmt.Sprintf("reported the unregistered %s taken", free)
+	}
+	return latency, ""
+}
+
+// splitBackends splits a --backend chain into its backend names
+func splitBackends(spec string) []string {
+	var names []string
+	for _, n := range strings.Split(spec, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
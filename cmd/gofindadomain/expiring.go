@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+var (
+	expiringSpec string
+	// expiringWithin is the parsed --expiring-within, 0 without one
+	expiringWithin time.Duration
+)
+
+// parseExpiringWithin parses --expiring-within, a number of days like 60d
+// or a duration like 36h
+func parseExpiringWithin() error {
+	expiringWithin = 0
+	if expiringSpec == "" {
+		return nil
+	}
+	if days, ok := strings.CutSuffix(expiringSpec, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			expiringWithin = time.Duration(n) * 24 * time.Hour
+			return nil
+		}
+	}
+	d, err := time.ParseDuration(expiringSpec)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid --expiring-within %q (use days like 60d or a duration like 36h)", expiringSpec)
+	}
+	expiringWithin = d
+	return nil
+}
+
+// expiringDays returns the days left for a taken domain expiring within
+// --expiring-within
+func expiringDays(r checker.Result) (int, bool) {
+	if expiringWithin <= 0 || r.Error != nil || r.Available {
+		return 0, false
+	}
+	return checker.Expiring(r.ExpiryDate, expiringWithin, time.Now())
+}
+
+// expiringNote flags a taken domain expiring within --expiring-within, or
+// returns ""
+func expiringNote(r checker.Result) string {
+	days, ok := expiringDays(r)
+	if !ok {
+		return ""
+	}
+	return red + "(" + checker.DaysLeft(days) + ")" + reset
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/james-see/gofindadomain/internal/rank"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+var (
+	formatSpec string
+	// formatTmpl is the parsed --format, nil without one
+	formatTmpl *template.Template
+)
+
+// formatEscapes turns the escapes shells leave alone in quotes into the
+// characters, so '{{.Domain}}\t{{.Available}}' gives TSV
+var formatEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`)
+
+// formatFuncs are the functions --format templates can call besides the
+// text/template builtins
+var formatFuncs = template.FuncMap{
+	"state":   entryState,
+	"unicode": tld.ToUnicode,
+	"join":    strings.Join,
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// parseFormat parses --format, which is executed for each result with the
+// rank.Entry, so every checker.Result field is available as well as Score,
+// Price and the rest
+func parseFormat() error {
+	if formatSpec == "" {
+		return nil
+	}
+	tmpl, err := template.New("format").Funcs(formatFuncs).Option("missingkey=error").Parse(formatEscapes.Replace(formatSpec))
+	if err != nil {
+		return fmt.Errorf("invalid --format: %w", err)
+	}
+	// Unknown fields only show when the template runs
+	if err := tmpl.Execute(io.Discard, rank.Entry{}); err != nil {
+		return fmt.Errorf("invalid --format: %w", err)
+	}
+	formatTmpl = tmpl
+	return nil
+}
+
+// printFormatted prints e through the --format template, on a line of its
+// own unless the template ends one itself
+func printFormatted(e rank.Entry) {
+	var out strings.Builder
+	if err := formatTmpl.Execute(&out, e); err != nil {
+		fmt.Fprintf(os.Stderr, "[%sformat%s] %s: %v\n", red, reset, e.Domain, err)
+		return
+	}
+	line := out.String()
+	if !strings.HasSuffix(line, "\n") {
+		line += "\n"
+	}
+	fmt.Print(line)
+}
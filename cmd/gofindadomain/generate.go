@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+// relatedWords is how many related words are asked of Datamuse
+const relatedWords = 50
+
+var (
+	generateStrategies   []string
+	generateLimit        int
+	generateMinPronounce float64
+	generateRelated      bool
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate <word>",
+	Short: "Generate keyword candidates from a base word",
+	Long: "Generate keyword candidates from a base word, rated by pronounceability and dictionary words.\n" +
+		"--min-score also drops candidates whose label scores lower.\n" +
+		"With -e or -E the candidates are checked for availability instead of printed; -q prints the bare labels\n" +
+		"for piping into check.",
+	Args: cobra.ExactArgs(1),
+	RunE: runGenerate,
+}
+
+func init() {
+	generateCmd.Flags().StringSliceVarP(&generateStrategies, "strategy", "s", []string{"anagram"}, "Strategies to use: "+strings.Join(generate.Strategies(), ", ")+", or all")
+	generateCmd.Flags().BoolVar(&generateRelated, "related", false, "Add words related in meaning from the Datamuse API (ship -> boat, vessel, cargo); alone it replaces the default strategy")
+	generateCmd.Flags().IntVarP(&generateLimit, "limit", "n", 20, "Maximum number of candidates (0 for all)")
+	generateCmd.Flags().Float64Var(&generateMinPronounce, "min-pronounce", 0.5, "Minimum pronounceability (0-1)")
+	generateCmd.Flags().IntVar(&labelMin, "min-length", 0, "Minimum candidate length (0 for none)")
+	generateCmd.Flags().IntVar(&labelMax, "max-length", 0, "Maximum candidate length (0 for none)")
+	generateCmd.Flags().StringVarP(&singleTLD, "tld", "e", "", "Check candidates in a single TLD (e.g., .com)")
+	generateCmd.Flags().StringVarP(&tldFile, "tld-file", "E", "", "Check candidates in TLDs from a file")
+	addTLDFilterFlags(generateCmd)
+	addCheckFlags(generateCmd, "check-strategy")
+	rootCmd.AddCommand(generateCmd)
+}
+
+func runGenerate(cmd *cobra.Command, args []string) error {
+	filter := generate.Filter{MinPronounce: generateMinPronounce, MinScore: minScore, MinLength: labelMin, MaxLength: labelMax}
+	// --related on its own asks for related words only
+	var names []string
+	if !generateRelated || cmd.Flags().Changed("strategy") {
+		names = generateStrategies
+	}
+	sets, err := generate.Variants(args[0], names)
+	if err != nil {
+		return err
+	}
+	if generateRelated {
+		words, err := generate.RelatedWords(context.Background(), args[0], relatedWords)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, generate.Labels{Strategy: generate.Related, Labels: words})
+	}
+	candidates := generate.Rank(args[0], sets, filter, generateLimit)
+
+	if singleTLD == "" && tldFile == "" {
+		for _, c := range candidates {
+			if quietOut {
+				fmt.Println(c.Label)
+				continue
+			}
+			fmt.Printf("%-24s %s%.2f%s  score %-3d %s\n", c.Label, orange, c.Rating(), reset, c.Score, joinNotes(c.Strategy, brandWarning(c.Label)))
+		}
+		return nil
+	}
+
+	tlds, tldSource, err := resolveTLDs(singleTLD, tldFile)
+	if err != nil {
+		return err
+	}
+
+	var domains []string
+	for _, c := range candidates {
+		domains = append(domains, keywordDomains(c.Label, tlds)...)
+	}
+
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	return sess.check(cmd, domains, checkInputs{keyword: args[0], tldSource: tldSource, tlds: tlds})
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historySince     string
+	historyAvailOnly bool
+	historyRun       int64
+	historyLimit     int
+	historyJSON      bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show results recorded by earlier runs",
+	Long: "Every check run records its results in ~/.config/gofindadomain/history.db.\n" +
+		"history lists them oldest first; `history runs` lists the runs themselves.",
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+var historyRunsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "List recorded runs",
+	Args:  cobra.NoArgs,
+	RunE:  runHistoryRuns,
+}
+
+func init() {
+	historyCmd.Flags().StringVarP(&keyword, "keyword", "k", "", "Only results for this keyword")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Only results since a date (2006-01-02) or age (36h, 7d)")
+	historyCmd.Flags().BoolVarP(&historyAvailOnly, "available-only", "x", false, "Only available domains")
+	historyCmd.Flags().Int64Var(&historyRun, "run", 0, "Only results of this run, by the ID shown by history runs")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 0, "Only the newest n results (0 for all)")
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Print results as JSON lines")
+	historyRunsCmd.Flags().StringVar(&historySince, "since", "", "Only runs since a date (2006-01-02) or age (36h, 7d)")
+
+	historyCmd.AddCommand(historyRunsCmd)
+	rootCmd.AddCommand(historyCmd)
+}
+
+// parseSince reads --since as a date or as an age before now; days ("7d")
+// are accepted in addition to Go durations
+func parseSince(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Now().AddDate(0, 0, -n), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use a date like 2006-01-02 or an age like 36h or 7d)", s)
+	}
+	return time.Now().Add(-d), nil
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	since, err := parseSince(historySince)
+	if err != nil {
+		return err
+	}
+	db, err := history.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	records, err := db.Records(history.Filter{
+		Keyword:       keyword,
+		Since:         since,
+		AvailableOnly: historyAvailOnly,
+		RunID:         historyRun,
+		Limit:         historyLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if historyJSON {
+		enc := json.NewEncoder(os.Stdout)
+		for _, rec := range records {
+			enc.Encode(jsonRecord(rec))
+		}
+		return nil
+	}
+	if len(records) == 0 {
+		fmt.Println("No recorded results match.")
+		return nil
+	}
+	for _, rec := range records {
+		printRecord(rec)
+	}
+	return nil
+}
+
+// printRecord prints a stored result like a live one, prefixed by when it
+// was checked
+func printRecord(rec history.Record) {
+	when := rec.Checked.Local().Format("2006-01-02 15:04")
+	switch {
+	case rec.Error != "":
+		fmt.Printf("%s [%serror%s] %s - %s\n", when, red, reset, rec.Domain, rec.Error)
+	case rec.Available:
+		fmt.Printf("%s [%savail%s] %s\n", when, bGreen, reset, rec.Domain)
+	case rec.State != "":
+		fmt.Printf("%s [%s%s%s] %s\n", when, orange, rec.State, reset, rec.Domain)
+	case rec.ExpiryDate != "":
+		fmt.Printf("%s [%staken%s] %s - Exp Date: %s%s%s\n", when, red, reset, rec.Domain, orange, rec.ExpiryDate, reset)
+	default:
+		fmt.Printf("%s [%staken%s] %s\n", when, red, reset, rec.Domain)
+	}
+}
+
+// historyJSONRecord is a stored result as printed by history --json
+type historyJSONRecord struct {
+	Run         int64     `json:"run"`
+	Checked     time.Time `json:"checked"`
+	Domain      string    `json:"domain"`
+	Keyword     string    `json:"keyword,omitempty"`
+	Available   bool      `json:"available"`
+	Premium     bool      `json:"premium,omitempty"`
+	Reserved    bool      `json:"reserved,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	ExpiryDate  string    `json:"expiry_date,omitempty"`
+	CreatedDate string    `json:"created_date,omitempty"`
+	Registrar   string    `json:"registrar,omitempty"`
+}
+
+func jsonRecord(rec history.Record) historyJSONRecord {
+	return historyJSONRecord{
+		Run:         rec.RunID,
+		Checked:     rec.Checked,
+		Domain:      rec.Domain,
+		Keyword:     rec.Keyword,
+		Available:   rec.Available,
+		Premium:     rec.State == "premium",
+		Reserved:    rec.State == "reserved",
+		Error:       rec.Error,
+		ExpiryDate:  rec.ExpiryDate,
+		CreatedDate: rec.CreatedDate,
+		Registrar:   rec.Registrar,
+	}
+}
+
+func runHistoryRuns(cmd *cobra.Command, args []string) error {
+	since, err := parseSince(historySince)
+	if err != nil {
+		return err
+	}
+	db, err := history.Open()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	runs, err := db.Runs(since)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Println("No recorded runs.")
+		return nil
+	}
+	for _, run := range runs {
+		status := ""
+		if run.Finished.IsZero() {
+			status = " " + orange + "(interrupted)" + reset
+		}
+		fmt.Printf("%s%4d%s  %s  %d checked, %s%d available%s  %s%s\n", bold, run.ID, reset,
+			run.Started.Local().Format("2006-01-02 15:04"), run.Checked, bGreen, run.Available, reset, run.Command, status)
+	}
+	return nil
+}
+
+// runRecorder stores a check run's results in the history. A nil
+// *runRecorder records nothing.
+type runRecorder struct {
+	db *history.DB
+	id int64
+}
+
+// startRecording opens the history and records the start of a run. The
+// history only complements the printed results, so failures are reported
+// and the run goes on unrecorded.
+func startRecording(in checkInputs) *runRecorder {
+	if noHistory {
+		return nil
+	}
+	db, err := history.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%shistory%s] %v\n", red, reset, err)
+		return nil
+	}
+	command := strings.Join(append([]string{"gofindadomain"}, os.Args[1:]...), " ")
+	id, err := db.StartRun(command, in.keyword, backendName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%shistory%s] %v\n", red, reset, err)
+		db.Close()
+		return nil
+	}
+	return &runRecorder{db: db, id: id}
+}
+
+func (r *runRecorder) add(keyword string, result checker.Result) {
+	if r == nil {
+		return
+	}
+	if err := r.db.Add(r.id, keyword, result); err != nil {
+		fmt.Fprintf(os.Stderr, "[%shistory%s] %v\n", red, reset, err)
+	}
+}
+
+// noteDrop fills in the re-registration date of a taken domain that RDAP
+// gave none for, when an earlier run saw the domain free or registered
+// before its current creation date
+func (r *runRecorder) noteDrop(result *checker.Result) {
+	if r == nil || result.Error != nil || result.Available || result.Reregistered != "" || len(result.CreatedDate) < 10 {
+		return
+	}
+	created := result.CreatedDate[:10]
+	if _, err := time.Parse("2006-01-02", created); err != nil {
+		return
+	}
+	dropped, err := r.db.Dropped(result.Domain, created)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%shistory%s] %v\n", red, reset, err)
+		return
+	}
+	if dropped {
+		result.Reregistered = created
+	}
+}
+
+// finish marks the run complete; interrupted runs are closed without it
+func (r *runRecorder) finish(completed bool) {
+	if r == nil {
+		return
+	}
+	if completed {
+		if err := r.db.FinishRun(r.id); err != nil {
+			fmt.Fprintf(os.Stderr, "[%shistory%s] %v\n", red, reset, err)
+		}
+	}
+	r.db.Close()
+}
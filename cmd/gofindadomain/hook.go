@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/hook"
+	"github.com/james-see/gofindadomain/internal/notify"
+)
+
+// resultHook applies the configured hook script to results and delivers the
+// notifications it requests. A nil *resultHook keeps every result.
+type resultHook struct {
+	script   *hook.Script
+	notifier *notify.Notifier
+}
+
+// loadHook loads the hook script of cfg and the sink its notifications go
+// to: hooks.notifier, where a bare name is a notifier plugin
+func loadHook(cfg *config.Config) (*resultHook, error) {
+	if cfg.Hooks.Script == "" {
+		return nil, nil
+	}
+
+	script, err := hook.Load(cfg.Hooks.Script)
+	if err != nil {
+		return nil, err
+	}
+	h := &resultHook{script: script}
+
+	if spec := cfg.Hooks.Notifier; spec != "" {
+		if !strings.Contains(spec, ":") {
+			spec = "plugin:" + spec
+		}
+		sink, err := notify.Parse(spec, cfg.Notify.SMTP)
+		if err != nil {
+			return nil, err
+		}
+		h.notifier = &notify.Notifier{Sinks: []notify.Sink{sink}}
+	}
+
+	return h, nil
+}
+
+func (h *resultHook) run(ctx context.Context, r checker.Result, quality int) hook.Outcome {
+	if h == nil {
+		return hook.Outcome{Keep: true, Score: quality}
+	}
+
+	outcome, err := h.script.Run(r, quality)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[%shook%s] %s - %v\n", red, reset, r.Domain, err)
+		return outcome
+	}
+
+	if outcome.Notify {
+		if h.notifier == nil {
+			fmt.Fprintf(os.Stderr, "[%shook%s] %s - notify requested but no notifier configured\n", red, reset, r.Domain)
+		} else if err := h.notifier.Send(ctx, notify.Notification{
+			Domain:     r.Domain,
+			Available:  r.Available,
+			ExpiryDate: r.ExpiryDate,
+			Message:    outcome.Note,
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "[%shook%s] %s - %v\n", red, reset, r.Domain, err)
+		}
+	}
+
+	return outcome
+}
+
+func (h *resultHook) close() {
+	if h != nil {
+		h.notifier.Close()
+	}
+}
+
+// annotation formats the note a hook attached to a result
+func annotation(o hook.Outcome) string {
+	if o.Note == "" {
+		return ""
+	}
+	return "(" + o.Note + ")"
+}
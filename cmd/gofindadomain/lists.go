@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/store"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(newListCmd(store.Shortlist, "domains you are considering"))
+	rootCmd.AddCommand(newListCmd(store.Watchlist, "domains you are waiting on"))
+}
+
+// newListCmd builds the command managing a saved domain list with its notes and tags
+func newListCmd(name, what string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: fmt.Sprintf("Manage the %s of %s", name, what),
+		Long: fmt.Sprintf("Manage the %s of %s, with free-text notes and tags.\n"+
+			"The %s is stored in ~/.config/gofindadomain/%s.json, or on the shared\n"+
+			"instance set as store.url in the config.", name, what, name, name),
+	}
+
+	var note string
+	var tags []string
+	add := &cobra.Command{
+		Use:   "add <domain>...",
+		Short: "Add domains, optionally with a note and tags",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateList(name, func(l *store.List) error {
+				for _, d := range args {
+					e := l.Add(d)
+					if note != "" {
+						e.Note = note
+					}
+					e.AddTags(tags...)
+				}
+				return nil
+			})
+		},
+	}
+	add.Flags().StringVar(&note, "note", "", "Note to attach (e.g. \"pitch to client\")")
+	add.Flags().StringSliceVarP(&tags, "tag", "t", nil, "Tags to attach (e.g. backup)")
+
+	remove := &cobra.Command{
+		Use:   "remove <domain>...",
+		Short: "Remove domains",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateList(name, func(l *store.List) error {
+				for _, d := range args {
+					if !l.Remove(d) {
+						return fmt.Errorf("%s is not on the %s", d, name)
+					}
+				}
+				return nil
+			})
+		},
+	}
+
+	noteCmd := &cobra.Command{
+		Use:   "note <domain> [text...]",
+		Short: "Set a domain's note (no text clears it)",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateList(name, func(l *store.List) error {
+				e, ok := l.Get(args[0])
+				if !ok {
+					return fmt.Errorf("%s is not on the %s", args[0], name)
+				}
+				e.Note = strings.Join(args[1:], " ")
+				return nil
+			})
+		},
+	}
+
+	tag := &cobra.Command{
+		Use:   "tag <domain> <tag>...",
+		Short: "Add tags to a domain",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateList(name, func(l *store.List) error {
+				e, ok := l.Get(args[0])
+				if !ok {
+					return fmt.Errorf("%s is not on the %s", args[0], name)
+				}
+				e.AddTags(args[1:]...)
+				return nil
+			})
+		},
+	}
+
+	untag := &cobra.Command{
+		Use:   "untag <domain> <tag>...",
+		Short: "Remove tags from a domain",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateList(name, func(l *store.List) error {
+				e, ok := l.Get(args[0])
+				if !ok {
+					return fmt.Errorf("%s is not on the %s", args[0], name)
+				}
+				e.RemoveTags(args[1:]...)
+				return nil
+			})
+		},
+	}
+
+	var filterTag string
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "List domains with their notes and tags",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			b, err := storeBackend()
+			if err != nil {
+				return err
+			}
+			l, err := store.Open(b, name)
+			if err != nil {
+				return err
+			}
+			entries := l.Entries(filterTag)
+			if len(entries) == 0 {
+				fmt.Printf("The %s is empty\n", name)
+				return nil
+			}
+			for _, e := range entries {
+				printListEntry(e)
+			}
+			return nil
+		},
+	}
+	list.Flags().StringVarP(&filterTag, "tag", "t", "", "Only list domains with this tag")
+
+	cmd.AddCommand(add, remove, noteCmd, tag, untag, list)
+	return cmd
+}
+
+// storeBackend returns the list store selected in the config
+func storeBackend() (store.Backend, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return store.New(cfg.Store)
+}
+
+// updateList applies fn to the named list and saves it
+func updateList(name string, fn func(*store.List) error) error {
+	b, err := storeBackend()
+	if err != nil {
+		return err
+	}
+	return store.Update(b, name, fn)
+}
+
+func printListEntry(e *store.Entry) {
+	line := bold + e.Domain + reset
+	if len(e.Tags) > 0 {
+		line += " " + orange + "[" + strings.Join(e.Tags, ", ") + "]" + reset
+	}
+	if e.Note != "" {
+		line += " - " + e.Note
+	}
+	fmt.Println(line)
+}
@@ -1,17 +1,28 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	gofindadomain "github.com/james-see/gofindadomain"
+	"github.com/james-see/gofindadomain/internal/branding"
 	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/namecheap"
+	"github.com/james-see/gofindadomain/internal/plugin"
+	"github.com/james-see/gofindadomain/internal/rank"
+	"github.com/james-see/gofindadomain/internal/report"
+	"github.com/james-see/gofindadomain/internal/route53"
 	"github.com/james-see/gofindadomain/internal/tld"
-	"github.com/james-see/gofindadomain/internal/tui"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 const banner = `
@@ -22,8 +33,8 @@ const banner = `
                    Domain Availability Checker
 `
 
-// ANSI colors
-const (
+// ANSI colors, overridable through branding config
+var (
 	reset  = "\033[0m"
 	red    = "\033[0;31m"
 	green  = "\033[0;32m"
@@ -33,110 +44,394 @@ const (
 	bRed   = "\033[1;31m"
 )
 
+// Set by goreleaser via ldflags
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
 var (
 	keyword     string
+	keywords    []string
 	singleTLD   string
 	tldFile     string
 	onlyAvail   bool
 	updateTLD   bool
 	interactive bool
 	concurrency int
+	fixedConc   bool
+	queryOrder  string
+	backendName string
+	configPath  string
+	proxyAddr   string
+	whoisServer string
+	manifestOut string
+	minScore    int
+	maxPrice    float64
+	pricingName string
+	sortBy      string
+	rankOut     bool
+	groupBy     string
+	firstN      int
+	failNone    bool
+	failOnError bool
+	lengthRep   bool
+	probeParked bool
+	probeSale   bool
+	probeUsage  bool
+	enrichWith  []string
+	jsonOut     bool
+	quietOut    bool
+	strategy    string
+	verify      bool
+	showDetails bool
+	rateLimit   float64
+	retries     int
+	retryDelay  time.Duration
+	resume      bool
+	notifySinks []string
+	includeTLDs []string
+	excludeTLDs []string
+	metricsAddr string
+	noHistory   bool
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "gofindadomain",
 	Short: "Domain availability checker",
 	Long:  banner + "\nCheck domain availability across multiple TLDs using whois lookups.",
+	Args:  cobra.MaximumNArgs(1),
 	RunE:  run,
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&keyword, "keyword", "k", "", "Keyword to check (e.g., mycompany)")
-	rootCmd.Flags().StringVarP(&singleTLD, "tld", "e", "", "Single TLD to check (e.g., .com)")
-	rootCmd.Flags().StringVarP(&tldFile, "tld-file", "E", "", "File containing TLDs to check")
-	rootCmd.Flags().BoolVarP(&onlyAvail, "not-registered", "x", false, "Only show available domains")
+	rootCmd.Version = fmt.Sprintf("%s (commit %s, built %s)", version, commit, date)
+
+	// The flags of the single-command CLI still work but are left out of
+	// the help in favor of the subcommands
+	addKeywordFlags(rootCmd)
 	rootCmd.Flags().BoolVar(&updateTLD, "update-tld", false, "Update TLD list from IANA")
 	rootCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Launch interactive TUI mode")
-	rootCmd.Flags().IntVarP(&concurrency, "concurrency", "c", 30, "Number of concurrent checks")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file (default ~/.config/gofindadomain/config.json)")
+	rootCmd.PersistentFlags().StringVar(&proxyAddr, "proxy", "", "Send whois and RDAP queries through a proxy: socks5://host:port or http://host:port (default $HTTPS_PROXY)")
+	rootCmd.PersistentFlags().StringVar(&whoisServer, "whois-server", "", "Send every whois query to this server (host or host:port) instead of the TLD's registry")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Named profile from the config file supplying TLDs, concurrency and filters")
+	rootCmd.PersistentPreRunE = applyProfile
+	addCheckFlags(rootCmd, "strategy")
+	rootCmd.Flags().VisitAll(func(f *pflag.Flag) { f.Hidden = true })
 }
 
+// Exit codes besides 0 and 1 (any other error), for scripts
+const (
+	// exitNoneAvailable is returned with --fail-if-none-available
+	exitNoneAvailable = 2
+	// exitCheckErrors is returned with --fail-on-error
+	exitCheckErrors = 3
+)
+
+// exitError ends the program with code instead of 1
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
+		var exit *exitError
+		if errors.As(err, &exit) {
+			os.Exit(exit.code)
+		}
 		os.Exit(1)
 	}
 }
 
+// run keeps the flags of the single-command CLI working, handing them to
+// the subcommands that replaced them
 func run(cmd *cobra.Command, args []string) error {
-	// Check for whois
-	if _, err := exec.LookPath("whois"); err != nil {
-		return fmt.Errorf("whois not installed. You must install whois to use this tool")
+	switch {
+	case updateTLD:
+		if len(keywords) > 0 || singleTLD != "" || tldFile != "" || onlyAvail || interactive {
+			return fmt.Errorf("--update-tld cannot be used with other flags")
+		}
+		deprecated("--update-tld", "update-tlds")
+		return runUpdateTLDs()
+	case interactive:
+		deprecated("-i", "tui")
+		return runTUI()
+	case len(keywords) == 0 && len(args) == 0:
+		return cmd.Help()
 	}
+	deprecated("checking from the root command", "check")
+	return runCheck(cmd, args)
+}
 
-	// Handle --update-tld
-	if updateTLD {
-		if keyword != "" || singleTLD != "" || tldFile != "" || onlyAvail || interactive {
-			return fmt.Errorf("--update-tld cannot be used with other flags")
+// deprecated warns that old is replaced by the command named replacement
+func deprecated(old, replacement string) {
+	fmt.Fprintf(os.Stderr, "[%sdeprecated%s] %s is replaced by 'gofindadomain %s' and will be removed in a future release\n", orange, reset, old, replacement)
+}
+
+// readNames reads one name per line, skipping blank lines and # comments.
+// Lines containing a dot are full domains; the rest are keywords.
+func readNames(r io.Reader) (keywords, domains []string, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.Contains(line, ".") {
+			domain, err := tld.ToASCII(strings.TrimSuffix(line, "."))
+			if err != nil {
+				return nil, nil, err
+			}
+			domains = append(domains, domain)
+		} else {
+			keywords = append(keywords, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read stdin: %w", err)
+	}
+	return keywords, domains, nil
+}
+
+// configureWhois hands the saved whois server registry, the configured
+// availability patterns, the server overrides, the proxy and the rate
+// limits to the checker. A missing or unreadable registry
+// only means servers are looked up at IANA.
+func configureWhois(cfg config.Whois) error {
+	if r, err := tld.LoadServers(); err != nil {
+		fmt.Fprintf(os.Stderr, "[%swhois%s] %v\n", red, reset, err)
+	} else {
+		checker.SetWhoisServers(r.Servers)
+	}
+
+	// --whois-server replaces the per-TLD overrides of the config
+	overrides := make(map[string]string, len(cfg.Servers))
+	if whoisServer == "" {
+		for suffix, server := range cfg.Servers {
+			ascii, err := tld.ToASCII(strings.TrimPrefix(suffix, "."))
+			if err != nil {
+				return fmt.Errorf("invalid whois server TLD %q: %w", suffix, err)
+			}
+			overrides[ascii] = server
 		}
-		fmt.Println("Fetching TLD data from IANA...")
-		if err := tld.UpdateTLDFile("tlds.txt"); err != nil {
+	}
+	checker.SetWhoisOverrides(whoisServer, overrides)
+
+	checker.SetRateLimit(rateLimit)
+	checker.SetServerRateLimits(cfg.RateLimits)
+	checker.SetAdaptiveConcurrency(!fixedConc)
+	order := queryOrder
+	// Stopping at --first only keeps the order of preference when queries follow it
+	if order == "" && firstN > 0 {
+		order = checker.OrderAsGiven
+	}
+	if order != "" {
+		if err := checker.SetQueryOrder(order); err != nil {
 			return err
 		}
-		fmt.Println("TLDs have been saved to tlds.txt")
-		return nil
 	}
 
-	// Interactive mode
-	if interactive {
-		tlds := loadTLDs()
-		return tui.Run(tlds)
+	proxy := proxyAddr
+	if proxy == "" {
+		proxy = cfg.Proxy
+	}
+	if err := checker.SetProxy(proxy); err != nil {
+		return err
 	}
 
-	// CLI mode - validate args
-	if keyword == "" {
-		return fmt.Errorf("keyword is required (-k). Use -h for help")
+	if cfg.Patterns != "" {
+		return checker.LoadPatterns(cfg.Patterns)
 	}
+	return nil
+}
 
-	if singleTLD != "" && tldFile != "" {
-		return fmt.Errorf("you can only specify one of -e or -E options")
+// newBackend resolves the --backend flag, where a comma-separated list such
+// as "rdap,whois,dns" builds a fallback chain, and the per-TLD chains of the
+// config, which take precedence for their TLDs. The returned func releases
+// any resources held by the backends.
+func newBackend(name string, cfg *config.Config) (checker.Backend, func(), error) {
+	// Backends named in several chains are started once
+	built := make(map[string]checker.Backend)
+	var closers []func()
+	closeAll := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
+	chain := func(spec string) (checker.Backend, error) {
+		var c checker.ChainBackend
+		for _, n := range strings.Split(spec, ",") {
+			n = strings.TrimSpace(n)
+			b, ok := built[n]
+			if !ok {
+				single, closeBackend, err := newSingleBackend(n, cfg)
+				if err != nil {
+					return nil, err
+				}
+				b = checker.NamedBackend{Name: n, Backend: single}
+				built[n] = b
+				closers = append(closers, closeBackend)
+			}
+			c = append(c, b)
+		}
+		if len(c) == 1 {
+			return c[0], nil
+		}
+		return c, nil
 	}
 
-	if singleTLD == "" && tldFile == "" {
-		return fmt.Errorf("either -e or -E option is required")
+	backend, err := chain(name)
+	if err != nil {
+		closeAll()
+		return nil, nil, err
+	}
+	if len(cfg.Backends) == 0 {
+		return backend, closeAll, nil
 	}
 
-	// Print banner
-	fmt.Print(banner)
-	fmt.Println()
+	byTLD := checker.TLDBackend{Default: backend, ByTLD: make(map[string]checker.Backend)}
+	for suffix, spec := range cfg.Backends {
+		b, err := chain(spec)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("invalid backend chain for %s: %w", suffix, err)
+		}
+		ascii, err := tld.ToASCII(strings.TrimPrefix(suffix, "."))
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("invalid backend chain TLD %q: %w", suffix, err)
+		}
+		byTLD.ByTLD[ascii] = b
+	}
+	return byTLD, closeAll, nil
+}
 
-	// Load TLDs
-	var tlds []string
-	if singleTLD != "" {
-		// Ensure TLD starts with dot
-		if !strings.HasPrefix(singleTLD, ".") {
-			singleTLD = "." + singleTLD
+// newSingleBackend resolves one backend name
+func newSingleBackend(name string, cfg *config.Config) (checker.Backend, func(), error) {
+	if pluginName, ok := strings.CutPrefix(name, "plugin:"); ok {
+		p, err := plugin.StartBackend(pluginName)
+		if err != nil {
+			return nil, nil, err
 		}
-		tlds = []string{singleTLD}
-	} else {
-		var err error
-		tlds, err = tld.LoadTLDsFromFile(tldFile)
+		return p, func() { p.Close() }, nil
+	}
+
+	switch name {
+	case "whois":
+		return checker.WhoisBackend{}, func() {}, nil
+	case "system-whois":
+		if _, err := exec.LookPath("whois"); err != nil {
+			return nil, nil, fmt.Errorf("whois not installed. Install whois or use --backend whois")
+		}
+		return checker.SystemWhoisBackend{}, func() {}, nil
+	case "rdap":
+		return checker.RDAPBackend{}, func() {}, nil
+	case "dns":
+		return checker.DNSBackend{}, func() {}, nil
+	case "namecheap":
+		c, err := newNamecheapClient(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return namecheap.Backend{Client: c}, func() {}, nil
+	case "route53":
+		b, err := route53.New(context.Background())
 		if err != nil {
-			return fmt.Errorf("TLD file %s not found: %w", tldFile, err)
+			return nil, nil, err
 		}
+		return b, func() {}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown backend %q (use whois, system-whois, rdap, dns, namecheap, route53 or plugin:<name>)", name)
 	}
+}
 
-	// Build domain list
-	var domains []string
-	for _, t := range tlds {
-		domains = append(domains, keyword+t)
+// applyBranding overrides the CLI colors from config and returns the banner to
+// print. NO_COLOR (https://no-color.org) leaves only bold.
+func applyBranding(b config.Branding) (string, error) {
+	if os.Getenv("NO_COLOR") != "" {
+		red, green, orange = "", "", ""
+		bGreen, bRed = bold, bold
+		return branding.Banner(b, banner, version)
 	}
 
-	// Check domains
-	ctx := context.Background()
-	checker.CheckDomainsWithCallback(ctx, domains, concurrency, func(result checker.Result) {
-		printResult(result, onlyAvail)
-	})
+	overrides := []struct {
+		hex    string
+		target *string
+	}{
+		{b.Colors.Available, &bGreen},
+		{b.Colors.Secondary, &bRed},
+		{b.Colors.Accent, &orange},
+	}
+	for _, o := range overrides {
+		if o.hex == "" {
+			continue
+		}
+		code, ok := branding.ANSI(o.hex)
+		if !ok {
+			return "", fmt.Errorf("invalid branding color %q (want #RRGGBB)", o.hex)
+		}
+		*o.target = code
+	}
+	if b.Colors.Secondary != "" {
+		red = bRed
+	}
 
-	return nil
+	return branding.Banner(b, banner, version)
+}
+
+// resolveTLDs loads the TLDs selected with -e or -E, narrowed by
+// --include-tlds and --exclude-tlds, and describes their source
+func resolveTLDs(single, file string) ([]string, string, error) {
+	tlds, source, err := loadSelectedTLDs(single, file)
+	if err != nil || (len(includeTLDs) == 0 && len(excludeTLDs) == 0) {
+		return tlds, source, err
+	}
+	if tlds, err = tld.Filter(tlds, includeTLDs, excludeTLDs); err != nil {
+		return nil, "", err
+	}
+	if len(tlds) == 0 {
+		return nil, "", fmt.Errorf("no TLDs of %s are left after --include-tlds and --exclude-tlds", source)
+	}
+	return tlds, source, nil
+}
+
+// loadSelectedTLDs loads the TLDs selected with -e, -E or a profile
+func loadSelectedTLDs(single, file string) ([]string, string, error) {
+	if single != "" && file != "" {
+		return nil, "", fmt.Errorf("you can only specify one of -e or -E options")
+	}
+
+	if single == "" && file == "" {
+		if len(profileTLDs) > 0 {
+			return profileTLDs, "profile " + profileName, nil
+		}
+		return nil, "", fmt.Errorf("either -e or -E option is required")
+	}
+
+	if single != "" {
+		// Ensure TLD starts with dot
+		if !strings.HasPrefix(single, ".") {
+			single = "." + single
+		}
+		t, err := tld.ToASCII(single)
+		if err != nil {
+			return nil, "", err
+		}
+		return []string{t}, single, nil
+	}
+
+	tlds, err := tld.LoadTLDsFromFile(file)
+	if err != nil {
+		return nil, "", fmt.Errorf("TLD file %s not found: %w", file, err)
+	}
+	return tlds, file, nil
 }
 
 func loadTLDs() []string {
@@ -148,24 +443,219 @@ func loadTLDs() []string {
 	return tld.LoadTLDsFromString(gofindadomain.EmbeddedTLDs)
 }
 
-func printResult(r checker.Result, showOnlyAvail bool) {
+// loadSuffixes returns the second-level public suffixes (.co.uk, .com.au, ...)
+func loadSuffixes() []string {
+	if suffixes, err := tld.LoadTLDsFromFile("suffixes.txt"); err == nil && len(suffixes) > 0 {
+		return suffixes
+	}
+	return tld.LoadTLDsFromString(gofindadomain.EmbeddedSuffixes)
+}
+
+// printLengthReport prints the available domains grouped by label length
+func printLengthReport(r *report.LengthReport) {
+	fmt.Printf("\n%sAvailable by length%s\n", bold, reset)
+	for _, t := range r.Tiers() {
+		var counts []string
+		for _, tld := range t.TLDs() {
+			counts = append(counts, fmt.Sprintf("%s %d", tld, t.PerTLD[tld]))
+		}
+		fmt.Printf("  %-4s %s%4d%s  %s\n", t.Name, bGreen, len(t.Domains), reset, strings.Join(counts, ", "))
+		for _, d := range t.Domains {
+			fmt.Printf("         %s\n", d)
+		}
+	}
+}
+
+// formatRegistrant renders a registrant as "Org (CC)", "Org" or "CC"
+func formatRegistrant(org, country string) string {
+	switch {
+	case org != "" && country != "":
+		return org + " (" + country + ")"
+	case org != "":
+		return org
+	default:
+		return country
+	}
+}
+
+// registrantLabel renders the visible registrant details and whether the
+// rest of the contact is redacted, or "" when there is nothing to say
+func registrantLabel(org, country string, private bool) string {
+	label := formatRegistrant(org, country)
+	switch {
+	case !private:
+		return label
+	case label == "":
+		return "redacted for privacy"
+	}
+	return label + ", contact redacted"
+}
+
+// shown reports whether printResult prints e
+func shown(e rank.Entry, showOnlyAvail bool) bool {
+	r := e.Result
+	switch {
+	case onlyRegistered && r.Error == nil && r.Available:
+		return false
+	case !showOnlyAvail || r.Error != nil || r.Available:
+		return true
+	case r.Restriction() != "":
+		return r.Premium
+	}
+	return r.Parked || r.ForSale || r.Dropping()
+}
+
+func printResult(e rank.Entry, showOnlyAvail bool, note string) {
+	r := e.Result
+	if note != "" {
+		note = " " + note
+	}
+
 	if r.Error != nil {
-		fmt.Printf("[%serror%s] %s - %v\n", red, reset, r.Domain, r.Error)
+		fmt.Printf("[%serror%s] %s - %v%s\n", red, reset, r.Domain, r.Error, note)
 		return
 	}
 
 	if r.Available {
-		fmt.Printf("[%savail%s] %s\n", bGreen, reset, r.Domain)
+		// Certificates and snapshots mean the domain was used before, for better or worse
+		if r.Certs != nil {
+			certs := r.Certs.String()
+			if r.Certs.Count > 0 {
+				certs = orange + certs + reset
+			}
+			note = " - Certs: " + certs + note
+		}
+		if r.Archive != nil {
+			archive := r.Archive.String()
+			if r.Archive.Months > 0 {
+				archive = orange + archive + reset
+			}
+			note = " - Wayback: " + archive + note
+		}
+		if r.Reputation != nil {
+			rep := r.Reputation.String()
+			if !r.Reputation.Clean() {
+				rep = red + rep + reset
+			}
+			note = " - Reputation: " + rep + note
+		}
+		if len(r.Trademarks) > 0 {
+			tm := r.Trademarks[0].String()
+			if more := len(r.Trademarks) - 1; more > 0 {
+				tm += fmt.Sprintf(" +%d more", more)
+			}
+			note = " - Trademark: " + red + tm + reset + note
+		}
+		// A failed register search must not pass for a clean name
+		if r.TrademarkError != nil {
+			note = " - Trademark search: " + orange + r.TrademarkError.Error() + reset + note
+		}
+		// Guesses are flagged; --verify confirms or corrects them
+		if r.Confidence == checker.ConfidenceLow {
+			note = " " + orange + "(low confidence)" + reset + note
+		}
+		if e.HasPrice {
+			price := e.FormatPrice()
+			if renew := e.FormatRenew(); renew != "" && renew != price {
+				price += " (renews " + renew + ")"
+			}
+			if e.BuyURL != "" {
+				note = " - Buy: " + e.BuyURL + note
+			}
+			fmt.Printf("[%savail%s] %s - Score: %d - Price: %s%s%s%s\n", bGreen, reset, r.Domain, e.Score, orange, price, reset, note)
+		} else {
+			fmt.Printf("[%savail%s] %s - Score: %d%s\n", bGreen, reset, r.Domain, e.Score, note)
+		}
+		return
+	}
+
+	// Premium names can still be bought, so they stay visible with -x
+	if restriction := r.Restriction(); restriction != "" {
+		if showOnlyAvail && !r.Premium {
+			return
+		}
+		fmt.Printf("[%s%s%s] %s - Score: %d%s\n", orange, restriction, reset, r.Domain, e.Score, note)
 		return
 	}
 
-	if showOnlyAvail {
+	// Parked, for-sale and dropping domains are often acquirable, so they stay visible with -x
+	if showOnlyAvail && !r.Parked && !r.ForSale && !r.Dropping() {
 		return
 	}
 
+	status := bRed + "taken" + reset
+	if r.Dropping() {
+		status += " " + bGreen + "(dropping soon)" + reset
+	}
+	// A confirmed sale offer below says more than the guess
+	if r.Parked && !r.ForSale {
+		status += " " + orange + "(parked — possibly for sale)" + reset
+	} else if r.Parked {
+		status += " " + orange + "(parked)" + reset
+	}
+	if r.ForSale {
+		status += " " + orange + "(for sale)" + reset
+		sale := " - Sale: " + r.SaleURL
+		if r.SaleContact != "" {
+			sale += " <" + r.SaleContact + ">"
+		}
+		if r.Listing != nil {
+			sale = " - Listed: " + r.Listing.String() + " " + r.Listing.URL
+		}
+		note = sale + note
+	}
+	if r.DNS != nil {
+		note = " - DNS: " + r.DNS.String() + note
+	}
+	if r.Usage != nil {
+		note = " - Usage: " + r.Usage.String() + note
+	}
+	if r.RegistrantOrg != "" || r.RegistrantCountry != "" {
+		note = " - Registrant: " + formatRegistrant(r.RegistrantOrg, r.RegistrantCountry) + note
+	}
+	if r.Reregistered != "" {
+		note = " - Re-registered: " + r.Reregistered + note
+	}
+	if age := checker.Age(r.CreatedDate, time.Now()); age != "" {
+		note = " - Age: " + age + note
+	}
+
 	if r.ExpiryDate != "" {
-		fmt.Printf("[%staken%s] %s - Exp Date: %s%s%s\n", bRed, reset, r.Domain, orange, r.ExpiryDate, reset)
+		fmt.Printf("[%s] %s - Exp Date: %s%s%s%s\n", status, r.Domain, orange, r.ExpiryDate, reset, note)
 	} else {
-		fmt.Printf("[%staken%s] %s - No expiry date found\n", bRed, reset, r.Domain)
+		fmt.Printf("[%s] %s - No expiry date found%s\n", status, r.Domain, note)
+	}
+	if showDetails {
+		printDetails(r)
+	}
+}
+
+// printDetails prints the parsed registration record of a taken domain
+// below its result line
+func printDetails(r checker.Result) {
+	const indent = "        "
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Printf("%s%-13s %s\n", indent, label, value)
+		}
+	}
+	// Results answered from DNS alone carry no record
+	if r.Registrar != "" || r.CreatedDate != "" || len(r.Status) > 0 || len(r.Nameservers) > 0 {
+		field("Registrar:", r.Registrar)
+		field("Abuse:", r.AbuseEmail)
+		field("Registrant:", registrantLabel(r.RegistrantOrg, r.RegistrantCountry, r.RegistrantPrivacy))
+		field("Created:", r.CreatedDate)
+		field("Updated:", r.UpdatedDate)
+		field("EPP Status:", strings.Join(r.Status, ", "))
+		field("DNSSEC:", dnssecLabel(r.DNSSEC))
+		field("Nameservers:", strings.Join(r.Nameservers, ", "))
+	}
+	if r.DNS != nil {
+		field("A:", strings.Join(r.DNS.A, ", "))
+		field("AAAA:", strings.Join(r.DNS.AAAA, ", "))
+		field("MX:", strings.Join(r.DNS.MX, ", "))
+	}
+	if r.Listing != nil {
+		field("Listed:", r.Listing.String())
 	}
 }
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/plugin"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// runManifest records how a result set was produced so automated pipelines
+// can reproduce and audit a run
+type runManifest struct {
+	Tool       manifestTool      `json:"tool"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	DurationMS int64             `json:"duration_ms"`
+	Args       []string          `json:"args"`
+	Flags      map[string]string `json:"flags"`
+	Config     string            `json:"config,omitempty"`
+	Inputs     manifestInputs    `json:"inputs"`
+	Backend    manifestBackend   `json:"backend"`
+	Results    manifestResults   `json:"results"`
+}
+
+type manifestTool struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+type manifestInputs struct {
+	Keyword       string `json:"keyword"`
+	TLDSource     string `json:"tld_source"`
+	TLDCount      int    `json:"tld_count"`
+	TLDListSHA256 string `json:"tld_list_sha256"`
+	DomainCount   int    `json:"domain_count"`
+}
+
+type manifestBackend struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Path    string `json:"path,omitempty"`
+}
+
+type manifestResults struct {
+	Available int `json:"available"`
+	Premium   int `json:"premium"`
+	Reserved  int `json:"reserved"`
+	Taken     int `json:"taken"`
+	Errors    int `json:"errors"`
+	// Backends counts the results each backend produced
+	Backends map[string]int `json:"backends,omitempty"`
+}
+
+func newRunManifest(cmd *cobra.Command, backend checker.Backend) *runManifest {
+	m := &runManifest{
+		Tool: manifestTool{
+			Name:      "gofindadomain",
+			Version:   version,
+			Commit:    commit,
+			BuildDate: date,
+			GoVersion: runtime.Version(),
+			Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		},
+		StartedAt: time.Now().UTC(),
+		Args:      os.Args[1:],
+		Flags:     make(map[string]string),
+		Config:    configPath,
+		Backend:   manifestBackend{Name: backendName},
+	}
+
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		m.Flags[f.Name] = f.Value.String()
+	})
+
+	switch b := checker.Unwrap(backend).(type) {
+	case checker.SystemWhoisBackend:
+		m.Backend.Version = checker.WhoisVersion()
+	case *plugin.Plugin:
+		m.Backend.Version = b.Version
+		m.Backend.Path = b.Info.Path
+	}
+
+	return m
+}
+
+func (m *runManifest) setInputs(keyword, source string, tlds []string, domainCount int) {
+	sum := sha256.Sum256([]byte(strings.Join(tlds, "\n")))
+	m.Inputs = manifestInputs{
+		Keyword:       keyword,
+		TLDSource:     source,
+		TLDCount:      len(tlds),
+		TLDListSHA256: hex.EncodeToString(sum[:]),
+		DomainCount:   domainCount,
+	}
+}
+
+func (m *runManifest) record(r checker.Result) {
+	if r.Backend != "" {
+		if m.Results.Backends == nil {
+			m.Results.Backends = make(map[string]int)
+		}
+		m.Results.Backends[r.Backend]++
+	}
+	switch {
+	case r.Error != nil:
+		m.Results.Errors++
+	case r.Available:
+		m.Results.Available++
+	case r.Premium:
+		m.Results.Premium++
+	case r.Reserved:
+		m.Results.Reserved++
+	default:
+		m.Results.Taken++
+	}
+}
+
+func (m *runManifest) write(path string) error {
+	m.FinishedAt = time.Now().UTC()
+	m.DurationMS = m.FinishedAt.Sub(m.StartedAt).Milliseconds()
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
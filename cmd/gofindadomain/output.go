@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/james-see/gofindadomain/internal/brand"
+	"github.com/james-see/gofindadomain/internal/hook"
+	"github.com/james-see/gofindadomain/internal/rank"
+)
+
+// jsonResult is a check result as written by --json, one object per line
+type jsonResult struct {
+	Domain            string       `json:"domain"`
+	Available         bool         `json:"available"`
+	Error             string       `json:"error,omitempty"`
+	Score             int          `json:"score"`
+	Composite         float64      `json:"composite"`
+	Price             *float64     `json:"price,omitempty"`
+	RenewPrice        *float64     `json:"renew_price,omitempty"`
+	Currency          string       `json:"currency,omitempty"`
+	BuyURL            string       `json:"buy_url,omitempty"`
+	ExpiryDate        string       `json:"expiry_date,omitempty"`
+	ExpiringInDays    *int         `json:"expiring_in_days,omitempty"`
+	CreatedDate       string       `json:"created_date,omitempty"`
+	Reregistered      string       `json:"reregistered,omitempty"`
+	RegistrantOrg     string       `json:"registrant_org,omitempty"`
+	RegistrantCountry string       `json:"registrant_country,omitempty"`
+	RegistrantPrivacy bool         `json:"registrant_privacy,omitempty"`
+	Registrar         string       `json:"registrar,omitempty"`
+	AbuseEmail        string       `json:"abuse_email,omitempty"`
+	UpdatedDate       string       `json:"updated_date,omitempty"`
+	Nameservers       []string     `json:"nameservers,omitempty"`
+	Status            []string     `json:"status,omitempty"`
+	DNSSEC            bool         `json:"dnssec,omitempty"`
+	Confidence        string       `json:"confidence,omitempty"`
+	Backend           string       `json:"backend,omitempty"`
+	Premium           bool         `json:"premium,omitempty"`
+	Reserved          bool         `json:"reserved,omitempty"`
+	Parked            bool         `json:"parked,omitempty"`
+	ForSale           bool         `json:"for_sale,omitempty"`
+	Dropping          bool         `json:"dropping,omitempty"`
+	SaleURL           string       `json:"sale_url,omitempty"`
+	SaleContact       string       `json:"sale_contact,omitempty"`
+	Usage             *jsonUsage   `json:"usage,omitempty"`
+	DNS               *jsonDNS     `json:"dns,omitempty"`
+	Listing           *jsonListing `json:"listing,omitempty"`
+	Certificates      *jsonCerts   `json:"certificates,omitempty"`
+	Wayback           *jsonArchive `json:"wayback,omitempty"`
+	Reputation        *jsonRep     `json:"reputation,omitempty"`
+	Trademarks        []jsonMark   `json:"trademarks,omitempty"`
+	TrademarkError    string       `json:"trademark_error,omitempty"`
+	SimilarBrand      string       `json:"similar_brand,omitempty"`
+	Note              string       `json:"note,omitempty"`
+	Saved             []jsonSaved  `json:"saved,omitempty"`
+}
+
+// jsonSaved is the result's entry on a saved list
+type jsonSaved struct {
+	List string   `json:"list"`
+	Note string   `json:"note,omitempty"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+type jsonUsage struct {
+	MX    bool `json:"mx"`
+	Web   bool `json:"web"`
+	HTTPS bool `json:"https"`
+}
+
+// jsonDNS is the DNS enrichment of a taken domain
+type jsonDNS struct {
+	A     []string `json:"a,omitempty"`
+	AAAA  []string `json:"aaaa,omitempty"`
+	MX    []string `json:"mx,omitempty"`
+	Hosts bool     `json:"hosts"`
+	Mail  bool     `json:"mail"`
+}
+
+// jsonListing is the aftermarket listing of a taken domain; a price of 0
+// means the seller takes offers
+type jsonListing struct {
+	Marketplace string  `json:"marketplace"`
+	Price       float64 `json:"price"`
+	Currency    string  `json:"currency,omitempty"`
+	URL         string  `json:"url,omitempty"`
+}
+
+// jsonCerts is the certificate transparency history of an available domain
+type jsonCerts struct {
+	Count       int      `json:"count"`
+	FirstIssued string   `json:"first_issued,omitempty"`
+	LastIssued  string   `json:"last_issued,omitempty"`
+	Issuers     []string `json:"issuers,omitempty"`
+}
+
+// jsonArchive is the Wayback Machine history of an available domain
+type jsonArchive struct {
+	Archived      bool   `json:"archived"`
+	Months        int    `json:"months"`
+	FirstSnapshot string `json:"first_snapshot,omitempty"`
+	LastSnapshot  string `json:"last_snapshot,omitempty"`
+}
+
+// jsonRep is the blocklist reputation of an available domain
+type jsonRep struct {
+	Clean    bool     `json:"clean"`
+	Listings []string `json:"listings,omitempty"`
+	Checked  int      `json:"checked"`
+}
+
+// jsonMark is a live trademark matching an available domain's name
+type jsonMark struct {
+	Mark   string `json:"mark"`
+	Office string `json:"office"`
+	Number string `json:"number,omitempty"`
+	Status string `json:"status,omitempty"`
+	Exact  bool   `json:"exact"`
+}
+
+var jsonEncoder = json.NewEncoder(os.Stdout)
+
+// printJSON writes a result as a single JSON line, skipping the results -x hides
+func printJSON(e rank.Entry, showOnlyAvail bool, o hook.Outcome, saved []savedEntry) {
+	r := e.Result
+	if showOnlyAvail && !r.Available && !r.Premium && !r.Parked && !r.ForSale && !r.Dropping() {
+		return
+	}
+	if err := jsonEncoder.Encode(newJSONResult(e, o, saved)); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write JSON result: %v\n", err)
+	}
+}
+
+// newJSONResult converts a ranked result to its JSON form
+func newJSONResult(e rank.Entry, o hook.Outcome, saved []savedEntry) jsonResult {
+	r := e.Result
+	out := jsonResult{
+		Domain:            r.Domain,
+		Available:         r.Available && r.Error == nil,
+		Score:             e.Score,
+		Composite:         e.Composite,
+		ExpiryDate:        r.ExpiryDate,
+		CreatedDate:       r.CreatedDate,
+		Reregistered:      r.Reregistered,
+		RegistrantOrg:     r.RegistrantOrg,
+		RegistrantCountry: r.RegistrantCountry,
+		RegistrantPrivacy: r.RegistrantPrivacy,
+		Registrar:         r.Registrar,
+		AbuseEmail:        r.AbuseEmail,
+		UpdatedDate:       r.UpdatedDate,
+		Nameservers:       r.Nameservers,
+		Status:            r.Status,
+		DNSSEC:            r.DNSSEC,
+		Confidence:        r.Confidence.String(),
+		Backend:           r.Backend,
+		Premium:           r.Premium,
+		Reserved:          r.Reserved,
+		Parked:            r.Parked,
+		ForSale:           r.ForSale,
+		Dropping:          r.Dropping(),
+		SaleURL:           r.SaleURL,
+		SaleContact:       r.SaleContact,
+		Note:              o.Note,
+	}
+	if r.Error != nil {
+		out.Error = r.Error.Error()
+	}
+	for _, se := range saved {
+		out.Saved = append(out.Saved, jsonSaved{List: se.list, Note: se.entry.Note, Tags: se.entry.Tags})
+	}
+	if e.HasPrice {
+		out.Price = &e.Price
+		out.Currency = e.Currency
+		out.BuyURL = e.BuyURL
+		if e.Renew > 0 {
+			out.RenewPrice = &e.Renew
+		}
+	}
+	if days, ok := expiringDays(r); ok {
+		out.ExpiringInDays = &days
+	}
+	if r.Usage != nil {
+		out.Usage = &jsonUsage{MX: r.Usage.MX, Web: r.Usage.Web, HTTPS: r.Usage.HTTPS}
+	}
+	if r.DNS != nil {
+		out.DNS = &jsonDNS{A: r.DNS.A, AAAA: r.DNS.AAAA, MX: r.DNS.MX, Hosts: r.DNS.Hosts(), Mail: r.DNS.Mail()}
+	}
+	if c := r.Certs; c != nil {
+		out.Certificates = &jsonCerts{Count: c.Count, FirstIssued: c.FirstIssued, LastIssued: c.LastIssued, Issuers: c.Issuers}
+	}
+	if a := r.Archive; a != nil {
+		out.Wayback = &jsonArchive{Archived: a.Months > 0, Months: a.Months, FirstSnapshot: a.FirstSnapshot, LastSnapshot: a.LastSnapshot}
+	}
+	if rep := r.Reputation; rep != nil {
+		out.Reputation = &jsonRep{Clean: rep.Clean(), Listings: rep.Listings, Checked: rep.Checked}
+	}
+	for _, t := range r.Trademarks {
+		out.Trademarks = append(out.Trademarks, jsonMark{Mark: t.Mark, Office: t.Office, Number: t.Number, Status: t.Status, Exact: t.Exact})
+	}
+	if r.TrademarkError != nil {
+		out.TrademarkError = r.TrademarkError.Error()
+	}
+	if l := r.Listing; l != nil {
+		out.Listing = &jsonListing{Marketplace: l.Marketplace, Price: l.Price, Currency: l.Currency, URL: l.URL}
+	}
+	if out.Available {
+		if m, ok := brand.Similar(r.Domain); ok {
+			out.SimilarBrand = m.Brand
+		}
+	}
+	return out
+}
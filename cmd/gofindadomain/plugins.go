@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/james-see/gofindadomain/internal/plugin"
+	"github.com/spf13/cobra"
+)
+
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List installed plugins",
+	Long:  "List plugin executables (gofindadomain-<kind>-<name>) found in the plugin directory and on PATH.",
+	Args:  cobra.NoArgs,
+	RunE:  runPlugins,
+}
+
+func init() {
+	rootCmd.AddCommand(pluginsCmd)
+}
+
+func runPlugins(cmd *cobra.Command, args []string) error {
+	if dir, err := plugin.Dir(); err == nil {
+		fmt.Printf("Plugin directory: %s\n\n", dir)
+	}
+
+	found := plugin.Discover()
+	if len(found) == 0 {
+		fmt.Println("No plugins installed")
+		return nil
+	}
+
+	for _, p := range found {
+		fmt.Printf("%-9s %s%-20s%s %s\n", p.Kind, bold, p.Name, reset, p.Path)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileName string
+	// profileTLDs are the selected profile's TLDs, checked when neither -e
+	// nor -E is given
+	profileTLDs []string
+)
+
+// applyProfile fills the flags not given on the command line from the
+// profile selected with --profile
+func applyProfile(cmd *cobra.Command, _ []string) error {
+	if profileName == "" {
+		return nil
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+	p, ok := cfg.Profiles[profileName]
+	if !ok {
+		names := make([]string, 0, len(cfg.Profiles))
+		for name := range cfg.Profiles {
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("unknown profile %q: the config file defines no profiles", profileName)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown profile %q (use %s)", profileName, strings.Join(names, ", "))
+	}
+
+	flags := cmd.Flags()
+	set := func(name, value string) error {
+		f := flags.Lookup(name)
+		if f == nil || f.Changed {
+			return nil
+		}
+		if err := flags.Set(name, value); err != nil {
+			return fmt.Errorf("invalid %s in profile %s: %w", name, profileName, err)
+		}
+		return nil
+	}
+
+	var settings [][2]string
+	if !flags.Changed("tld") && !flags.Changed("tld-file") {
+		if p.TLDFile != "" {
+			settings = append(settings, [2]string{"tld-file", p.TLDFile})
+		}
+		for _, t := range p.TLDs {
+			if !strings.HasPrefix(t, ".") {
+				t = "." + t
+			}
+			profileTLDs = append(profileTLDs, strings.ToLower(t))
+		}
+	}
+	if p.Concurrency > 0 {
+		settings = append(settings, [2]string{"concurrency", strconv.Itoa(p.Concurrency)})
+	}
+	if p.RateLimit > 0 {
+		settings = append(settings, [2]string{"rate-limit", strconv.FormatFloat(p.RateLimit, 'f', -1, 64)})
+	}
+	if p.Backend != "" {
+		settings = append(settings, [2]string{"backend", p.Backend})
+	}
+	if p.NotRegistered {
+		settings = append(settings, [2]string{"not-registered", "true"})
+	}
+	if p.MinScore > 0 {
+		settings = append(settings, [2]string{"min-score", strconv.Itoa(p.MinScore)})
+	}
+	if p.MaxPrice > 0 {
+		settings = append(settings, [2]string{"max-price", strconv.FormatFloat(p.MaxPrice, 'f', -1, 64)})
+	}
+	if p.Sort != "" && !flags.Changed("rank") {
+		settings = append(settings, [2]string{"sort", p.Sort})
+	}
+	for _, s := range settings {
+		if err := set(s[0], s[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
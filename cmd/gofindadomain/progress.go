@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// progressInterval is how often the progress line is redrawn
+const progressInterval = 200 * time.Millisecond
+
+var noProgress bool
+
+// progress draws a live checked/total, rate, ETA and error count line on
+// stderr while a run checks domains, so stdout keeps only the results. It is
+// a no-op when stderr is not a terminal or --no-progress is given.
+type progress struct {
+	mu      sync.Mutex
+	total   int
+	checked int
+	errors  int
+	start   time.Time
+	shown   bool
+	done    chan struct{}
+	stopped sync.WaitGroup
+}
+
+// newProgress starts drawing progress over total domains, or returns nil
+// when progress is not shown
+func newProgress(total int) *progress {
+	if noProgress || total == 0 || !stderrIsTerminal() {
+		return nil
+	}
+	p := &progress{total: total, start: time.Now(), done: make(chan struct{})}
+	p.stopped.Add(1)
+	go func() {
+		defer p.stopped.Done()
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-ticker.C:
+				p.mu.Lock()
+				p.draw()
+				p.mu.Unlock()
+			}
+		}
+	}()
+	return p
+}
+
+// stderrIsTerminal reports whether stderr is a terminal rather than a file or pipe
+func stderrIsTerminal() bool {
+	fi, err := os.Stderr.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// add counts a finished check
+func (p *progress) add(r checker.Result) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checked++
+	if r.Error != nil {
+		p.errors++
+	}
+}
+
+// print runs fn, which writes a result, with the progress line cleared so
+// the two do not run into each other on the terminal
+func (p *progress) print(fn func()) {
+	if p == nil {
+		fn()
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clear()
+	fn()
+	p.draw()
+}
+
+// finish stops drawing and removes the progress line
+func (p *progress) finish() {
+	if p == nil {
+		return
+	}
+	close(p.done)
+	p.stopped.Wait()
+	p.mu.Lock()
+	p.clear()
+	p.mu.Unlock()
+}
+
+func (p *progress) clear() {
+	if p.shown {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+		p.shown = false
+	}
+}
+
+func (p *progress) draw() {
+	const width = 30
+	filled := p.checked * width / p.total
+	line := fmt.Sprintf("[%s%s] %d/%d (%d%%)", strings.Repeat("█", filled), strings.Repeat("░", width-filled),
+		p.checked, p.total, p.checked*100/p.total)
+
+	// The rate of the first moments says little about the run
+	elapsed := time.Since(p.start)
+	if p.checked > 0 && elapsed >= time.Second {
+		rate := float64(p.checked) / elapsed.Seconds()
+		eta := time.Duration(float64(p.total-p.checked) / rate * float64(time.Second))
+		line += fmt.Sprintf(" • %.1f/s • ETA %s", rate, eta.Round(time.Second))
+	}
+	if p.errors > 0 {
+		line += fmt.Sprintf(" • %s%d errors%s", red, p.errors, reset)
+	}
+	fmt.Fprint(os.Stderr, "\r\033[K"+line)
+	p.shown = true
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/plugin"
+	"github.com/james-see/gofindadomain/internal/pricing"
+	"github.com/james-see/gofindadomain/internal/rank"
+)
+
+// newRanker builds the ranker from config and --pricing, starting the pricing
+// plugin if one is selected. --max-price and --sort price fall back to the
+// static price table when no source is configured. The returned func stops
+// the plugin.
+func newRanker(cfg *config.Config) (*rank.Ranker, func(), error) {
+	source := pricingName
+	if source == "" {
+		source = cfg.Pricing.Source
+	}
+	if source == "" && cfg.Pricing.Plugin != "" {
+		source = "plugin:" + cfg.Pricing.Plugin
+	}
+	if source == "" && (maxPrice > 0 || sortBy == "price") {
+		source = "static"
+	}
+	if source == "" {
+		return rank.New(cfg.Ranking.ScoreWeight, cfg.Ranking.PriceWeight, nil), func() {}, nil
+	}
+
+	if name, ok := strings.CutPrefix(source, "plugin:"); ok {
+		info, err := plugin.Find(plugin.KindPricing, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		p, err := plugin.Start(info)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		price := func(ctx context.Context, domain string) (rank.Quote, bool) {
+			quote, err := p.Price(ctx, domain)
+			if err != nil {
+				return rank.Quote{}, false
+			}
+			return rank.Quote{Price: quote.Register, Renew: quote.Renew, Currency: quote.Currency, BuyURL: quote.URL}, true
+		}
+		return rank.New(cfg.Ranking.ScoreWeight, cfg.Ranking.PriceWeight, price), func() { p.Close() }, nil
+	}
+
+	src, err := pricing.New(source, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	price := func(ctx context.Context, domain string) (rank.Quote, bool) {
+		quote, err := src.Price(ctx, domain)
+		if err != nil {
+			return rank.Quote{}, false
+		}
+		return rank.Quote{Price: quote.Register, Renew: quote.Renew, Currency: quote.Currency, BuyURL: quote.URL}, true
+	}
+	return rank.New(cfg.Ranking.ScoreWeight, cfg.Ranking.PriceWeight, price), func() {}, nil
+}
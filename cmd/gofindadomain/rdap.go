@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var rdapRaw bool
+
+var rdapCmd = &cobra.Command{
+	Use:   "rdap <domain>",
+	Short: "Query RDAP for a domain",
+	Long:  "Query the authoritative RDAP server for a single domain and print the mapped fields.\nUse --raw to print the full JSON response instead.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runRDAP,
+}
+
+func init() {
+	rdapCmd.Flags().BoolVar(&rdapRaw, "raw", false, "Print the raw RDAP JSON response")
+	rootCmd.AddCommand(rdapCmd)
+}
+
+func runRDAP(cmd *cobra.Command, args []string) error {
+	domain := strings.ToLower(strings.TrimSpace(args[0]))
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+	if err := configureWhois(cfg.Whois); err != nil {
+		return err
+	}
+
+	resp, err := checker.LookupRDAP(context.Background(), domain)
+	if err != nil {
+		return err
+	}
+
+	if rdapRaw {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, resp.Raw, "", "  "); err != nil {
+			fmt.Println(string(resp.Raw))
+			return nil
+		}
+		fmt.Println(pretty.String())
+		return nil
+	}
+
+	printRDAPFields(domain, resp)
+	return nil
+}
+
+func printRDAPFields(domain string, resp *checker.RDAPResponse) {
+	fmt.Printf("%-13s %s\n", "Domain:", domain)
+	fmt.Printf("%-13s %s\n", "Server:", resp.Server)
+
+	if resp.Available() {
+		fmt.Printf("%-13s %savailable%s\n", "Status:", bGreen, reset)
+		return
+	}
+	fmt.Printf("%-13s %staken%s\n", "Status:", bRed, reset)
+
+	d := resp.Domain
+	if d.Handle != "" {
+		fmt.Printf("%-13s %s\n", "Handle:", d.Handle)
+	}
+	if registrar := d.Registrar(); registrar != "" {
+		fmt.Printf("%-13s %s\n", "Registrar:", registrar)
+	}
+	if abuse := d.AbuseEmail(); abuse != "" {
+		fmt.Printf("%-13s %s\n", "Abuse:", abuse)
+	}
+	org, country := d.Registrant()
+	if registrant := registrantLabel(org, country, d.RegistrantPrivacy()); registrant != "" {
+		fmt.Printf("%-13s %s\n", "Registrant:", registrant)
+	}
+	if date := d.EventDate("registration"); date != "" {
+		if age := checker.Age(date, time.Now()); age != "" {
+			date += " (" + age + ")"
+		}
+		fmt.Printf("%-13s %s\n", "Created:", date)
+	}
+	if date := d.EventDate("reregistration"); date != "" {
+		fmt.Printf("%-13s %s\n", "Re-reg'd:", date)
+	}
+	if date := d.EventDate("last changed"); date != "" {
+		fmt.Printf("%-13s %s\n", "Updated:", date)
+	}
+	if date := d.EventDate("expiration"); date != "" {
+		fmt.Printf("%-13s %s%s%s\n", "Expiry:", orange, date, reset)
+	}
+	printRecordLists(checker.Result{Status: d.Status, DNSSEC: d.Signed(), Nameservers: d.NameserverNames()})
+}
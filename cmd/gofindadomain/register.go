@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/namecheap"
+	"github.com/james-see/gofindadomain/internal/tld"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registerYears        int
+	registerYes          bool
+	registerAllowPremium bool
+)
+
+var registerCmd = &cobra.Command{
+	Use:   "register <domain>",
+	Short: "Register an available domain through Namecheap",
+	Long: "Check that a domain is available, show its price and contact, and after confirmation register it\n" +
+		"through the Namecheap account in the config file. The account is charged.",
+	Example: "  gofindadomain register swiftpanda.io\n  gofindadomain register swiftpanda.io --years 2",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runRegister,
+}
+
+func init() {
+	registerCmd.Flags().IntVar(&registerYears, "years", 1, "Registration period in years")
+	registerCmd.Flags().BoolVar(&registerYes, "yes", false, "Register without asking for confirmation (premium names also need --allow-premium)")
+	registerCmd.Flags().BoolVar(&registerAllowPremium, "allow-premium", false, "Let --yes register premium names at their premium price")
+	rootCmd.AddCommand(registerCmd)
+}
+
+// newNamecheapClient returns a client for the configured Namecheap account,
+// rate limited to what the API allows unless the config sets a limit for
+// its host
+func newNamecheapClient(cfg *config.Config) (*namecheap.Client, error) {
+	c, err := namecheap.New(cfg.Namecheap)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := cfg.Whois.RateLimits[c.Host()]; !ok {
+		checker.SetServerRateLimits(map[string]float64{c.Host(): namecheap.RateLimit})
+	}
+	return c, nil
+}
+
+func runRegister(cmd *cobra.Command, args []string) error {
+	if registerYears < 1 || registerYears > 10 {
+		return fmt.Errorf("invalid --years %d (use 1 to 10)", registerYears)
+	}
+	domain, err := tld.ToASCII(strings.TrimSuffix(strings.TrimSpace(args[0]), "."))
+	if err != nil {
+		return err
+	}
+	if err := tld.ValidateDomain(domain); err != nil {
+		return err
+	}
+	if !strings.Contains(domain, ".") {
+		return fmt.Errorf("%q is not a domain; give the name with its TLD", args[0])
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+	if err := configureWhois(cfg.Whois); err != nil {
+		return err
+	}
+	c, err := newNamecheapClient(cfg)
+	if err != nil {
+		return err
+	}
+	contact := cfg.Namecheap.Contact
+	if err := namecheap.ValidateContact(contact); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	a, err := c.Check(ctx, domain)
+	if err != nil {
+		return err
+	}
+	if !a.Available {
+		return fmt.Errorf("%s is not available", tld.ToUnicode(domain))
+	}
+
+	order := namecheap.Order{Domain: domain, Years: registerYears}
+	price := "unknown"
+	if a.Premium {
+		order.PremiumPrice, order.EapFee = a.PremiumCost()
+		price = fmt.Sprintf("$%.2f first year (premium)", order.PremiumPrice+order.EapFee)
+	} else if _, suffix, ok := strings.Cut(domain, "."); ok {
+		prices, err := c.Pricing(ctx, suffix)
+		if err != nil {
+			return err
+		}
+		if p, ok := prices[suffix]; ok {
+			price = fmt.Sprintf("%.2f %s first year", p.Register, p.Currency)
+			if p.Renew > 0 {
+				price += fmt.Sprintf(", %.2f %s a year to renew", p.Renew, p.Currency)
+			}
+		}
+	}
+
+	fmt.Printf("%-13s %s\n", "Domain:", tld.ToUnicode(domain))
+	fmt.Printf("%-13s %d\n", "Years:", registerYears)
+	fmt.Printf("%-13s %s\n", "Price:", price)
+	fmt.Printf("%-13s %s %s <%s>\n", "Registrant:", contact.FirstName, contact.LastName, contact.Email)
+	account := cfg.Namecheap.APIUser
+	if c.Sandbox() {
+		account += " (sandbox, not charged)"
+	}
+	fmt.Printf("%-13s %s\n", "Account:", account)
+	fmt.Println()
+
+	// Premium names can cost thousands, so they take a second, explicit
+	// confirmation, which --yes alone does not give
+	if registerYes && a.Premium && !registerAllowPremium {
+		return fmt.Errorf("%s is a premium name; pass --allow-premium with --yes to register it, or confirm it without --yes", tld.ToUnicode(domain))
+	}
+	if !registerYes {
+		in := bufio.NewReader(os.Stdin)
+		if !confirm(in, fmt.Sprintf("Register %s and charge the account? [y/N] ", tld.ToUnicode(domain))) {
+			return fmt.Errorf("registration cancelled")
+		}
+		if a.Premium {
+			fmt.Print("This is a premium name. Type the domain to confirm: ")
+			line, _ := in.ReadString('\n')
+			if typed, _ := tld.ToASCII(strings.TrimSpace(line)); typed != domain {
+				return fmt.Errorf("registration cancelled")
+			}
+		}
+	}
+
+	r, err := c.Register(ctx, order)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[%sregistered%s] %s - order %s, charged %.2f\n", bGreen, reset, tld.ToUnicode(domain), r.OrderID, r.ChargedAmount)
+	return nil
+}
+
+// confirm prints prompt and reports whether the answer is yes
+func confirm(in *bufio.Reader, prompt string) bool {
+	fmt.Print(prompt)
+	line, _ := in.ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
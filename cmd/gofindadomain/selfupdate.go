@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/james-see/gofindadomain/internal/selfupdate"
+	"github.com/spf13/cobra"
+)
+
+var selfUpdateCheckOnly bool
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update gofindadomain to the latest release",
+	Long:  "Check GitHub releases for a newer version, verify its checksum and replace the running binary.",
+	Args:  cobra.NoArgs,
+	RunE:  runSelfUpdate,
+}
+
+func init() {
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateCheckOnly, "check-only", false, "Only report whether an update is available")
+	rootCmd.AddCommand(selfUpdateCmd)
+}
+
+func runSelfUpdate(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	release, err := selfupdate.LatestRelease(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !selfupdate.IsNewer(release.Version(), version) {
+		fmt.Printf("gofindadomain %s is up to date\n", version)
+		return nil
+	}
+
+	if selfUpdateCheckOnly {
+		fmt.Printf("Update available: %s -> %s%s%s\n", version, bGreen, release.Version(), reset)
+		return nil
+	}
+
+	fmt.Printf("Updating %s -> %s...\n", version, release.Version())
+	if err := selfupdate.Apply(ctx, release); err != nil {
+		return err
+	}
+	fmt.Printf("Updated to %s\n", release.Version())
+	return nil
+}
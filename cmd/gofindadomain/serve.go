@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/hook"
+	"github.com/james-see/gofindadomain/internal/score"
+	"github.com/james-see/gofindadomain/internal/server"
+	"github.com/james-see/gofindadomain/internal/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr    string
+	serveToken   string
+	serveMaxJobs int
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run gofindadomain as a shared HTTP service",
+	Long: "Serve a REST API for checks (POST /check, POST /jobs, GET and DELETE /jobs/{id}, GET /jobs/{id}/events)\n" +
+		"and the shared shortlist and watchlist used by clients whose store.url points here.\n" +
+		"Results have the same fields as --json output.",
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "Address to listen on")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "Require this bearer token on every request (default $GOFINDADOMAIN_SERVE_TOKEN)")
+	serveCmd.Flags().IntVar(&serveMaxJobs, "max-jobs", server.DefaultMaxJobs, "Maximum number of bulk jobs running at once; further jobs are refused until one finishes")
+	addBackendFlag(serveCmd)
+	addQueryFlags(serveCmd)
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	dir, err := config.Dir()
+	if err != nil {
+		return fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	token := serveToken
+	if token == "" {
+		token = os.Getenv("GOFINDADOMAIN_SERVE_TOKEN")
+	}
+
+	srv := &server.Server{
+		Backend:     sess.checkBackend(),
+		Concurrency: concurrency,
+		MaxJobs:     serveMaxJobs,
+		Format: func(ctx context.Context, r checker.Result) any {
+			return newJSONResult(sess.ranker.Rank(ctx, r, score.Score(r.Domain)), hook.Outcome{}, nil)
+		},
+		Token: token,
+		Lists: store.FileBackend{Dir: dir},
+	}
+	// No WriteTimeout: event streams stay open for as long as their job runs
+	httpServer := &http.Server{
+		Addr:              serveAddr,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	errc := make(chan error, 1)
+	go func() { errc <- httpServer.ListenAndServe() }()
+	fmt.Printf("Listening on http://%s\n", serveAddr)
+	if token == "" {
+		fmt.Fprintf(os.Stderr, "[%sserve%s] no --token set; anyone who can reach %s can use it\n", orange, reset, serveAddr)
+	}
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to shut down: %w", err)
+	}
+	return nil
+}
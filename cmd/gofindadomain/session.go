@@ -0,0 +1,782 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/aftermarket"
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/checkpoint"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/crtsh"
+	"github.com/james-see/gofindadomain/internal/hook"
+	"github.com/james-see/gofindadomain/internal/metrics"
+	"github.com/james-see/gofindadomain/internal/notify"
+	"github.com/james-see/gofindadomain/internal/probe"
+	"github.com/james-see/gofindadomain/internal/rank"
+	"github.com/james-see/gofindadomain/internal/report"
+	"github.com/james-see/gofindadomain/internal/reputation"
+	"github.com/james-see/gofindadomain/internal/score"
+	"github.com/james-see/gofindadomain/internal/store"
+	"github.com/james-see/gofindadomain/internal/tld"
+	"github.com/james-see/gofindadomain/internal/trademark"
+	"github.com/james-see/gofindadomain/internal/wayback"
+	"github.com/spf13/cobra"
+)
+
+// addCheckFlags registers the flags shared by every command that checks domains.
+// strategyFlag names the check strategy flag, since generate already uses
+// --strategy for how candidates are built.
+func addCheckFlags(cmd *cobra.Command, strategyFlag string) {
+	cmd.Flags().BoolVarP(&onlyAvail, "not-registered", "x", false, "Only show available domains")
+	addQueryFlags(cmd)
+	cmd.Flags().BoolVar(&resume, "resume", false, "Skip the domains an interrupted run of the same scan already checked")
+	cmd.Flags().IntVar(&minScore, "min-score", 0, "Only show available domains with a quality score of at least this (0-100)")
+	cmd.Flags().Float64Var(&maxPrice, "max-price", 0, "Only show available domains whose registration price is at most this (unknown prices are kept)")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "Print results sorted at the end instead of streaming: rank, price, avail, domain or expiry")
+	cmd.Flags().StringVar(&expiringSpec, "expiring-within", "", "Flag taken domains expiring within this many days (e.g., 60d) and list them soonest first")
+	cmd.Flags().BoolVar(&rankOut, "rank", false, "Print results best first by quality score (and price, with a price source) once the run finishes; same as --sort rank")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "Print results at the end in sections by tld, keyword or state")
+	cmd.Flags().BoolVar(&probeParked, "parked", false, "Probe taken domains for parking (nameservers, landing page) and label them parked, possibly for sale")
+	cmd.Flags().BoolVar(&probeSale, "for-sale", false, "Probe taken domains' landing pages for sale offers and show the sale URL and contact")
+	cmd.Flags().BoolVar(&probeUsage, "usage", false, "Probe taken domains for usage signals (MX records, website, valid HTTPS)")
+	addEnrichFlag(cmd)
+	cmd.Flags().BoolVar(&showDetails, "details", false, "Print the registrar, dates, EPP status, DNSSEC and nameservers of taken domains")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Print results as JSON lines")
+	cmd.Flags().StringVar(&formatSpec, "format", "", "Print each result with a Go template, e.g. '{{.Domain}}\\t{{.Available}}\\t{{.ExpiryDate}}'")
+	cmd.Flags().BoolVarP(&quietOut, "quiet", "q", false, "Print only the available domains, one per line, without banner, colors, progress or summary")
+	cmd.Flags().BoolVar(&lengthRep, "length-report", false, "Print available domains grouped by label length (<=3, 4, 5, 6+) with counts per TLD")
+	cmd.Flags().IntVar(&firstN, "first", 0, "Stop checking once this many available domains are found, querying in the order given unless --order is set (0 checks all)")
+	cmd.Flags().BoolVar(&failNone, "fail-if-none-available", false, "Exit with status 2 when no available domain is found")
+	cmd.Flags().BoolVar(&failOnError, "fail-on-error", false, "Exit with status 3 when any check fails")
+	cmd.Flags().StringVar(&summaryMode, "summary", "on", "Print a summary of the run on stderr (totals, fastest and slowest servers, rate limiting): on or off")
+	cmd.Flags().BoolVar(&noProgress, "no-progress", false, "Do not show the progress line on stderr (it is only shown on a terminal)")
+	cmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not record the results in the result history")
+	cmd.Flags().StringVar(&manifestOut, "manifest", "", "Write a JSON run manifest (inputs, flags, TLD hash, backend, timing) to this file")
+	addBackendFlag(cmd)
+	addVerifyFlag(cmd)
+	addPricingFlag(cmd)
+	addNotifyFlag(cmd)
+	addMetricsFlag(cmd)
+	cmd.Flags().StringVar(&strategy, strategyFlag, "backend", "Check strategy: backend, or dns-first to look up NS records (not SOA) and skip the backend for domains that have them")
+}
+
+// addBackendFlag registers --backend, which picks the checker backend or
+// fallback chain
+func addBackendFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&backendName, "backend", "whois", "Checker backend: whois, system-whois, rdap, dns, namecheap, route53 or plugin:<name>, or a comma-separated fallback chain")
+}
+
+// addVerifyFlag registers --verify, which double-checks available domains
+func addVerifyFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&verify, "verify", false, "Double-check available domains with a second method (NS lookup, then RDAP, or whois for --backend rdap)")
+}
+
+// enrichments are the values accepted by --enrich
+var enrichments = []string{"dns", "aftermarket", "certs", "wayback", "reputation", "trademarks"}
+
+// addEnrichFlag registers --enrich, which adds lookups to checked domains
+func addEnrichFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&enrichWith, "enrich", nil, "Add lookups to checked domains: dns (resolve A, AAAA and MX records to see whether they host anything or receive mail), aftermarket (listings and asking prices on the configured marketplaces) for taken domains; certs (past certificates on crt.sh), wayback (Wayback Machine snapshots of prior content), reputation (DNS blocklists and Google Safe Browsing) or trademarks (trademark register marks matching the name) for available domains")
+}
+
+// addPricingFlag registers --pricing, which overrides the configured price source
+func addPricingFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&pricingName, "pricing", "", "Price source: static, porkbun, namecheap, gandi or plugin:<name> (default from config)")
+}
+
+// addQueryFlags registers the concurrency, rate limit and retry flags of
+// every command that queries registries in bulk
+func addQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "c", 30, "Maximum number of concurrent checks; ramped up to while checks succeed")
+	cmd.Flags().StringVar(&queryOrder, "order", "", "Query order: shuffle (the default; spread each whois server's domains over the run), by-tld or as-given (the default with --first)")
+	cmd.Flags().BoolVar(&fixedConc, "fixed-concurrency", false, "Keep --concurrency checks in flight instead of backing off when servers refuse or rate limit")
+	cmd.Flags().Float64Var(&rateLimit, "rate-limit", checker.DefaultRateLimit, "Maximum queries per second to any one whois or RDAP server (0 for no limit)")
+	cmd.Flags().IntVar(&retries, "retries", 2, "Retries for checks that fail transiently (timeouts, dropped connections, rate limiting)")
+	cmd.Flags().DurationVar(&retryDelay, "retry-delay", time.Second, "Wait before the first retry; doubled for each further retry, with jitter")
+}
+
+// session holds the config, backend and ranker shared by every command that
+// checks domains
+type session struct {
+	cfg     *config.Config
+	backend checker.Backend
+	ranker  *rank.Ranker
+	banner  string
+	// lists are the saved domain lists whose notes and tags annotate results;
+	// listStore is where they are kept, nil when it is unavailable
+	lists     []*store.List
+	listStore store.Backend
+	// notifier alerts the configured sinks about available domains
+	notifier  *notify.Notifier
+	notifying sync.WaitGroup
+	// marketplaces are asked about taken domains with --enrich aftermarket
+	marketplaces []aftermarket.Source
+	// trademarks screens available names with --enrich trademarks
+	trademarks *trademark.Screener
+	closers    []func()
+}
+
+func newSession() (*session, error) {
+	if strategy != "backend" && strategy != "dns-first" {
+		return nil, fmt.Errorf("invalid --strategy %q (use backend or dns-first)", strategy)
+	}
+	if summaryMode != "on" && summaryMode != "off" {
+		return nil, fmt.Errorf("invalid --summary %q (use on or off)", summaryMode)
+	}
+	for _, e := range enrichWith {
+		if !slices.Contains(enrichments, e) {
+			return nil, fmt.Errorf("invalid --enrich %q (use dns, aftermarket, certs, wayback, reputation or trademarks)", e)
+		}
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := configureWhois(cfg.Whois); err != nil {
+		return nil, err
+	}
+	s := &session{cfg: cfg}
+
+	backend, closeBackend, err := newBackend(backendName, cfg)
+	if err != nil {
+		return nil, err
+	}
+	s.backend = backend
+	s.closers = append(s.closers, closeBackend)
+
+	if s.banner, err = applyBranding(cfg.Branding); err != nil {
+		s.close()
+		return nil, err
+	}
+
+	if s.notifier, err = notify.New(cfg.Notify, notifySinks); err != nil {
+		s.close()
+		return nil, err
+	}
+	s.closers = append(s.closers, s.notifier.Close)
+
+	if metricsAddr != "" {
+		stop, err := serveMetrics(metricsAddr)
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+		s.closers = append(s.closers, stop)
+	}
+
+	// Saved lists only annotate results, so an unreachable store must not stop a check
+	if b, err := store.New(cfg.Store); err != nil {
+		fmt.Fprintf(os.Stderr, "[%sstore%s] %v\n", red, reset, err)
+	} else {
+		s.listStore = b
+		for _, name := range []string{store.Shortlist, store.Watchlist} {
+			l, err := store.Open(b, name)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[%sstore%s] %v\n", red, reset, err)
+				continue
+			}
+			s.lists = append(s.lists, l)
+		}
+	}
+
+	ranker, closeRanker, err := newRanker(cfg)
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+	s.ranker = ranker
+	s.closers = append(s.closers, closeRanker)
+
+	if slices.Contains(enrichWith, "aftermarket") {
+		sources, stop, err := aftermarket.Open(cfg.Aftermarket)
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+		s.marketplaces = sources
+		s.closers = append(s.closers, stop)
+	}
+	if slices.Contains(enrichWith, "trademarks") {
+		screener, stop, err := trademark.Open(cfg.Trademark)
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+		s.trademarks = screener
+		s.closers = append(s.closers, stop)
+	}
+
+	return s, nil
+}
+
+// checkBackend returns the backend wrapped with the retries, strategy,
+// verification, probes and enrichments selected by flags
+func (s *session) checkBackend() checker.Backend {
+	backend := s.backend
+	verifier := checker.VerifierFor(backend)
+	if retries > 0 {
+		backend = checker.RetryBackend{Backend: backend, Retries: retries, Delay: retryDelay}
+		verifier = checker.RetryBackend{Backend: verifier, Retries: retries, Delay: retryDelay}
+	}
+	if strategy == "dns-first" {
+		backend = checker.DNSFirstBackend{Backend: backend}
+	}
+	if verify {
+		backend = checker.VerifyBackend{Backend: backend, Verifier: verifier}
+	}
+	opts := probe.Options{Parked: probeParked, ForSale: probeSale, Usage: probeUsage, DNS: slices.Contains(enrichWith, "dns")}
+	if opts.Enabled() {
+		backend = probe.Backend{Backend: backend, Options: opts}
+	}
+	if len(s.marketplaces) > 0 {
+		backend = aftermarket.Backend{Backend: backend, Sources: s.marketplaces}
+	}
+	if slices.Contains(enrichWith, "certs") {
+		backend = crtsh.Backend{Backend: backend}
+	}
+	if slices.Contains(enrichWith, "wayback") {
+		backend = wayback.Backend{Backend: backend}
+	}
+	if slices.Contains(enrichWith, "reputation") {
+		backend = reputation.Backend{Backend: backend, SafeBrowsingKey: reputation.SafeBrowsingKey(s.cfg.Reputation)}
+	}
+	if s.trademarks != nil {
+		backend = trademark.Backend{Backend: backend, Screener: s.trademarks}
+	}
+	return backend
+}
+
+// addNotifyFlag registers --notify, which adds notification sinks to the
+// configured ones
+func addNotifyFlag(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&notifySinks, "notify", nil, "Alert on available domains: webhook:<url>, slack:<url>, discord:<url>, telegram:<bot token>/<chat id>, email:<address> or plugin:<name> (repeatable)")
+}
+
+// addMetricsFlag registers --metrics-addr, which serves Prometheus metrics
+// while the command runs
+func addMetricsFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics on this address (e.g. localhost:9090) while running")
+}
+
+// notify alerts the notification sinks about r in the background; the
+// alerts are awaited by notifyWait
+func (s *session) notify(r checker.Result, msg string) {
+	if s.notifier == nil {
+		return
+	}
+	n := notify.Notification{Domain: r.Domain, Available: r.Available, ExpiryDate: r.ExpiryDate, Message: msg}
+	s.notifying.Go(func() {
+		// Alerts for domains already found still go out after an interrupt
+		if err := s.notifier.Send(context.Background(), n); err != nil {
+			fmt.Fprintf(os.Stderr, "[%snotify%s] %s - %v\n", red, reset, r.Domain, err)
+		}
+	})
+}
+
+// notifyWait waits for the alerts sent so far
+func (s *session) notifyWait() {
+	s.notifying.Wait()
+}
+
+// serveMetrics serves /metrics on addr in the background until the returned
+// func is called
+func serveMetrics(addr string) (func(), error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serve metrics: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(ln)
+	return func() { srv.Close() }, nil
+}
+
+func (s *session) close() {
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		s.closers[i]()
+	}
+}
+
+// checkInputs describes what a run checked, for the manifest
+type checkInputs struct {
+	keyword   string
+	tldSource string
+	tlds      []string
+	// groups splits the domains into sections checked and printed one after
+	// another, such as one per keyword; nil checks them as one section
+	groups []checkGroup
+}
+
+// checkGroup is a titled section of a run's domains
+type checkGroup struct {
+	title string
+	// keyword is recorded in the history for the group's results
+	keyword string
+	domains []string
+}
+
+// check runs domains through the backend and prints the results according
+// to the output flags
+func (s *session) check(cmd *cobra.Command, domains []string, in checkInputs) error {
+	if firstN < 0 {
+		return fmt.Errorf("invalid --first %d (use 0 or more)", firstN)
+	}
+	if err := parseExpiringWithin(); err != nil {
+		return err
+	}
+	if rankOut {
+		if sortBy != "" && sortBy != "rank" {
+			return fmt.Errorf("--rank cannot be used with --sort %s", sortBy)
+		}
+		sortBy = "rank"
+	}
+	// Expiring domains are the point of --expiring-within, so they come first
+	if expiringWithin > 0 && sortBy == "" && groupBy == "" {
+		sortBy = "expiry"
+	}
+	if _, ok := sorters[sortBy]; !ok && sortBy != "" {
+		return fmt.Errorf("invalid --sort %q (use rank, price, avail, domain or expiry)", sortBy)
+	}
+	if groupBy != "" && groupBy != "tld" && groupBy != "keyword" && groupBy != "state" {
+		return fmt.Errorf("invalid --group-by %q (use tld, keyword or state)", groupBy)
+	}
+	if quietOut && jsonOut {
+		return fmt.Errorf("--quiet cannot be used with --json")
+	}
+	if formatSpec != "" && (jsonOut || quietOut) {
+		return fmt.Errorf("--format cannot be used with --json or --quiet")
+	}
+	if err := parseFormat(); err != nil {
+		return err
+	}
+	// Quiet and templated output are only the results, for pipes
+	if quietOut || formatTmpl != nil {
+		summaryMode = "off"
+	}
+	if quietOut {
+		noProgress = true
+	}
+
+	var manifest *runManifest
+	if manifestOut != "" {
+		manifest = newRunManifest(cmd, s.backend)
+	}
+
+	h, err := loadHook(s.cfg)
+	if err != nil {
+		return err
+	}
+	defer h.close()
+	defer s.notifyWait()
+
+	// Print banner
+	if s.banner != "" && !jsonOut && !quietOut && formatTmpl == nil {
+		fmt.Print(s.banner)
+		fmt.Println()
+	}
+
+	var lengths *report.LengthReport
+	if lengthRep {
+		lengths = report.NewLengthReport()
+	}
+
+	groups := in.groups
+	if groups == nil {
+		groups = []checkGroup{{keyword: in.keyword, domains: domains}}
+	}
+
+	rec := startRecording(in)
+
+	// Check domains, recording progress so an interrupted run can be resumed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	// runCtx is cancelled as well once --first available domains are found
+	runCtx, cancelRun := context.WithCancel(ctx)
+	defer cancelRun()
+
+	cpPath, err := checkpoint.PathFor(domains)
+	if err != nil {
+		return err
+	}
+	cp, err := checkpoint.Open(cpPath, resume)
+	if err != nil {
+		return err
+	}
+	if n := cp.Len(); n > 0 {
+		fmt.Fprintf(os.Stderr, "Resuming: skipping %d of %d domains checked by the interrupted run\n\n", n, len(domains))
+	}
+	var cpErr error
+
+	total := 0
+	for _, g := range groups {
+		for _, d := range g.domains {
+			if !cp.Done(d) {
+				total++
+			}
+		}
+	}
+	prog := newProgress(total)
+	summary := newRunSummary()
+	// found counts the available domains that passed the filters
+	found := 0
+
+	// --group-by regroups the results of every check group at the end
+	var grouped []groupedEntry
+
+	for i, g := range groups {
+		if runCtx.Err() != nil {
+			break
+		}
+		if len(groups) > 1 && !jsonOut && !quietOut && formatTmpl == nil && groupBy == "" {
+			prog.print(func() {
+				if i > 0 {
+					fmt.Println()
+				}
+				fmt.Printf("%s== %s ==%s\n", bold, g.title, reset)
+			})
+		}
+
+		var buffered []rank.Entry
+		outcomes := make(map[string]hook.Outcome)
+		var pending []string
+		for _, d := range g.domains {
+			if !cp.Done(d) {
+				pending = append(pending, d)
+			}
+		}
+
+		handle := func(result checker.Result) {
+			rec.noteDrop(&result)
+			prog.add(result)
+			summary.add(result)
+			if manifest != nil {
+				manifest.record(result)
+			}
+			rec.add(g.keyword, result)
+			// Failed checks are left out so a resumed run tries them again
+			if result.Error == nil {
+				if err := cp.Record(result.Domain); err != nil && cpErr == nil {
+					cpErr = err
+				}
+			}
+			outcome := h.run(ctx, result, score.Score(result.Domain))
+			if !outcome.Keep {
+				return
+			}
+			if result.Available && result.Error == nil && outcome.Score < minScore {
+				return
+			}
+			entry := s.ranker.Rank(ctx, result, outcome.Score)
+			if maxPrice > 0 && entry.HasPrice && entry.Price > maxPrice {
+				return
+			}
+			if result.Available && result.Error == nil {
+				found++
+				s.notify(result, result.Domain+" is available")
+				if firstN > 0 && found >= firstN {
+					cancelRun()
+				}
+			}
+			if lengths != nil && result.Available && result.Error == nil {
+				lengths.Add(result.Domain)
+			}
+			if groupBy != "" {
+				grouped = append(grouped, groupedEntry{entry: entry, outcome: outcome, keyword: g.title})
+				return
+			}
+			if sortBy != "" {
+				buffered = append(buffered, entry)
+				outcomes[entry.Domain] = outcome
+				return
+			}
+			prog.print(func() { s.emit(entry, outcome) })
+		}
+		if firstN > 0 {
+			// A later domain answering first must not take the place of an
+			// earlier, preferred one
+			var flush func()
+			handle, flush = inOrder(runCtx, pending, handle)
+			checker.CheckDomainsWithCallback(runCtx, s.checkBackend(), pending, concurrency, handle)
+			flush()
+		} else {
+			checker.CheckDomainsWithCallback(runCtx, s.checkBackend(), pending, concurrency, handle)
+		}
+
+		sortEntries(buffered)
+		for _, e := range buffered {
+			prog.print(func() { s.emit(e, outcomes[e.Domain]) })
+		}
+	}
+	if groupBy != "" {
+		prog.print(func() { s.emitGrouped(grouped) })
+	}
+	prog.finish()
+	if summaryMode == "on" && total > 0 {
+		summary.write(os.Stderr)
+	}
+
+	if cpErr != nil {
+		fmt.Fprintf(os.Stderr, "[%scheckpoint%s] %v\n", red, reset, cpErr)
+	}
+	rec.finish(ctx.Err() == nil)
+	if ctx.Err() != nil {
+		if err := cp.Close(); err != nil {
+			return err
+		}
+		return fmt.Errorf("interrupted after %d of %d domains; run the same command with --resume to continue", cp.Len(), len(domains))
+	}
+	if err := cp.Remove(); err != nil {
+		return err
+	}
+
+	if lengths != nil && !jsonOut && !quietOut && formatTmpl == nil {
+		printLengthReport(lengths)
+	}
+
+	if manifest != nil {
+		manifest.setInputs(in.keyword, in.tldSource, in.tlds, len(domains))
+		if err := manifest.write(manifestOut); err != nil {
+			return err
+		}
+	}
+
+	// The results are printed already; the exit code is all that is left
+	switch {
+	case failOnError && summary.failures > 0:
+		cmd.SilenceUsage = true
+		return &exitError{exitCheckErrors, fmt.Errorf("%d of %d checks failed", summary.failures, total)}
+	case failNone && found == 0:
+		cmd.SilenceUsage = true
+		return &exitError{exitNoneAvailable, fmt.Errorf("no available domains found")}
+	}
+	return nil
+}
+
+// inOrder wraps fn to receive results in the order of domains, holding each
+// back until the results of every domain before it were handed on. Nothing is
+// handed on once ctx is done. The returned flush hands on the results still
+// held, such as those of domains a backend renamed, once the checks are done.
+func inOrder(ctx context.Context, domains []string, fn func(checker.Result)) (func(checker.Result), func()) {
+	held := make(map[string][]checker.Result)
+	next := 0
+	release := func() {
+		for next < len(domains) && ctx.Err() == nil {
+			d := domains[next]
+			if len(held[d]) == 0 {
+				return
+			}
+			r := held[d][0]
+			held[d] = held[d][1:]
+			next++
+			fn(r)
+		}
+	}
+	handle := func(result checker.Result) {
+		held[result.Domain] = append(held[result.Domain], result)
+		release()
+	}
+	flush := func() {
+		for _, d := range domains {
+			if ctx.Err() != nil {
+				return
+			}
+			for len(held[d]) > 0 {
+				r := held[d][0]
+				held[d] = held[d][1:]
+				fn(r)
+			}
+		}
+		for _, rs := range held {
+			for _, r := range rs {
+				if ctx.Err() != nil {
+					return
+				}
+				fn(r)
+			}
+		}
+	}
+	return handle, flush
+}
+
+// sorters are the orders --sort accepts
+var sorters = map[string]func([]rank.Entry){
+	"rank":   rank.Sort,
+	"price":  rank.SortByPrice,
+	"avail":  rank.SortByAvailability,
+	"domain": rank.SortByDomain,
+	"expiry": rank.SortByExpiry,
+}
+
+// sortEntries orders buffered results by --sort, by rank when it is not given
+func sortEntries(entries []rank.Entry) {
+	if sort, ok := sorters[sortBy]; ok {
+		sort(entries)
+		return
+	}
+	rank.Sort(entries)
+}
+
+// groupedEntry is a result held back for --group-by
+type groupedEntry struct {
+	entry   rank.Entry
+	outcome hook.Outcome
+	// keyword is the title of the check group the domain came from
+	keyword string
+}
+
+// stateSections are the --group-by state sections in the order printed
+var stateSections = []string{"available", "premium", "reserved", "dropping", "parked", "for sale", "expiring", "taken", "error"}
+
+// emitGrouped prints results in sections by --group-by, each sorted by
+// --sort. Sections -x leaves empty are skipped.
+func (s *session) emitGrouped(grouped []groupedEntry) {
+	var order []string
+	sections := make(map[string][]rank.Entry)
+	outcomes := make(map[string]hook.Outcome, len(grouped))
+	for _, g := range grouped {
+		if !jsonOut && !shown(g.entry, onlyAvail || quietOut) {
+			continue
+		}
+		var key string
+		switch groupBy {
+		case "tld":
+			_, suffix, _ := strings.Cut(g.entry.Domain, ".")
+			key = tld.ToUnicode("." + suffix)
+		case "keyword":
+			key = g.keyword
+			if key == "" {
+				key = "domains"
+			}
+		case "state":
+			key = entryState(g.entry)
+		}
+		if _, ok := sections[key]; !ok {
+			order = append(order, key)
+		}
+		sections[key] = append(sections[key], g.entry)
+		outcomes[g.entry.Domain] = g.outcome
+	}
+
+	switch groupBy {
+	case "tld":
+		sort.Strings(order)
+	case "state":
+		order = slices.DeleteFunc(slices.Clone(stateSections), func(state string) bool {
+			_, ok := sections[state]
+			return !ok
+		})
+	}
+
+	for i, key := range order {
+		if !jsonOut && !quietOut && formatTmpl == nil {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s== %s (%d) ==%s\n", bold, key, len(sections[key]), reset)
+		}
+		entries := sections[key]
+		sortEntries(entries)
+		for _, e := range entries {
+			s.emit(e, outcomes[e.Domain])
+		}
+	}
+}
+
+// entryState names the --group-by state section of a result
+func entryState(e rank.Entry) string {
+	switch {
+	case e.Error != nil:
+		return "error"
+	case e.Available:
+		return "available"
+	case e.Premium:
+		return "premium"
+	case e.Reserved:
+		return "reserved"
+	case e.Dropping():
+		return "dropping"
+	case e.Parked:
+		return "parked"
+	case e.ForSale:
+		return "for sale"
+	}
+	if _, ok := expiringDays(e.Result); ok {
+		return "expiring"
+	}
+	return "taken"
+}
+
+// emit prints a kept result as text or JSON
+func (s *session) emit(e rank.Entry, o hook.Outcome) {
+	if quietOut {
+		if _, expiring := expiringDays(e.Result); expiring || (expiringWithin == 0 && e.Error == nil && e.Available != onlyRegistered) {
+			fmt.Println(e.Domain)
+		}
+		return
+	}
+	if onlyRegistered && e.Available && e.Error == nil {
+		return
+	}
+	if formatTmpl != nil {
+		if shown(e, onlyAvail) {
+			printFormatted(e)
+		}
+		return
+	}
+	saved := s.saved(e.Domain)
+	if jsonOut {
+		printJSON(e, onlyAvail, o, saved)
+		return
+	}
+
+	note := joinNotes(annotation(o), expiringNote(e.Result))
+	if e.Available && e.Error == nil {
+		note = joinNotes(note, brandWarning(e.Domain))
+	}
+	for _, se := range saved {
+		note = joinNotes(note, savedLabel(se))
+	}
+	printResult(e, onlyAvail, note)
+}
+
+// savedEntry is a domain's entry on one of the saved lists
+type savedEntry struct {
+	list  string
+	entry *store.Entry
+}
+
+// saved returns the list entries for domain
+func (s *session) saved(domain string) []savedEntry {
+	var out []savedEntry
+	for _, l := range s.lists {
+		if e, ok := l.Get(domain); ok {
+			out = append(out, savedEntry{list: l.Name, entry: e})
+		}
+	}
+	return out
+}
+
+// savedLabel formats a saved entry as "[shortlist: tag, tag] note"
+func savedLabel(se savedEntry) string {
+	label := se.list
+	if len(se.entry.Tags) > 0 {
+		label += ": " + strings.Join(se.entry.Tags, ", ")
+	}
+	label = orange + "[" + label + "]" + reset
+	if se.entry.Note != "" {
+		label += " " + se.entry.Note
+	}
+	return label
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	suggestCheck  bool
+	suggestThemes []string
+	suggestTLD    string
+)
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest <keyword>",
+	Short: "Suggest domains where the TLD completes or extends the keyword",
+	Long: "Suggest domains whose TLD reads as a natural ending of the keyword (host -> host.ing, bit -> bit.ly),\n" +
+		"using the loaded TLD list. --theme adds compounds with words from an embedded wordpack\n" +
+		"(in the TLD given with -e, default .com). Use --check to check the suggestions for availability.",
+	Args: cobra.ExactArgs(1),
+	RunE: runSuggest,
+}
+
+func init() {
+	suggestCmd.Flags().BoolVar(&suggestCheck, "check", false, "Check the suggested domains for availability")
+	suggestCmd.Flags().StringSliceVar(&suggestThemes, "theme", nil, "Wordpacks to compound the keyword with: "+strings.Join(generate.Themes(), ", "))
+	suggestCmd.Flags().StringVarP(&suggestTLD, "tld", "e", ".com", "TLD for themed compounds")
+	addCheckFlags(suggestCmd, "strategy")
+	rootCmd.AddCommand(suggestCmd)
+}
+
+func runSuggest(cmd *cobra.Command, args []string) error {
+	tlds := loadTLDs()
+	suggestions := generate.SuffixSuggestions(args[0], tlds)
+	if len(suggestThemes) > 0 {
+		themed, err := generate.ThemedSuggestions(args[0], suggestThemes, suggestTLD)
+		if err != nil {
+			return err
+		}
+		suggestions = append(suggestions, themed...)
+	}
+	if len(suggestions) == 0 {
+		fmt.Printf("No TLDs complete or extend %q\n", args[0])
+		return nil
+	}
+
+	if !suggestCheck {
+		for _, s := range suggestions {
+			word := ""
+			if s.Word {
+				word = bGreen + " (word)" + reset
+			}
+			fmt.Printf("%-24s %-10s score %3d  reads %s\n", s.Domain, s.Kind, s.Score, joinNotes(s.Reads+word, brandWarning(s.Reads)))
+		}
+		return nil
+	}
+
+	domains := make([]string, len(suggestions))
+	for i, s := range suggestions {
+		domains[i] = s.Domain
+	}
+
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	return sess.check(cmd, domains, checkInputs{keyword: args[0], tldSource: "suggest", tlds: tlds})
+}
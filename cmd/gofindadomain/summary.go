@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/metrics"
+)
+
+// summaryServers is how many of the fastest and slowest servers are listed
+const summaryServers = 3
+
+var summaryMode string
+
+// runSummary counts the results of a run for the summary printed after it
+type runSummary struct {
+	start                                         time.Time
+	available, taken, premium, reserved, failures int
+	// expiring counts the taken domains --expiring-within flags
+	expiring int
+}
+
+func newRunSummary() *runSummary {
+	return &runSummary{start: time.Now()}
+}
+
+// add counts a result
+func (s *runSummary) add(r checker.Result) {
+	switch {
+	case r.Error != nil:
+		s.failures++
+	case r.Available:
+		s.available++
+	case r.Premium:
+		s.premium++
+	case r.Reserved:
+		s.reserved++
+	default:
+		s.taken++
+	}
+	if _, ok := expiringDays(r); ok {
+		s.expiring++
+	}
+}
+
+// serverLatency is the mean query latency of a whois or RDAP server
+type serverLatency struct {
+	server  string
+	queries uint64
+	mean    time.Duration
+}
+
+// write prints the totals by state, the fastest and slowest servers, the
+// rate limit incidents and the elapsed time
+func (s *runSummary) write(w io.Writer) {
+	total := s.available + s.taken + s.premium + s.reserved + s.failures
+	fmt.Fprintf(w, "\n%s── Summary ──%s\n", bold, reset)
+	fmt.Fprintf(w, "%-13s %d checked in %s (%.1f/s)\n", "Domains:", total,
+		time.Since(s.start).Round(time.Millisecond), float64(total)/time.Since(s.start).Seconds())
+	fmt.Fprintf(w, "%-13s %s%d available%s, %d taken, %d premium, %d reserved, ", "Results:",
+		bGreen, s.available, reset, s.taken, s.premium, s.reserved)
+	if s.failures > 0 {
+		fmt.Fprintf(w, "%s%d errors%s\n", red, s.failures, reset)
+	} else {
+		fmt.Fprintln(w, "0 errors")
+	}
+	if expiringWithin > 0 {
+		fmt.Fprintf(w, "%-13s %s%d%s within %s\n", "Expiring:", red, s.expiring, reset, expiringSpec)
+	}
+
+	var servers []serverLatency
+	metrics.QueryDuration.Each(func(values []string, count uint64, sum float64) {
+		if count > 0 {
+			servers = append(servers, serverLatency{
+				server:  values[1],
+				queries: count,
+				mean:    time.Duration(sum / float64(count) * float64(time.Second)),
+			})
+		}
+	})
+	sort.Slice(servers, func(i, j int) bool { return servers[i].mean < servers[j].mean })
+	if len(servers) > 0 {
+		n := min(summaryServers, len(servers))
+		fmt.Fprintf(w, "%-13s %s\n", "Fastest:", formatLatencies(servers[:n]))
+		if len(servers) > n {
+			slowest := servers[max(n, len(servers)-summaryServers):]
+			for i, j := 0, len(slowest)-1; i < j; i, j = i+1, j-1 {
+				slowest[i], slowest[j] = slowest[j], slowest[i]
+			}
+			fmt.Fprintf(w, "%-13s %s\n", "Slowest:", formatLatencies(slowest))
+		}
+	}
+
+	var limited []string
+	incidents := 0.0
+	metrics.RateLimited.Each(func(values []string, v float64) {
+		incidents += v
+		limited = append(limited, fmt.Sprintf("%s %.0f", values[0], v))
+	})
+	if incidents > 0 {
+		fmt.Fprintf(w, "%-13s %s%.0f%s (%s)\n", "Rate limited:", orange, incidents, reset, strings.Join(limited, ", "))
+	} else {
+		fmt.Fprintf(w, "%-13s none\n", "Rate limited:")
+	}
+}
+
+// formatLatencies lists servers with their mean latency and query count
+func formatLatencies(servers []serverLatency) string {
+	parts := make([]string, len(servers))
+	for i, s := range servers {
+		parts[i] = fmt.Sprintf("%s %s (%d)", s.server, s.mean.Round(time.Millisecond), s.queries)
+	}
+	return strings.Join(parts, ", ")
+}
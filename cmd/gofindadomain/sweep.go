@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/sweep"
+	"github.com/james-see/gofindadomain/internal/tld"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sweepEvery time.Duration
+	sweepHTML  string
+)
+
+var sweepCmd = &cobra.Command{
+	Use:   "sweep",
+	Short: "Re-run saved sweeps and report how the TLD landscape changes",
+	Long: "A sweep is a keyword checked across a fixed TLD list. Each run is recorded, so reports can show\n" +
+		"which TLDs were taken or freed since last month and how availability trends over time.",
+}
+
+var sweepSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save a sweep definition",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSweepSave,
+}
+
+var sweepListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved sweeps",
+	Args:  cobra.NoArgs,
+	RunE:  runSweepList,
+}
+
+var sweepRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "Run a sweep now, or every --every interval",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSweepRun,
+}
+
+var sweepReportCmd = &cobra.Command{
+	Use:   "report <name>",
+	Short: "Write an HTML trend report for a sweep",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSweepReport,
+}
+
+func init() {
+	sweepSaveCmd.Flags().StringVarP(&keyword, "keyword", "k", "", "Keyword to sweep")
+	sweepSaveCmd.Flags().StringVarP(&singleTLD, "tld", "e", "", "Single TLD to sweep (e.g., .com)")
+	sweepSaveCmd.Flags().StringVarP(&tldFile, "tld-file", "E", "", "File containing TLDs to sweep")
+	addTLDFilterFlags(sweepSaveCmd)
+
+	sweepRunCmd.Flags().DurationVar(&sweepEvery, "every", 0, "Keep running, re-running the sweep at this interval (e.g. 720h)")
+	sweepRunCmd.Flags().StringVar(&sweepHTML, "html", "", "Write the HTML trend report to this file after each run")
+	sweepRunCmd.Flags().IntVarP(&concurrency, "concurrency", "c", 30, "Number of concurrent checks")
+	addBackendFlag(sweepRunCmd)
+	addMetricsFlag(sweepRunCmd)
+
+	sweepReportCmd.Flags().StringVar(&sweepHTML, "html", "", "Report file (default <name>.html)")
+
+	sweepCmd.AddCommand(sweepSaveCmd, sweepListCmd, sweepRunCmd, sweepReportCmd)
+	rootCmd.AddCommand(sweepCmd)
+}
+
+func runSweepSave(cmd *cobra.Command, args []string) error {
+	if keyword == "" {
+		return fmt.Errorf("keyword is required (-k)")
+	}
+	label, err := tld.ToASCII(keyword)
+	if err != nil {
+		return err
+	}
+	if err := tld.ValidateLabel(label); err != nil {
+		return fmt.Errorf("invalid keyword: %w", err)
+	}
+	tlds, _, err := resolveTLDs(singleTLD, tldFile)
+	if err != nil {
+		return err
+	}
+
+	def := sweep.Definition{Name: args[0], Keyword: label, TLDs: tlds, Created: time.Now().UTC()}
+	if err := sweep.Save(def); err != nil {
+		return err
+	}
+	fmt.Printf("Saved sweep %s: %s across %d TLDs\n", def.Name, def.Keyword, len(def.TLDs))
+	return nil
+}
+
+func runSweepList(cmd *cobra.Command, args []string) error {
+	defs, err := sweep.List()
+	if err != nil {
+		return err
+	}
+	if len(defs) == 0 {
+		fmt.Println("No sweeps saved")
+		return nil
+	}
+
+	for _, d := range defs {
+		snaps, err := sweep.History(d.Name)
+		if err != nil {
+			return err
+		}
+		last := "never run"
+		if len(snaps) > 0 {
+			s := snaps[len(snaps)-1]
+			last = fmt.Sprintf("last run %s, %.1f%% available", s.Time.Local().Format("2006-01-02 15:04"), s.AvailabilityPct())
+		}
+		fmt.Printf("%s%-16s%s %s across %d TLDs - %d runs, %s\n", bold, d.Name, reset, d.Keyword, len(d.TLDs), len(snaps), last)
+	}
+	return nil
+}
+
+func runSweepRun(cmd *cobra.Command, args []string) error {
+	def, err := sweep.Load(args[0])
+	if err != nil {
+		return err
+	}
+
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	for {
+		if err := runSweepOnce(sess, def); err != nil {
+			return err
+		}
+		if sweepEvery <= 0 {
+			return nil
+		}
+		next := time.Now().Add(sweepEvery)
+		fmt.Printf("Next run at %s\n\n", next.Format("2006-01-02 15:04"))
+		time.Sleep(time.Until(next))
+	}
+}
+
+// runSweepOnce checks the sweep's domains, records the snapshot and prints
+// what changed since the previous run
+func runSweepOnce(sess *session, def sweep.Definition) error {
+	prev, err := sweep.History(def.Name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Sweeping %s across %d TLDs...\n", def.Keyword, len(def.TLDs))
+	snap := sweep.Snapshot{Time: time.Now().UTC()}
+	checker.CheckDomainsWithCallback(context.Background(), sess.backend, def.Domains(), concurrency, func(r checker.Result) {
+		switch {
+		case r.Error != nil:
+			snap.Errors = append(snap.Errors, r.Domain)
+		case r.Available:
+			snap.Available = append(snap.Available, r.Domain)
+		default:
+			snap.Taken = append(snap.Taken, r.Domain)
+		}
+	})
+
+	if err := sweep.Record(def.Name, snap); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s%d available%s, %s%d taken%s, %d errors (%.1f%% available)\n",
+		bGreen, len(snap.Available), reset, bRed, len(snap.Taken), reset, len(snap.Errors), snap.AvailabilityPct())
+	if len(prev) > 0 {
+		last := prev[len(prev)-1]
+		c := sweep.Compare(last, snap)
+		fmt.Printf("Since %s (%.1f%% available):\n", last.Time.Local().Format("2006-01-02 15:04"), last.AvailabilityPct())
+		if len(c.NewlyTaken) == 0 && len(c.NewlyAvailable) == 0 {
+			fmt.Println("  no changes")
+		}
+		for _, d := range c.NewlyTaken {
+			fmt.Printf("  [%snewly taken%s] %s\n", bRed, reset, d)
+		}
+		for _, d := range c.NewlyAvailable {
+			fmt.Printf("  [%snewly available%s] %s\n", bGreen, reset, d)
+		}
+	}
+
+	if sweepHTML != "" {
+		return writeSweepReport(def, sweepHTML)
+	}
+	return nil
+}
+
+func runSweepReport(cmd *cobra.Command, args []string) error {
+	def, err := sweep.Load(args[0])
+	if err != nil {
+		return err
+	}
+	out := sweepHTML
+	if out == "" {
+		out = def.Name + ".html"
+	}
+	return writeSweepReport(def, out)
+}
+
+func writeSweepReport(def sweep.Definition, path string) error {
+	snaps, err := sweep.History(def.Name)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	if err := sweep.WriteHTML(f, def, snaps); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Printf("Report written to %s\n", path)
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"github.com/james-see/gofindadomain/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Launch the interactive terminal UI",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI()
+	},
+}
+
+func init() {
+	addBackendFlag(tuiCmd)
+	addQueryFlags(tuiCmd)
+	addVerifyFlag(tuiCmd)
+	addPricingFlag(tuiCmd)
+	addEnrichFlag(tuiCmd)
+	tuiCmd.Flags().StringVar(&expiringSpec, "expiring-within", "", "Flag taken domains expiring within this many days (e.g., 60d) and sort results by expiry")
+	rootCmd.AddCommand(tuiCmd)
+}
+
+// runTUI checks domains interactively against every known TLD and
+// second-level suffix
+func runTUI() error {
+	if err := parseExpiringWithin(); err != nil {
+		return err
+	}
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	tlds := append(loadTLDs(), loadSuffixes()...)
+	tui.ApplyBranding(sess.banner, sess.cfg.Branding.Colors)
+	return tui.Run(tlds, tui.Options{Backend: sess.checkBackend(), Ranker: sess.ranker, Lists: sess.lists, Store: sess.listStore, Registrar: sess.cfg.Registrar.URL, Theme: sess.cfg.TUI.Theme, Concurrency: concurrency, ExpiringWithin: expiringWithin, Listings: len(sess.marketplaces) > 0})
+}
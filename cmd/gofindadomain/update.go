@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/tld"
+	"github.com/spf13/cobra"
+)
+
+var updateTLDsCmd = &cobra.Command{
+	Use:   "update-tlds",
+	Short: "Update the TLD list and per-TLD whois servers from IANA",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runUpdateTLDs()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(updateTLDsCmd)
+}
+
+// runUpdateTLDs refreshes tlds.txt and the whois server registry
+func runUpdateTLDs() error {
+	fmt.Println("Fetching TLD data from IANA...")
+	updated, err := tld.UpdateTLDFile("tlds.txt")
+	if err != nil {
+		return err
+	}
+	if !updated {
+		fmt.Println("TLD list is already up to date")
+	} else {
+		fmt.Println("TLDs have been saved to tlds.txt")
+	}
+
+	fmt.Println("Fetching second-level suffixes from the Public Suffix List...")
+	updated, err = tld.UpdateSuffixFile("suffixes.txt")
+	if err != nil {
+		return err
+	}
+	if !updated {
+		fmt.Println("Suffix list is already up to date")
+	} else {
+		fmt.Println("Suffixes have been saved to suffixes.txt")
+	}
+
+	fmt.Println("Fetching whois servers from IANA...")
+	tlds := loadTLDs()
+	failed, err := tld.UpdateServers(context.Background(), tlds, checker.LookupWhoisServer)
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		fmt.Printf("Could not look up the whois server of %d TLDs; kept their previous entries\n", failed)
+	}
+	path, _ := tld.ServersPath()
+	fmt.Printf("Whois servers for %d TLDs have been saved to %s\n", len(tlds)-failed, path)
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/generate"
+	"github.com/james-see/gofindadomain/internal/tld"
+	"github.com/spf13/cobra"
+)
+
+// tldSwap is the --kind checking the brand's name in other TLDs
+const tldSwap = "tld"
+
+// swapTLDs are the TLDs the brand's name is tried in without -e or -E
+var swapTLDs = []string{".com", ".net", ".org", ".co", ".io", ".info", ".biz", ".app", ".online", ".site", ".shop", ".xyz"}
+
+var (
+	variantKinds []string
+	variantsAll  bool
+	// onlyRegistered hides available results, for variants where the
+	// registered ones are the finding
+	onlyRegistered bool
+)
+
+var variantsCmd = &cobra.Command{
+	Use:   "variants <domain>",
+	Short: "Find registered typosquats and lookalikes of a brand domain",
+	Long: "Generate the typos, homoglyphs, character swaps and TLD swaps of a brand domain and check which are\n" +
+		"registered, to find phishing and typosquatting domains. Typos are checked in the brand's TLD and the\n" +
+		"brand name in the TLDs from -e or -E (default a set of popular ones).\n" +
+		"Only registered variants are shown unless --all or -x is given.",
+	Args: cobra.ExactArgs(1),
+	RunE: runVariants,
+}
+
+func init() {
+	variantsCmd.Flags().StringSliceVarP(&variantKinds, "kind", "k", []string{"all"}, "Variants to check: "+strings.Join(generate.TypoKinds(), ", ")+", "+tldSwap+", or all")
+	variantsCmd.Flags().BoolVar(&variantsAll, "all", false, "Show available variants as well as registered ones")
+	variantsCmd.Flags().StringVarP(&singleTLD, "tld", "e", "", "Single TLD to swap in (e.g., .net)")
+	variantsCmd.Flags().StringVarP(&tldFile, "tld-file", "E", "", "File containing TLDs to swap in")
+	addTLDFilterFlags(variantsCmd)
+	addCheckFlags(variantsCmd, "strategy")
+	rootCmd.AddCommand(variantsCmd)
+}
+
+func runVariants(cmd *cobra.Command, args []string) error {
+	domain, err := tld.ToASCII(strings.TrimSuffix(strings.TrimSpace(args[0]), "."))
+	if err != nil {
+		return err
+	}
+	label, suffix, ok := strings.Cut(domain, ".")
+	if !ok {
+		return fmt.Errorf("invalid domain %q (use a full domain such as example.com)", args[0])
+	}
+	if err := tld.ValidateLabel(label); err != nil {
+		return err
+	}
+	brandTLD := "." + suffix
+
+	kinds := slices.Clone(variantKinds)
+	for _, k := range kinds {
+		if k != "all" && k != tldSwap && !slices.Contains(generate.TypoKinds(), k) {
+			return fmt.Errorf("invalid --kind %q (use %s, %s or all)", k, strings.Join(generate.TypoKinds(), ", "), tldSwap)
+		}
+	}
+	swap := slices.Contains(kinds, "all") || slices.Contains(kinds, tldSwap)
+	kinds = slices.DeleteFunc(kinds, func(k string) bool { return k == tldSwap })
+	sets, err := generate.Typos(tld.ToUnicode(label), kinds)
+	if err != nil {
+		return err
+	}
+
+	var domains []string
+	var groups []checkGroup
+	for _, set := range sets {
+		g := checkGroup{title: set.Strategy, keyword: label}
+		for _, v := range set.Labels {
+			ascii, err := tld.ToASCII(v)
+			if err != nil || tld.ValidateLabel(ascii) != nil {
+				continue
+			}
+			g.domains = append(g.domains, ascii+brandTLD)
+		}
+		if g.domains = allowedDomains(g.domains); len(g.domains) > 0 {
+			domains = append(domains, g.domains...)
+			groups = append(groups, g)
+		}
+	}
+
+	tlds, tldSource := swapTLDs, "variants"
+	if swap {
+		if singleTLD != "" || tldFile != "" {
+			tlds, tldSource, err = resolveTLDs(singleTLD, tldFile)
+		} else if len(includeTLDs) > 0 || len(excludeTLDs) > 0 {
+			tlds, err = tld.Filter(tlds, includeTLDs, excludeTLDs)
+		}
+		if err != nil {
+			return err
+		}
+		g := checkGroup{title: tldSwap, keyword: label}
+		for _, t := range tlds {
+			if t != brandTLD {
+				g.domains = append(g.domains, label+t)
+			}
+		}
+		if g.domains = allowedDomains(g.domains); len(g.domains) > 0 {
+			domains = append(domains, g.domains...)
+			groups = append(groups, g)
+		}
+	}
+	if len(domains) == 0 {
+		return fmt.Errorf("no variants of %s to check", tld.ToUnicode(domain))
+	}
+
+	onlyRegistered = !variantsAll && !onlyAvail
+
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	return sess.check(cmd, domains, checkInputs{keyword: label, tldSource: tldSource, tlds: tlds, groups: groups})
+}
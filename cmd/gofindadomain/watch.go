@@ -0,0 +1,160 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/store"
+	"github.com/james-see/gofindadomain/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+var watchEvery time.Duration
+
+var watchCmd = &cobra.Command{
+	Use:   "watch [domain...]",
+	Short: "Watch taken domains drop through redemption and pending delete",
+	Long: "Check taken domains (by default the watchlist) and report EPP lifecycle transitions such as\n" +
+		"redemptionPeriod -> pendingDelete, alerting when a domain becomes available. The last known\n" +
+		"state is kept in ~/.config/gofindadomain/watch.json, so runs can also be scheduled with cron.",
+	RunE: runWatch,
+}
+
+func init() {
+	watchCmd.Flags().DurationVar(&watchEvery, "every", 0, "Keep running, re-checking at this interval (e.g. 6h)")
+	addQueryFlags(watchCmd)
+	addBackendFlag(watchCmd)
+	addNotifyFlag(watchCmd)
+	addMetricsFlag(watchCmd)
+	rootCmd.AddCommand(watchCmd)
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	sess, err := newSession()
+	if err != nil {
+		return err
+	}
+	defer sess.close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for {
+		err := watchRound(ctx, sess, args)
+		if watchEvery <= 0 {
+			return err
+		}
+		// A failed round, such as an unreachable store, must not end a
+		// long-running watch; the next round tries again
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[%swatch%s] %v\n", red, reset, err)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		next := time.Now().Add(watchEvery)
+		fmt.Printf("Next check at %s\n\n", next.Format("2006-01-02 15:04"))
+		select {
+		case <-time.After(time.Until(next)):
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// watchRound checks the given domains, or else the watchlist, once
+func watchRound(ctx context.Context, sess *session, args []string) error {
+	domains := args
+	if len(domains) == 0 {
+		var err error
+		if domains, err = watchlistDomains(); err != nil {
+			return err
+		}
+	}
+	if len(domains) == 0 {
+		return fmt.Errorf("nothing to watch: pass domains or add them with `gofindadomain watchlist add`")
+	}
+	return runWatchOnce(ctx, sess, domains)
+}
+
+// watchlistDomains loads the watchlist afresh, so domains added while
+// watching are picked up on the next round
+func watchlistDomains() ([]string, error) {
+	b, err := storeBackend()
+	if err != nil {
+		return nil, err
+	}
+	l, err := store.Open(b, store.Watchlist)
+	if err != nil {
+		return nil, err
+	}
+	var domains []string
+	for _, e := range l.Entries("") {
+		domains = append(domains, e.Domain)
+	}
+	return domains, nil
+}
+
+// runWatchOnce checks the domains, prints each one's phase and any
+// transition since the last check, and saves the new state
+func runWatchOnce(ctx context.Context, sess *session, domains []string) error {
+	state, err := watch.Load()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	fmt.Printf("%sWatching %d domains%s (%s)\n", bold, len(domains), reset, time.Now().Format("2006-01-02 15:04"))
+	checker.CheckDomainsWithCallback(ctx, sess.checkBackend(), domains, concurrency, func(r checker.Result) {
+		if r.Error != nil {
+			fmt.Printf("[%serror%s] %s - %v\n", red, reset, r.Domain, r.Error)
+			return
+		}
+
+		prev, seen := state[r.Domain]
+		t, changed := state.Update(r, now)
+		phase := watch.Phase(r)
+
+		line := fmt.Sprintf("%s - %s", r.Domain, phaseLabel(phase))
+		if changed {
+			line = fmt.Sprintf("%s - %s -> %s", r.Domain, phaseLabel(t.From), phaseLabel(t.To))
+		} else if seen {
+			line += " since " + prev.Since.Local().Format("2006-01-02")
+		}
+		if phase == watch.Registered && r.ExpiryDate != "" {
+			line += " - Exp Date: " + orange + r.ExpiryDate + reset
+		}
+		fmt.Println(line)
+
+		// Alert once, when the domain is first seen available
+		if phase == watch.Available && (!seen || prev.Phase != watch.Available) {
+			alertDrop(sess, r)
+		}
+	})
+	sess.notifyWait()
+
+	return state.Save()
+}
+
+// phaseLabel colors a lifecycle phase by how close the domain is to dropping
+func phaseLabel(phase string) string {
+	switch phase {
+	case watch.Available:
+		return bGreen + phase + reset
+	case watch.PendingDelete, watch.RedemptionPeriod, watch.PendingRestore:
+		return orange + phase + reset
+	}
+	return phase
+}
+
+// alertDrop announces a domain that became available and alerts the
+// notification sinks
+func alertDrop(sess *session, r checker.Result) {
+	msg := r.Domain + " is available"
+	fmt.Printf("\a%s%s%s\n", bGreen, msg, reset)
+	sess.notify(r, msg)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var whoisVerbose bool
+
+var whoisCmd = &cobra.Command{
+	Use:   "whois <domain>",
+	Short: "Show how a domain's whois response is classified",
+	Long:  "Run a whois lookup for a single domain and print the fields the classifier parsed from it.\nUse -v to also print the raw whois response.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runWhois,
+}
+
+func init() {
+	whoisCmd.Flags().BoolVarP(&whoisVerbose, "verbose", "v", false, "Also print the charset, language and whois response")
+	rootCmd.AddCommand(whoisCmd)
+}
+
+func runWhois(cmd *cobra.Command, args []string) error {
+	domain := strings.ToLower(strings.TrimSpace(args[0]))
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+	if err := configureWhois(cfg.Whois); err != nil {
+		return err
+	}
+	resp, err := checker.LookupWhois(context.Background(), domain)
+	if err != nil {
+		return fmt.Errorf("whois lookup for %s failed: %w", domain, err)
+	}
+	output, charset := checker.Decode(domain, resp.Raw)
+
+	result := checker.Parse(domain, output)
+	_, indicator := checker.Classify(domain, output)
+	if _, _, restriction := checker.Restriction(domain, output); restriction != "" {
+		indicator = restriction
+	}
+	printWhoisFields(result, indicator)
+
+	if whoisVerbose {
+		fmt.Printf("%-13s %s\n", "Servers:", strings.Join(resp.Servers, " -> "))
+		fmt.Printf("%-13s %s\n", "Charset:", charset)
+		if lang := checker.Language(output); lang != "" {
+			fmt.Printf("%-13s %s\n", "Language:", lang)
+		}
+		fmt.Printf("\n%s--- whois response (UTF-8) ---%s\n", bold, reset)
+		fmt.Print(output)
+		if !strings.HasSuffix(output, "\n") {
+			fmt.Println()
+		}
+	}
+
+	return nil
+}
+
+func printWhoisFields(r checker.Result, indicator string) {
+	fmt.Printf("%-13s %s\n", "Domain:", r.Domain)
+
+	if r.Error != nil {
+		fmt.Printf("%-13s %s%v%s\n", "Status:", red, r.Error, reset)
+		return
+	}
+	if r.Available {
+		fmt.Printf("%-13s %savailable%s\n", "Status:", bGreen, reset)
+	} else if restriction := r.Restriction(); restriction != "" {
+		fmt.Printf("%-13s %s%s%s\n", "Status:", orange, restriction, reset)
+	} else {
+		fmt.Printf("%-13s %staken%s\n", "Status:", bRed, reset)
+	}
+
+	fmt.Printf("%-13s %s\n", "Confidence:", r.Confidence)
+	if indicator != "" {
+		fmt.Printf("%-13s %q\n", "Indicator:", indicator)
+	} else {
+		fmt.Printf("%-13s none matched (assumed available)\n", "Indicator:")
+	}
+
+	if !r.Available && r.Restriction() == "" {
+		if r.CreatedDate != "" {
+			created := r.CreatedDate
+			if age := checker.Age(created, time.Now()); age != "" {
+				created += " (" + age + ")"
+			}
+			fmt.Printf("%-13s %s\n", "Created:", created)
+		}
+		if registrant := registrantLabel(r.RegistrantOrg, r.RegistrantCountry, r.RegistrantPrivacy); registrant != "" {
+			fmt.Printf("%-13s %s\n", "Registrant:", registrant)
+		}
+		if r.Registrar != "" {
+			fmt.Printf("%-13s %s\n", "Registrar:", r.Registrar)
+		}
+		if r.AbuseEmail != "" {
+			fmt.Printf("%-13s %s\n", "Abuse:", r.AbuseEmail)
+		}
+		if r.UpdatedDate != "" {
+			fmt.Printf("%-13s %s\n", "Updated:", r.UpdatedDate)
+		}
+		if r.ExpiryDate != "" {
+			fmt.Printf("%-13s %s%s%s\n", "Expiry:", orange, r.ExpiryDate, reset)
+		} else {
+			fmt.Printf("%-13s not found\n", "Expiry:")
+		}
+		printRecordLists(r)
+	}
+}
+
+// printRecordLists prints the EPP status codes, DNSSEC state and nameservers
+// of a taken domain, aligned with the other record fields
+func printRecordLists(r checker.Result) {
+	if len(r.Status) > 0 {
+		fmt.Printf("%-13s %s\n", "EPP Status:", strings.Join(r.Status, ", "))
+	}
+	fmt.Printf("%-13s %s\n", "DNSSEC:", dnssecLabel(r.DNSSEC))
+	for i, ns := range r.Nameservers {
+		label := ""
+		if i == 0 {
+			label = "Nameservers:"
+		}
+		fmt.Printf("%-13s %s\n", label, ns)
+	}
+}
+
+func dnssecLabel(signed bool) string {
+	if signed {
+		return "signed"
+	}
+	return "unsigned"
+}
@@ -10,3 +10,6 @@ var EmbeddedTLDs string
 //go:embed top-12.txt
 var EmbeddedTop12 string
 
+//go:embed suffixes.txt
+var EmbeddedSuffixes string
+
@@ -0,0 +1,122 @@
+// Package aftermarket asks domain marketplaces whether taken domains are
+// listed for sale and at what price.
+package aftermarket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/plugin"
+)
+
+// ErrNotListed is returned for domains a marketplace does not list
+var ErrNotListed = errors.New("not listed")
+
+var client = &http.Client{Timeout: 15 * time.Second}
+
+// Source is a marketplace that can be asked about a domain
+type Source interface {
+	Listing(ctx context.Context, domain string) (checker.Listing, error)
+}
+
+// Open returns the sources configured in cfg, in order, starting plugin
+// sources. The returned func stops them.
+func Open(cfg config.Aftermarket) ([]Source, func(), error) {
+	names := cfg.Sources
+	if len(names) == 0 {
+		names = []string{"sedo"}
+	}
+
+	var sources []Source
+	var plugins []*plugin.Plugin
+	stop := func() {
+		for _, p := range plugins {
+			p.Close()
+		}
+	}
+	for _, name := range names {
+		if pluginName, ok := strings.CutPrefix(name, "plugin:"); ok {
+			info, err := plugin.Find(plugin.KindAftermarket, pluginName)
+			if err != nil {
+				stop()
+				return nil, nil, err
+			}
+			p, err := plugin.Start(info)
+			if err != nil {
+				stop()
+				return nil, nil, err
+			}
+			plugins = append(plugins, p)
+			sources = append(sources, pluginSource{name: pluginName, plugin: p})
+			continue
+		}
+
+		switch name {
+		case "sedo":
+			s, err := newSedo(cfg.Sedo)
+			if err != nil {
+				stop()
+				return nil, nil, err
+			}
+			sources = append(sources, s)
+		default:
+			stop()
+			return nil, nil, fmt.Errorf("unknown aftermarket source %q (use sedo or plugin:<name>)", name)
+		}
+	}
+	return sources, stop, nil
+}
+
+// Backend wraps a checker.Backend and looks up the domains it finds taken on
+// the marketplaces. A listing marks the domain for sale.
+type Backend struct {
+	checker.Backend
+	Sources []Source
+}
+
+// Check implements checker.Backend
+func (b Backend) Check(ctx context.Context, domain string) checker.Result {
+	r := b.Backend.Check(ctx, domain)
+	if r.Available || r.Error != nil || r.Restriction() != "" {
+		return r
+	}
+
+	// A failed lookup is treated like no listing; it must not fail the check
+	for _, s := range b.Sources {
+		l, err := s.Listing(ctx, domain)
+		if err != nil {
+			continue
+		}
+		r.Listing = &l
+		r.ForSale = true
+		if r.SaleURL == "" {
+			r.SaleURL = l.URL
+		}
+		break
+	}
+	return r
+}
+
+// pluginSource asks an aftermarket plugin
+type pluginSource struct {
+	name   string
+	plugin *plugin.Plugin
+}
+
+// Listing implements Source
+func (s pluginSource) Listing(ctx context.Context, domain string) (checker.Listing, error) {
+	l, err := s.plugin.Listing(ctx, domain)
+	if err != nil {
+		return checker.Listing{}, err
+	}
+	if !l.Listed {
+		return checker.Listing{}, ErrNotListed
+	}
+	return checker.Listing{Marketplace: s.name, Price: l.Price, Currency: l.Currency, URL: l.URL}, nil
+}
@@ -0,0 +1,92 @@
+package aftermarket
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+const SedoStatusURL = "https://api.sedo.com/api/v1/DomainStatus"
+
+// sedoCurrencies maps the currency codes of the Sedo API
+var sedoCurrencies = map[int]string{0: "EUR", 1: "USD", 2: "GBP"}
+
+// sedoSource asks the Sedo partner API whether a domain is listed. It needs
+// a partner ID and signkey.
+type sedoSource struct {
+	partnerID string
+	signKey   string
+}
+
+func newSedo(cfg config.Sedo) (sedoSource, error) {
+	s := sedoSource{partnerID: cfg.PartnerID, signKey: cfg.SignKey}
+	if env := os.Getenv("GOFINDADOMAIN_SEDO_PARTNER_ID"); env != "" {
+		s.partnerID = env
+	}
+	if env := os.Getenv("GOFINDADOMAIN_SEDO_SIGNKEY"); env != "" {
+		s.signKey = env
+	}
+	if s.partnerID == "" || s.signKey == "" {
+		return sedoSource{}, fmt.Errorf("sedo needs aftermarket.sedo.partner_id and sign_key in the config or $GOFINDADOMAIN_SEDO_PARTNER_ID and $GOFINDADOMAIN_SEDO_SIGNKEY")
+	}
+	return s, nil
+}
+
+// Listing implements Source
+func (s sedoSource) Listing(ctx context.Context, domain string) (checker.Listing, error) {
+	q := url.Values{
+		"partnerid":     {s.partnerID},
+		"signkey":       {s.signKey},
+		"domainlist":    {domain},
+		"output_method": {"xml"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, SedoStatusURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return checker.Listing{}, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return checker.Listing{}, fmt.Errorf("failed to fetch sedo listing: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return checker.Listing{}, fmt.Errorf("failed to fetch sedo listing: HTTP %d", resp.StatusCode)
+	}
+
+	// Errors come back with status 200 as a SEDOFAULT document
+	var body struct {
+		XMLName xml.Name
+		Fault   string `xml:"faultstring"`
+		Items   []struct {
+			Domain   string  `xml:"domain"`
+			ForSale  int     `xml:"forsale"`
+			Price    float64 `xml:"price"`
+			Currency int     `xml:"currency"`
+		} `xml:"item"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return checker.Listing{}, fmt.Errorf("failed to parse sedo listing: %w", err)
+	}
+	if body.XMLName.Local == "SEDOFAULT" {
+		return checker.Listing{}, fmt.Errorf("failed to fetch sedo listing: %s", body.Fault)
+	}
+	for _, item := range body.Items {
+		if item.ForSale != 1 {
+			continue
+		}
+		return checker.Listing{
+			Marketplace: "sedo",
+			Price:       item.Price,
+			Currency:    sedoCurrencies[item.Currency],
+			URL:         "https://sedo.com/search/details/?domain=" + url.QueryEscape(domain),
+		}, nil
+	}
+	return checker.Listing{}, ErrNotListed
+}
@@ -0,0 +1,144 @@
+// Package brand flags names that look or sound like well-known brands.
+package brand
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed brands.txt
+var embeddedBrands string
+
+var brands = loadBrands(embeddedBrands)
+
+// Match is a brand a name is confusingly similar to
+type Match struct {
+	Brand string
+	// Distance is the edit distance between the name and the brand
+	Distance int
+	// Phonetic is true when the name and the brand sound alike (same Soundex code)
+	Phonetic bool
+}
+
+// Similar returns the brand closest to the first label of domain, if it is
+// close enough to be confused with it
+func Similar(domain string) (Match, bool) {
+	label := strings.ToLower(domain)
+	if i := strings.Index(label, "."); i >= 0 {
+		label = label[:i]
+	}
+	if len(label) < 3 {
+		return Match{}, false
+	}
+
+	code := Soundex(label)
+	best := Match{Distance: -1}
+	for _, b := range brands {
+		d := Distance(label, b)
+		phonetic := code != "" && code == Soundex(b)
+		if !collides(label, b, d, phonetic) {
+			continue
+		}
+		if best.Distance < 0 || d < best.Distance {
+			best = Match{Brand: b, Distance: d, Phonetic: phonetic}
+		}
+	}
+	return best, best.Distance >= 0
+}
+
+// Confusable reports whether name is close enough to other, by spelling or
+// sound, to be confused with it. Both are compared in lower case.
+func Confusable(name, other string) bool {
+	name, other = strings.ToLower(name), strings.ToLower(other)
+	code := Soundex(name)
+	return collides(name, other, Distance(name, other), code != "" && code == Soundex(other))
+}
+
+// collides decides whether a name at edit distance d from a brand is too close
+func collides(name, brand string, d int, phonetic bool) bool {
+	n := max(len(name), len(brand))
+	switch {
+	case d == 0:
+		return true
+	case d == 1 && n >= 6:
+		return true
+	case d == 2 && n >= 8:
+		return true
+	case n < 4:
+		return false
+	}
+	// Names that sound alike only collide while most letters still match
+	return phonetic && d*3 <= n
+}
+
+// Distance returns the Levenshtein edit distance between a and b
+func Distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// soundexCodes maps letters to their Soundex digit; vowels and h, w, y are 0
+var soundexCodes = [26]byte{
+	'0', '1', '2', '3', '0', '1', '2', '0', '0', '2', '2', '4', '5',
+	'5', '0', '1', '2', '6', '2', '3', '0', '1', '0', '2', '0', '2',
+}
+
+// Soundex returns the four-character American Soundex code of s, or "" if s
+// has no letters
+func Soundex(s string) string {
+	var out []byte
+	var last byte
+	for i := 0; i < len(s) && len(out) < 4; i++ {
+		c := s[i] | 0x20
+		if c < 'a' || c > 'z' {
+			continue
+		}
+		code := soundexCodes[c-'a']
+		if len(out) == 0 {
+			out = append(out, c-'a'+'A')
+			last = code
+			continue
+		}
+		// h and w do not separate letters with the same code
+		if c == 'h' || c == 'w' {
+			continue
+		}
+		if code != '0' && code != last {
+			out = append(out, code)
+		}
+		last = code
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	for len(out) < 4 {
+		out = append(out, '0')
+	}
+	return string(out)
+}
+
+func loadBrands(data string) []string {
+	var out []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line != "" && !strings.HasPrefix(line, "#") {
+			out = append(out, line)
+		}
+	}
+	return out
+}
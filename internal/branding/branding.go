@@ -0,0 +1,89 @@
+package branding
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// BannerData is passed to banner templates
+type BannerData struct {
+	Version string
+	Locale  string
+}
+
+// Locale returns the user's locale from LC_ALL, LC_MESSAGES or LANG
+// (e.g. "de_DE"), without encoding or modifier suffixes
+func Locale() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(key)
+		if v == "" {
+			continue
+		}
+		if i := strings.IndexAny(v, ".@"); i >= 0 {
+			v = v[:i]
+		}
+		if v == "C" || v == "POSIX" {
+			return ""
+		}
+		return v
+	}
+	return ""
+}
+
+// Banner renders the configured banner, falling back to def. It returns ""
+// when the banner is hidden.
+func Banner(b config.Branding, def, version string) (string, error) {
+	if b.HideBanner {
+		return "", nil
+	}
+
+	locale := Locale()
+	text := def
+	if b.Banner != "" {
+		text = b.Banner
+	}
+	if t, ok := b.Banners[locale]; ok {
+		text = t
+	} else if lang, _, _ := strings.Cut(locale, "_"); lang != "" {
+		if t, ok := b.Banners[lang]; ok {
+			text = t
+		}
+	}
+
+	if text == def {
+		return def, nil
+	}
+
+	tmpl, err := template.New("banner").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid banner template: %w", err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, BannerData{Version: version, Locale: locale}); err != nil {
+		return "", fmt.Errorf("invalid banner template: %w", err)
+	}
+
+	banner := out.String()
+	if !strings.HasSuffix(banner, "\n") {
+		banner += "\n"
+	}
+	return banner, nil
+}
+
+// ANSI converts a hex color ("#00D4AA") to a bold 24-bit ANSI escape sequence
+func ANSI(hex string) (string, bool) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return "", false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("\033[1;38;2;%d;%d;%dm", v>>16, (v>>8)&0xff, v&0xff), true
+}
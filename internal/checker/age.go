@@ -0,0 +1,100 @@
+package checker
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// createdPattern matches the creation date line of common whois formats
+var createdPattern = regexp.MustCompile(`(?i)(Creation Date|Created On|Created|Registered On|Registration Time|Registration Date|Domain Registration Date)[:\s]+([0-9]{4}[-./][0-9]{2}[-./][0-9]{2}|[0-9]{2}[-./][0-9]{2}[-./][0-9]{4}|[0-9]{2}-[A-Za-z]{3}-[0-9]{4})`)
+
+// dateLayouts are the date formats registries use in whois and RDAP responses
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006.01.02",
+	"2006/01/02",
+	"02.01.2006",
+	"02-01-2006",
+	"02/01/2006",
+	"02-Jan-2006",
+}
+
+// extractCreatedDate extracts the creation date from whois output
+func extractCreatedDate(whoisOutput string) string {
+	if matches := createdPattern.FindStringSubmatch(whoisOutput); len(matches) >= 3 {
+		return matches[2]
+	}
+	return ""
+}
+
+// ParseDate parses a whois or RDAP date
+func ParseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	// Fall back to the leading date of longer timestamps
+	if len(s) > 10 {
+		if t, err := time.Parse("2006-01-02", s[:10]); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Age formats how long ago a domain was created ("27y 3m", "5m", "<1m"), or
+// returns "" when the creation date cannot be parsed
+func Age(created string, now time.Time) string {
+	t, ok := ParseDate(created)
+	if !ok || t.After(now) {
+		return ""
+	}
+
+	months := (now.Year()-t.Year())*12 + int(now.Month()-t.Month())
+	if now.Day() < t.Day() {
+		months--
+	}
+
+	switch {
+	case months < 1:
+		return "<1m"
+	case months < 12:
+		return fmt.Sprintf("%dm", months)
+	case months%12 == 0:
+		return fmt.Sprintf("%dy", months/12)
+	default:
+		return fmt.Sprintf("%dy %dm", months/12, months%12)
+	}
+}
+
+// Expiring returns the whole days left until a domain's expiry date when it
+// falls within the given time of now (negative once it has passed); ok is
+// false for later or unparseable dates
+func Expiring(expiry string, within time.Duration, now time.Time) (days int, ok bool) {
+	t, parsed := ParseDate(expiry)
+	if !parsed || t.Sub(now) > within {
+		return 0, false
+	}
+	return int(math.Floor(t.Sub(now).Hours() / 24)), true
+}
+
+// DaysLeft describes the days left until expiry ("expires in 12d",
+// "expires today", "expired 3d ago")
+func DaysLeft(days int) string {
+	switch {
+	case days > 0:
+		return fmt.Sprintf("expires in %dd", days)
+	case days == 0:
+		return "expires today"
+	default:
+		return fmt.Sprintf("expired %dd ago", -days)
+	}
+}
@@ -0,0 +1,107 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Backend checks the availability of a single domain. A failed check is
+// returned as a Result whose Error is set rather than as a separate error,
+// so that the domain and whatever the backend learned before failing travel
+// with it through chains, hooks and the output like any other result.
+type Backend interface {
+	Check(ctx context.Context, domain string) Result
+}
+
+// WhoisBackend checks domains with the built-in whois client over port 43
+type WhoisBackend struct{}
+
+// Check implements Backend
+func (WhoisBackend) Check(ctx context.Context, domain string) Result {
+	return CheckDomain(ctx, domain)
+}
+
+// SystemWhoisBackend checks domains with the system whois command
+type SystemWhoisBackend struct{}
+
+// Check implements Backend
+func (SystemWhoisBackend) Check(ctx context.Context, domain string) Result {
+	return CheckDomainSystem(ctx, domain)
+}
+
+// RDAPBackend checks domains with RDAP queries over HTTPS, without any
+// external command. TLDs the IANA bootstrap lists no RDAP server for are
+// checked over whois instead.
+type RDAPBackend struct{}
+
+// Check implements Backend
+func (RDAPBackend) Check(ctx context.Context, domain string) Result {
+	resp, err := LookupRDAP(ctx, domain)
+	if errors.Is(err, ErrNoRDAPServer) {
+		r := CheckDomain(ctx, domain)
+		r.Backend = "whois"
+		return r
+	}
+	if err != nil {
+		return Result{Domain: domain, Error: err}
+	}
+	if resp.Available() {
+		return unregistered(domain, ConfidenceHigh)
+	}
+	r := Result{
+		Domain:       domain,
+		Confidence:   ConfidenceHigh,
+		ExpiryDate:   resp.Domain.EventDate("expiration"),
+		CreatedDate:  resp.Domain.EventDate("registration"),
+		Reregistered: resp.Domain.EventDate("reregistration"),
+		Registrar:    resp.Domain.Registrar(),
+		UpdatedDate:  resp.Domain.EventDate("last changed"),
+		Nameservers:  resp.Domain.NameserverNames(),
+		Status:       resp.Domain.Status,
+		DNSSEC:       resp.Domain.Signed(),
+	}
+	r.RegistrantOrg, r.RegistrantCountry = resp.Domain.Registrant()
+	r.RegistrantPrivacy = resp.Domain.RegistrantPrivacy()
+	r.AbuseEmail = resp.Domain.AbuseEmail()
+	return r
+}
+
+// ChainBackend tries each backend in turn until one answers without an
+// error, so a TLD without RDAP or a whois server that times out falls back
+// to the next transport. A named backend that keeps failing for a TLD is
+// skipped for a while, unless it is the last one left.
+type ChainBackend []Backend
+
+// Check implements Backend
+func (c ChainBackend) Check(ctx context.Context, domain string) Result {
+	var errs []error
+	for i, b := range c {
+		if n, ok := b.(NamedBackend); ok && i < len(c)-1 && !health.healthy(n.Name, domain) {
+			errs = append(errs, fmt.Errorf("%s skipped after %d failures in a row", n.Name, unhealthyAfter))
+			continue
+		}
+		r := b.Check(ctx, domain)
+		if r.Error == nil {
+			return r
+		}
+		errs = append(errs, r.Error)
+	}
+	return Result{Domain: domain, Error: chainError(errs)}
+}
+
+// chainError holds the error of every backend in a chain
+type chainError []error
+
+func (e chainError) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e chainError) Unwrap() []error {
+	return e
+}
@@ -0,0 +1,187 @@
+package checker
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+)
+
+// legacyCharsets are the non-UTF-8 encodings registries are known to answer
+// in, tried in order when a response is not valid UTF-8
+var legacyCharsets = []struct {
+	name string
+	enc  encoding.Encoding
+}{
+	{"Shift_JIS", japanese.ShiftJIS},
+	{"EUC-JP", japanese.EUCJP},
+	{"EUC-KR", korean.EUCKR},
+	{"GBK", simplifiedchinese.GBK},
+	{"KOI8-R", charmap.KOI8R},
+	{"windows-1251", charmap.Windows1251},
+	{"ISO-8859-1", charmap.ISO8859_1},
+}
+
+// tldCharsets are the legacy charsets a ccTLD registry most likely answers
+// in; they win ties against the generic order
+var tldCharsets = map[string][]string{
+	"jp": {"Shift_JIS", "EUC-JP"},
+	"kr": {"EUC-KR"},
+	"cn": {"GBK"},
+	"ru": {"KOI8-R", "windows-1251"},
+	"su": {"KOI8-R", "windows-1251"},
+	"ua": {"windows-1251", "KOI8-R"},
+	"by": {"windows-1251", "KOI8-R"},
+	"bg": {"windows-1251"},
+}
+
+// Decode transcodes a raw whois response for domain to UTF-8 and returns the
+// charset it was detected as
+func Decode(domain string, raw []byte) (text, charset string) {
+	// ISO-2022-JP is 7-bit and passes as UTF-8, so look for its escapes first
+	if bytes.Contains(raw, []byte("\x1b$B")) || bytes.Contains(raw, []byte("\x1b$@")) {
+		if out, err := japanese.ISO2022JP.NewDecoder().Bytes(raw); err == nil {
+			return string(out), "ISO-2022-JP"
+		}
+	}
+	if utf8.Valid(raw) {
+		return string(raw), "UTF-8"
+	}
+
+	preferred := tldCharsets[strings.ToLower(domain[strings.LastIndex(domain, ".")+1:])]
+	candidates := make([]int, 0, len(legacyCharsets))
+	for _, name := range preferred {
+		for i, c := range legacyCharsets {
+			if c.name == name {
+				candidates = append(candidates, i)
+			}
+		}
+	}
+	for i, c := range legacyCharsets {
+		if !slices.Contains(preferred, c.name) {
+			candidates = append(candidates, i)
+		}
+	}
+
+	best, bestName, bestScore := string(raw), "", -1.0
+	for _, i := range candidates {
+		c := legacyCharsets[i]
+		out, err := c.enc.NewDecoder().Bytes(raw)
+		if err != nil {
+			continue
+		}
+		if s := plausibility(string(out)); s > bestScore {
+			best, bestName, bestScore = string(out), c.name, s
+		}
+	}
+	return best, bestName
+}
+
+// plausibility rates decoded text from 0 to 1 by how natural its non-ASCII
+// runes look. Wrong decodings produce symbols and replacement characters,
+// capitals in mid-word, non-Latin letters glued to ASCII words, half-width
+// kana and a mix of scripts.
+func plausibility(text string) float64 {
+	runes := []rune(text)
+	sums := make(map[string]float64)
+	total := 0
+	for i, r := range runes {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		total++
+		script := scriptOf(r)
+		if script == "" || !unicode.IsLetter(r) {
+			continue
+		}
+
+		var prev, next rune
+		if i > 0 {
+			prev = runes[i-1]
+		}
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		s := 1.0
+		switch {
+		case script != "latin" && (isASCIILetter(prev) || isASCIILetter(next)):
+			s = 0
+		case r >= 0xFF61 && r <= 0xFF9F:
+			s = 0.5
+		case unicode.IsUpper(r) && unicode.IsLetter(prev):
+			s = 0.5
+		}
+		sums[script] += s
+	}
+	if total == 0 {
+		return 1
+	}
+	best := 0.0
+	for _, v := range sums {
+		best = max(best, v)
+	}
+	return best / float64(total)
+}
+
+func isASCIILetter(r rune) bool {
+	return r < utf8.RuneSelf && unicode.IsLetter(r)
+}
+
+// scriptOf groups letters by writing system; kana and kanji are grouped
+// because Japanese mixes them
+func scriptOf(r rune) string {
+	switch {
+	case unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han):
+		return "cjk"
+	case unicode.Is(unicode.Hangul, r):
+		return "hangul"
+	case unicode.Is(unicode.Cyrillic, r):
+		return "cyrillic"
+	case unicode.Is(unicode.Latin, r):
+		return "latin"
+	}
+	return ""
+}
+
+// Language guesses the language of a decoded response from the scripts its
+// letters are written in, or returns "" when it has none outside ASCII
+func Language(text string) string {
+	var kana, hangul, han, cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case r < utf8.RuneSelf:
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	switch {
+	case kana > 0:
+		return "Japanese"
+	case hangul > 0:
+		return "Korean"
+	case han > 0:
+		return "Chinese"
+	case cyrillic > 0:
+		return "Cyrillic (Russian, Ukrainian, ...)"
+	case latin > 0:
+		return "Latin (European)"
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// DNSBackend checks domains by their NS records alone: a delegated domain is
+// taken, and one the resolver reports as nonexistent is assumed available.
+// It is fast and never rate limited, but registered domains without
+// nameservers pass as available, so it suits the end of a fallback chain.
+type DNSBackend struct {
+	// Resolver is used for the NS lookups; nil means net.DefaultResolver
+	Resolver *net.Resolver
+}
+
+// Check implements Backend
+func (b DNSBackend) Check(ctx context.Context, domain string) Result {
+	ns, err := lookupNS(ctx, b.Resolver, domain)
+	if err == nil && len(ns) > 0 {
+		return Result{Domain: domain, Nameservers: ns, Confidence: ConfidenceHigh}
+	}
+	var dnsErr *net.DNSError
+	if err == nil || errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return unregistered(domain, ConfidenceLow)
+	}
+	return Result{Domain: domain, Error: fmt.Errorf("NS lookup failed: %w", err)}
+}
+
+// DNSFirstBackend answers from DNS when a domain is delegated and only asks
+// the wrapped backend about domains without nameservers. A registered domain
+// is almost always delegated, so on large scans most taken domains skip the
+// slower whois or RDAP query; they are reported without an expiry date. SOA
+// records are not looked up, since the NS lookup already tells delegated
+// domains apart.
+type DNSFirstBackend struct {
+	Backend
+	// Resolver is used for the NS lookups; nil means net.DefaultResolver
+	Resolver *net.Resolver
+}
+
+// Check implements Backend
+func (b DNSFirstBackend) Check(ctx context.Context, domain string) Result {
+	// NXDOMAIN, an empty answer and resolver failures all leave the final
+	// call to the backend
+	if ns, err := lookupNS(ctx, b.Resolver, domain); err == nil && len(ns) > 0 {
+		return Result{Domain: domain, Nameservers: ns, Confidence: ConfidenceHigh, Backend: "dns"}
+	}
+	return b.Backend.Check(ctx, domain)
+}
+
+// lookupNS returns the lower-cased nameservers of domain
+func lookupNS(ctx context.Context, resolver *net.Resolver, domain string) ([]string, error) {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	records, err := resolver.LookupNS(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+	ns := make([]string, len(records))
+	for i, r := range records {
+		ns[i] = strings.ToLower(strings.TrimSuffix(r.Host, "."))
+	}
+	return ns, nil
+}
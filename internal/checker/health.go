@@ -0,0 +1,114 @@
+package checker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/metrics"
+)
+
+const (
+	// unhealthyAfter consecutive failures for a TLD take a backend out of
+	// its chains for that TLD
+	unhealthyAfter = 5
+	// healthCooldown is how long an unhealthy backend is skipped before it
+	// is tried again
+	healthCooldown = time.Minute
+)
+
+// NamedBackend labels the results of a backend with its name, as given to
+// --backend, and tracks its health per TLD for ChainBackend
+type NamedBackend struct {
+	Name string
+	Backend
+}
+
+// Check implements Backend
+func (b NamedBackend) Check(ctx context.Context, domain string) Result {
+	r := b.Backend.Check(ctx, domain)
+	if r.Backend == "" {
+		r.Backend = b.Name
+	}
+	health.record(b.Name, domain, r.Error)
+	outcome := "ok"
+	if r.Error != nil {
+		outcome = "error"
+	}
+	metrics.BackendChecks.Inc(b.Name, outcome)
+	return r
+}
+
+// Unwrap returns the backend inside a NamedBackend, or b itself
+func Unwrap(b Backend) Backend {
+	if n, ok := b.(NamedBackend); ok {
+		return n.Backend
+	}
+	return b
+}
+
+// TLDBackend sends each domain to the backend configured for its TLD or
+// suffix, matching the longest, and the rest to Default
+type TLDBackend struct {
+	Default Backend
+	// ByTLD is keyed by TLD or suffix without the leading dot ("io", "co.uk")
+	ByTLD map[string]Backend
+}
+
+// Check implements Backend
+func (t TLDBackend) Check(ctx context.Context, domain string) Result {
+	labels := strings.Split(domain, ".")
+	for i := 1; i < len(labels); i++ {
+		if b, ok := t.ByTLD[strings.Join(labels[i:], ".")]; ok {
+			return b.Check(ctx, domain)
+		}
+	}
+	return t.Default.Check(ctx, domain)
+}
+
+// healthTracker counts the consecutive failures of each backend per TLD
+type healthTracker struct {
+	mu      sync.Mutex
+	entries map[string]*healthEntry
+}
+
+type healthEntry struct {
+	consecutive int
+	lastFailure time.Time
+}
+
+var health = &healthTracker{entries: make(map[string]*healthEntry)}
+
+// healthKey identifies a backend's record for the suffix of domain
+func healthKey(name, domain string) string {
+	_, suffix, _ := strings.Cut(domain, ".")
+	return name + " " + suffix
+}
+
+func (h *healthTracker) record(name, domain string, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := healthKey(name, domain)
+	e, ok := h.entries[key]
+	if !ok {
+		e = &healthEntry{}
+		h.entries[key] = e
+	}
+	if err == nil {
+		e.consecutive = 0
+		return
+	}
+	e.consecutive++
+	e.lastFailure = time.Now()
+}
+
+// healthy reports whether a backend may be tried for domain: it has not
+// failed unhealthyAfter times in a row, or the cooldown since its last
+// failure has passed
+func (h *healthTracker) healthy(name, domain string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e, ok := h.entries[healthKey(name, domain)]
+	return !ok || e.consecutive < unhealthyAfter || time.Since(e.lastFailure) > healthCooldown
+}
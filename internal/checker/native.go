@@ -0,0 +1,243 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/metrics"
+)
+
+// IANAWhoisServer answers which whois server is authoritative for each TLD
+const IANAWhoisServer = "whois.iana.org"
+
+const (
+	whoisTimeout     = 15 * time.Second
+	maxWhoisResponse = 1 << 20
+)
+
+// ErrNoWhoisServer is returned when IANA lists no whois server for a TLD
+var ErrNoWhoisServer = errors.New("no whois server for TLD")
+
+var (
+	whoisServersMu sync.Mutex
+	whoisServers   = maps.Clone(registrySuffixServers)
+	// whoisOverride and whoisOverrides replace the registry's server for
+	// every domain, or for a TLD or suffix
+	whoisOverride  string
+	whoisOverrides map[string]string
+)
+
+var (
+	// ianaReferralPattern matches the whois server line of a whois.iana.org answer
+	ianaReferralPattern = regexp.MustCompile(`(?im)^\s*(?:whois|refer):\s*(\S+)\s*$`)
+
+	// registrarReferralPattern matches a registry's pointer to the registrar's whois server
+	registrarReferralPattern = regexp.MustCompile(`(?im)^\s*(?:Registrar WHOIS Server|Whois Server|ReferralServer):\s*(\S+)\s*$`)
+)
+
+// WhoisResponse is a whois answer from the registry, followed by the
+// registrar's answer when the registry refers to one
+type WhoisResponse struct {
+	// Servers are the servers queried, registry first
+	Servers []string
+	Raw     []byte
+}
+
+// LookupWhois queries the registry whois server for a domain over port 43 and
+// follows one referral to the registrar's whois server. A server set with
+// SetWhoisOverrides is queried alone.
+func LookupWhois(ctx context.Context, domain string) (*WhoisResponse, error) {
+	server, err := WhoisServer(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := QueryWhois(ctx, server, whoisQuery(server, domain))
+	if err != nil {
+		return nil, err
+	}
+	resp := &WhoisResponse{Servers: []string{server}, Raw: raw}
+	if _, ok := WhoisOverride(domain); ok {
+		return resp, nil
+	}
+
+	// The registrar usually holds the fuller record; keep the registry answer
+	// alone when the referral fails
+	if ref := referral(registrarReferralPattern, raw); ref != "" && !strings.EqualFold(ref, server) {
+		if more, err := QueryWhois(ctx, ref, domain); err == nil && len(more) > 0 {
+			resp.Servers = append(resp.Servers, ref)
+			resp.Raw = append(append(raw, '\n'), more...)
+		}
+	}
+	return resp, nil
+}
+
+// WhoisServer returns the whois server of a domain's TLD, from the servers
+// set with SetWhoisServers or else as listed by whois.iana.org, cached per
+// process. A second-level suffix with a server of its own, such as uk.com,
+// takes precedence over its TLD, and an override over both.
+func WhoisServer(ctx context.Context, domain string) (string, error) {
+	if server, ok := WhoisOverride(domain); ok {
+		return server, nil
+	}
+	if suffix, server, ok := suffixServer(domain); ok {
+		if server == "" {
+			return "", fmt.Errorf("%w .%s", ErrNoWhoisServer, suffix)
+		}
+		return server, nil
+	}
+
+	tld := strings.ToLower(domain[strings.LastIndex(domain, ".")+1:])
+
+	whoisServersMu.Lock()
+	server, ok := whoisServers[tld]
+	whoisServersMu.Unlock()
+	if !ok {
+		var err error
+		if server, err = LookupWhoisServer(ctx, tld); err != nil {
+			return "", err
+		}
+		whoisServersMu.Lock()
+		whoisServers[tld] = server
+		whoisServersMu.Unlock()
+	}
+
+	if server == "" {
+		return "", fmt.Errorf("%w .%s", ErrNoWhoisServer, tld)
+	}
+	return server, nil
+}
+
+// LookupWhoisServer asks whois.iana.org for a TLD's whois server. It returns
+// "" when IANA lists none.
+func LookupWhoisServer(ctx context.Context, tld string) (string, error) {
+	raw, err := QueryWhois(ctx, IANAWhoisServer, strings.TrimPrefix(tld, "."))
+	if err != nil {
+		return "", err
+	}
+	return referral(ianaReferralPattern, raw), nil
+}
+
+// SetWhoisServers records known whois servers by TLD (without the dot), so
+// WhoisServer does not have to ask IANA. An empty server marks a TLD that
+// has none. A multi-label key such as co.uk routes that suffix to its own
+// server.
+func SetWhoisServers(servers map[string]string) {
+	whoisServersMu.Lock()
+	defer whoisServersMu.Unlock()
+	for tld, server := range servers {
+		whoisServers[strings.ToLower(tld)] = server
+	}
+}
+
+// SetWhoisOverrides directs whois queries to server for every domain, or for
+// the TLDs and suffixes (without the dot) of byTLD, instead of the registry's
+// server. A server is a host, or host:port for a mirror off port 43.
+// Registrar referrals are not followed from an override.
+func SetWhoisOverrides(server string, byTLD map[string]string) {
+	whoisServersMu.Lock()
+	defer whoisServersMu.Unlock()
+	whoisOverride = server
+	whoisOverrides = make(map[string]string, len(byTLD))
+	for tld, s := range byTLD {
+		whoisOverrides[strings.ToLower(strings.TrimPrefix(tld, "."))] = s
+	}
+}
+
+// WhoisOverride returns the server set with SetWhoisOverrides for domain:
+// the longest matching suffix, or else the one for every domain
+func WhoisOverride(domain string) (string, bool) {
+	whoisServersMu.Lock()
+	defer whoisServersMu.Unlock()
+	labels := strings.Split(strings.ToLower(domain), ".")
+	for i := 1; i < len(labels); i++ {
+		if server, ok := whoisOverrides[strings.Join(labels[i:], ".")]; ok {
+			return server, true
+		}
+	}
+	return whoisOverride, whoisOverride != ""
+}
+
+// QueryWhois sends a query to a whois server on port 43 and returns the
+// response, waiting first if the server's rate limit is reached
+func QueryWhois(ctx context.Context, server, query string) ([]byte, error) {
+	if err := WaitServer(ctx, server); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	raw, err := queryWhois(ctx, server, query)
+	metrics.ObserveQuery(metrics.Whois, server, time.Since(start), err != nil, false)
+	return raw, err
+}
+
+// queryWhois sends one query to server over port 43
+func queryWhois(ctx context.Context, server, query string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, whoisTimeout)
+	defer cancel()
+
+	host, port, err := net.SplitHostPort(server)
+	if err != nil {
+		host, port = server, "43"
+	}
+	conn, err := dialTCP(ctx, host, port)
+	if err != nil {
+		return nil, fmt.Errorf("whois query to %s failed: %w", server, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := io.WriteString(conn, query+"\r\n"); err != nil {
+		return nil, fmt.Errorf("whois query to %s failed: %w", server, err)
+	}
+	raw, err := io.ReadAll(io.LimitReader(conn, maxWhoisResponse))
+	if err != nil && len(raw) == 0 {
+		return nil, fmt.Errorf("whois query to %s failed: %w", server, err)
+	}
+	return raw, nil
+}
+
+// whoisQuery formats the query for servers that need more than the bare domain
+func whoisQuery(server, domain string) string {
+	switch strings.ToLower(server) {
+	case "whois.verisign-grs.com":
+		// Without the keyword Verisign also lists matching nameserver hosts
+		return "domain " + domain
+	case "whois.denic.de":
+		return "-T dn,ace " + domain
+	case "whois.jprs.jp":
+		// English output instead of ISO-2022-JP
+		return domain + "/e"
+	}
+	return domain
+}
+
+// referral extracts a whois server host from the first line matching pattern
+func referral(pattern *regexp.Regexp, raw []byte) string {
+	m := pattern.FindSubmatch(raw)
+	if m == nil {
+		return ""
+	}
+	host := strings.TrimSpace(string(m[1]))
+	host = strings.TrimPrefix(host, "whois://")
+	host = strings.TrimPrefix(host, "rwhois://")
+	// Web referrals cannot be queried on port 43
+	if strings.Contains(host, "/") {
+		return ""
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.TrimSuffix(host, ".")
+}
@@ -0,0 +1,101 @@
+package checker
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// Orders in which the domains of a run are queried
+const (
+	// OrderShuffle spreads each whois server's domains evenly over the run
+	// in random order, so consecutive queries go to different registries
+	OrderShuffle = "shuffle"
+	// OrderByTLD queries the domains of one TLD after another
+	OrderByTLD = "by-tld"
+	// OrderAsGiven keeps the order the domains were given in
+	OrderAsGiven = "as-given"
+)
+
+var queryOrder atomic.Value
+
+func init() {
+	queryOrder.Store(OrderShuffle)
+}
+
+// SetQueryOrder sets the order CheckDomains queries domains in: shuffle (the
+// default), by-tld or as-given
+func SetQueryOrder(order string) error {
+	switch order {
+	case OrderShuffle, OrderByTLD, OrderAsGiven:
+	default:
+		return fmt.Errorf("invalid order %q (use shuffle, by-tld or as-given)", order)
+	}
+	queryOrder.Store(order)
+	return nil
+}
+
+// OrderDomains returns domains in the given order, leaving domains itself
+// unchanged
+func OrderDomains(domains []string, order string) []string {
+	out := slices.Clone(domains)
+	switch order {
+	case OrderByTLD:
+		sort.SliceStable(out, func(i, j int) bool {
+			return tldOf(out[i]) < tldOf(out[j])
+		})
+	case OrderShuffle:
+		// Each domain is placed at a random point of its own slot, where a
+		// server with n domains has n equal slots across the run. Positions
+		// are kept by index, so a repeated domain takes a slot per copy.
+		groups := make(map[string][]int)
+		for i, d := range domains {
+			key := serverKey(d)
+			groups[key] = append(groups[key], i)
+		}
+		pos := make([]float64, len(domains))
+		for _, g := range groups {
+			rand.Shuffle(len(g), func(i, j int) { g[i], g[j] = g[j], g[i] })
+			for i, idx := range g {
+				pos[idx] = (float64(i) + rand.Float64()) / float64(len(g))
+			}
+		}
+		idx := make([]int, len(domains))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(i, j int) bool {
+			return pos[idx[i]] < pos[idx[j]]
+		})
+		for i, j := range idx {
+			out[i] = domains[j]
+		}
+	}
+	return out
+}
+
+// serverKey identifies the whois server a domain is queried at, as far as it
+// is known without a lookup, or else its TLD
+func serverKey(domain string) string {
+	if server, ok := WhoisOverride(domain); ok {
+		return server
+	}
+	if _, server, ok := suffixServer(domain); ok {
+		return server
+	}
+	tld := tldOf(domain)
+	whoisServersMu.Lock()
+	defer whoisServersMu.Unlock()
+	if server := whoisServers[tld]; server != "" {
+		return server
+	}
+	return tld
+}
+
+// tldOf returns the last label of domain
+func tldOf(domain string) string {
+	return strings.ToLower(domain[strings.LastIndex(domain, ".")+1:])
+}
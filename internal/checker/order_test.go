@@ -0,0 +1,49 @@
+package checker
+
+import (
+	"slices"
+	"testing"
+)
+
+var orderInput = []string{"b.org", "a.com", "c.net", "d.com", "e.org", "f.com"}
+
+func TestOrderDomainsAsGiven(t *testing.T) {
+	for _, order := range []string{OrderAsGiven, ""} {
+		if got := OrderDomains(orderInput, order); !slices.Equal(got, orderInput) {
+			t.Errorf("OrderDomains(%q) = %v, want %v", order, got, orderInput)
+		}
+	}
+}
+
+func TestOrderDomainsByTLD(t *testing.T) {
+	want := []string{"a.com", "d.com", "f.com", "c.net", "b.org", "e.org"}
+	if got := OrderDomains(orderInput, OrderByTLD); !slices.Equal(got, want) {
+		t.Errorf("OrderDomains(by-tld) = %v, want %v", got, want)
+	}
+}
+
+func TestOrderDomainsShuffle(t *testing.T) {
+	input := slices.Clone(orderInput)
+	got := OrderDomains(input, OrderShuffle)
+	if !slices.Equal(input, orderInput) {
+		t.Errorf("OrderDomains(shuffle) changed its input to %v", input)
+	}
+	slices.Sort(got)
+	want := slices.Sorted(slices.Values(orderInput))
+	if !slices.Equal(got, want) {
+		t.Errorf("OrderDomains(shuffle) returned %v, not a permutation of %v", got, orderInput)
+	}
+}
+
+func TestOrderDomainsShuffleDuplicates(t *testing.T) {
+	// Each copy of a repeated domain has a slot of its own, so every
+	// domain appears once in each half of the run
+	input := []string{"a.com", "a.com", "b.org", "b.org", "c.net", "c.net"}
+	for range 20 {
+		got := OrderDomains(input, OrderShuffle)
+		first := slices.Sorted(slices.Values(got[:3]))
+		if want := []string{"a.com", "b.org", "c.net"}; !slices.Equal(first, want) {
+			t.Fatalf("OrderDomains(shuffle) = %v, want one copy of each domain in each half", got)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package checker
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+//go:embed patterns.json
+var embeddedPatterns []byte
+
+// defaultPatterns is the pattern table key applied to every TLD
+const defaultPatterns = "default"
+
+// PatternSet holds the regular expressions that mark a whois response as
+// available or registered, or the name as premium or reserved. Blocked
+// lists names the registry withholds whatever whois says (its block list).
+type PatternSet struct {
+	Available  []string `json:"available"`
+	Registered []string `json:"registered"`
+	Premium    []string `json:"premium,omitempty"`
+	Reserved   []string `json:"reserved,omitempty"`
+	Blocked    []string `json:"blocked,omitempty"`
+}
+
+type compiledPatterns struct {
+	available  []*regexp.Regexp
+	registered []*regexp.Regexp
+	premium    []*regexp.Regexp
+	reserved   []*regexp.Regexp
+	blocked    map[string]bool
+}
+
+var (
+	patternsMu sync.RWMutex
+	// patterns maps TLDs (without the dot) and "default" to their indicators
+	patterns = mustParsePatterns(embeddedPatterns)
+)
+
+// LoadPatterns reads a JSON pattern table keyed by TLD (or "default") and
+// lays it over the embedded table. A TLD entry replaces the embedded entry
+// for that TLD; the default patterns still apply when it matches nothing.
+func LoadPatterns(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read pattern file: %w", err)
+	}
+	table, err := parsePatterns(data)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", path, err)
+	}
+
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	for tld, p := range table {
+		patterns[tld] = p
+	}
+	return nil
+}
+
+func mustParsePatterns(data []byte) map[string]compiledPatterns {
+	table, err := parsePatterns(data)
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+func parsePatterns(data []byte) (map[string]compiledPatterns, error) {
+	var raw map[string]PatternSet
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	table := make(map[string]compiledPatterns, len(raw))
+	for key, set := range raw {
+		key = strings.TrimPrefix(strings.ToLower(key), ".")
+		var c compiledPatterns
+		var err error
+		if c.available, err = compilePatterns(set.Available); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if c.registered, err = compilePatterns(set.Registered); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if c.premium, err = compilePatterns(set.Premium); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		if c.reserved, err = compilePatterns(set.Reserved); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		c.blocked = make(map[string]bool, len(set.Blocked))
+		for _, name := range set.Blocked {
+			c.blocked[strings.ToLower(name)] = true
+		}
+		table[key] = c
+	}
+	return table, nil
+}
+
+// compilePatterns compiles case-insensitive, multi-line patterns so ^ and $
+// anchor to response lines
+func compilePatterns(exprs []string) ([]*regexp.Regexp, error) {
+	var out []*regexp.Regexp
+	for _, expr := range exprs {
+		re, err := regexp.Compile("(?im)" + expr)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, re)
+	}
+	return out, nil
+}
+
+// patternsFor returns the pattern sets to try for a domain, TLD-specific first
+func patternsFor(domain string) []compiledPatterns {
+	tld := strings.ToLower(domain[strings.LastIndex(domain, ".")+1:])
+
+	patternsMu.RLock()
+	defer patternsMu.RUnlock()
+	var sets []compiledPatterns
+	if p, ok := patterns[tld]; ok && tld != defaultPatterns {
+		sets = append(sets, p)
+	}
+	return append(sets, patterns[defaultPatterns])
+}
+
+// blocked reports whether the name of a domain (its first label) is on the
+// block list of its TLD or the default one
+func blocked(domain string) bool {
+	name, _, _ := strings.Cut(strings.ToLower(domain), ".")
+	for _, p := range patternsFor(domain) {
+		if p.blocked[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// firstMatch returns the first text matched by any of the patterns
+func firstMatch(res []*regexp.Regexp, text string) string {
+	for _, re := range res {
+		if match := re.FindString(text); match != "" {
+			return match
+		}
+	}
+	return ""
+}
@@ -0,0 +1,241 @@
+package checker
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	proxyMu sync.Mutex
+	// proxyURL is the --proxy every whois and RDAP connection goes through;
+	// nil means the HTTPS_PROXY environment variable
+	proxyURL *url.URL
+)
+
+// SetProxy sends all whois connections and RDAP requests through a proxy,
+// given as socks5://[user:pass@]host:port (socks5h is the same, names are
+// always resolved by the proxy, so Tor works) or http(s)://host:port for an
+// HTTP CONNECT proxy. An empty addr goes back to HTTPS_PROXY.
+func SetProxy(addr string) error {
+	var u *url.URL
+	if addr != "" {
+		var err error
+		u, err = url.Parse(addr)
+		if err != nil {
+			return fmt.Errorf("invalid proxy %q: %w", addr, err)
+		}
+		switch u.Scheme {
+		case "socks5", "socks5h", "http", "https":
+		default:
+			return fmt.Errorf("invalid proxy %q (use socks5://host:port or http://host:port)", addr)
+		}
+		if u.Hostname() == "" {
+			return fmt.Errorf("invalid proxy %q: missing host", addr)
+		}
+	}
+
+	proxyMu.Lock()
+	proxyURL = u
+	proxyMu.Unlock()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if u != nil {
+		transport.Proxy = http.ProxyURL(u)
+	}
+	rdapClient.Transport = transport
+	return nil
+}
+
+// proxyFor returns the proxy for a connection to host:port, if any
+func proxyFor(host, port string) (*url.URL, error) {
+	proxyMu.Lock()
+	u := proxyURL
+	proxyMu.Unlock()
+	if u != nil {
+		return u, nil
+	}
+	// HTTPS_PROXY and NO_PROXY apply to whois as they do to RDAP
+	return http.ProxyFromEnvironment(&http.Request{URL: &url.URL{Scheme: "https", Host: net.JoinHostPort(host, port)}})
+}
+
+// dialTCP connects to host:port directly or through the proxy
+func dialTCP(ctx context.Context, host, port string) (net.Conn, error) {
+	proxy, err := proxyFor(host, port)
+	if err != nil {
+		return nil, err
+	}
+	var dialer net.Dialer
+	if proxy == nil {
+		return dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	}
+
+	proxyPort := proxy.Port()
+	if proxyPort == "" {
+		proxyPort = map[string]string{"socks5": "1080", "socks5h": "1080", "http": "80", "https": "443"}[proxy.Scheme]
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(proxy.Hostname(), proxyPort))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	switch proxy.Scheme {
+	case "https":
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxy.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to connect to proxy: %w", err)
+		}
+		conn = tlsConn
+		fallthrough
+	case "http":
+		err = httpConnect(conn, proxy, host, port)
+	default:
+		err = socks5Connect(conn, proxy, host, port)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// httpConnect opens a tunnel to host:port with an HTTP CONNECT request
+func httpConnect(conn net.Conn, proxy *url.URL, host, port string) error {
+	target := net.JoinHostPort(host, port)
+	req := "CONNECT " + target + " HTTP/1.1\r\nHost: " + target + "\r\n"
+	if proxy.User != nil {
+		pass, _ := proxy.User.Password()
+		req += "Proxy-Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte(proxy.User.Username()+":"+pass)) + "\r\n"
+	}
+	if _, err := io.WriteString(conn, req+"\r\n"); err != nil {
+		return fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	// Whois servers only speak after the query, so nothing past the
+	// response header can be buffered away
+	resp, err := http.ReadResponse(bufio.NewReader(conn), &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		return fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("proxy refused connection to %s: %s", target, resp.Status)
+	}
+	return nil
+}
+
+// socks5Connect opens a tunnel to host:port with a SOCKS5 CONNECT (RFC 1928),
+// authenticating with the user and password of the proxy URL (RFC 1929)
+func socks5Connect(conn net.Conn, proxy *url.URL, host, port string) error {
+	portNum, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || len(host) > 255 {
+		return fmt.Errorf("invalid proxy target %s", net.JoinHostPort(host, port))
+	}
+
+	method := byte(0x00)
+	if proxy.User != nil {
+		method = 0x02
+	}
+	if _, err := conn.Write([]byte{0x05, 1, method}); err != nil {
+		return fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	if reply[0] != 0x05 || reply[1] != method {
+		return errors.New("SOCKS5 proxy refused the authentication method")
+	}
+
+	if method == 0x02 {
+		user := proxy.User.Username()
+		pass, _ := proxy.User.Password()
+		if len(user) > 255 || len(pass) > 255 {
+			return errors.New("SOCKS5 proxy user or password is too long")
+		}
+		auth := append([]byte{0x01, byte(len(user))}, user...)
+		auth = append(append(auth, byte(len(pass))), pass...)
+		if _, err := conn.Write(auth); err != nil {
+			return fmt.Errorf("failed to connect to proxy: %w", err)
+		}
+		if _, err := io.ReadFull(conn, reply); err != nil {
+			return fmt.Errorf("failed to connect to proxy: %w", err)
+		}
+		if reply[1] != 0x00 {
+			return errors.New("SOCKS5 proxy rejected the user and password")
+		}
+	}
+
+	// The proxy resolves the name, so lookups do not leak past it
+	req := append([]byte{0x05, 0x01, 0x00, 0x03, byte(len(host))}, host...)
+	req = binary.BigEndian.AppendUint16(req, uint16(portNum))
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	head := make([]byte, 4)
+	if _, err := io.ReadFull(conn, head); err != nil {
+		return fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	if head[1] != 0x00 {
+		return fmt.Errorf("SOCKS5 proxy could not connect to %s: %s", net.JoinHostPort(host, port), socks5Reply(head[1]))
+	}
+
+	// Skip the bound address and port
+	var skip int
+	switch head[3] {
+	case 0x01:
+		skip = net.IPv4len + 2
+	case 0x04:
+		skip = net.IPv6len + 2
+	case 0x03:
+		n := make([]byte, 1)
+		if _, err := io.ReadFull(conn, n); err != nil {
+			return fmt.Errorf("failed to connect to proxy: %w", err)
+		}
+		skip = int(n[0]) + 2
+	default:
+		return errors.New("SOCKS5 proxy sent an invalid reply")
+	}
+	if _, err := io.ReadFull(conn, make([]byte, skip)); err != nil {
+		return fmt.Errorf("failed to connect to proxy: %w", err)
+	}
+	return nil
+}
+
+// socks5Reply describes a SOCKS5 reply code
+func socks5Reply(code byte) string {
+	switch code {
+	case 0x01:
+		return "general failure"
+	case 0x02:
+		return "not allowed by ruleset"
+	case 0x03:
+		return "network unreachable"
+	case 0x04:
+		return "host unreachable"
+	case 0x05:
+		return "connection refused"
+	case 0x06:
+		return "TTL expired"
+	case 0x07:
+		return "command not supported"
+	case 0x08:
+		return "address type not supported"
+	}
+	return fmt.Sprintf("error %d", code)
+}
@@ -0,0 +1,112 @@
+package checker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/metrics"
+)
+
+// DefaultRateLimit is the default number of queries per second sent to any
+// one whois or RDAP server
+const DefaultRateLimit = 5
+
+var (
+	limitersMu  sync.Mutex
+	limiters    = make(map[string]*bucket)
+	defaultRate = float64(DefaultRateLimit)
+	serverRates = make(map[string]float64)
+)
+
+// SetRateLimit sets the queries per second allowed per server; 0 or less
+// disables rate limiting for servers without an override
+func SetRateLimit(perSecond float64) {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	defaultRate = perSecond
+	limiters = make(map[string]*bucket)
+}
+
+// SetServerRateLimits overrides the queries per second for individual
+// servers, keyed by host name (whois.verisign-grs.com)
+func SetServerRateLimits(rates map[string]float64) {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	for server, rate := range rates {
+		serverRates[strings.ToLower(server)] = rate
+	}
+	limiters = make(map[string]*bucket)
+}
+
+// WaitServer blocks until the server's rate limit allows another query.
+// Registrar API backends use it for their API hosts too.
+func WaitServer(ctx context.Context, server string) error {
+	server = strings.ToLower(server)
+
+	limitersMu.Lock()
+	b, ok := limiters[server]
+	if !ok {
+		rate, ok := serverRates[server]
+		if !ok {
+			rate = defaultRate
+		}
+		b = newBucket(rate)
+		limiters[server] = b
+	}
+	limitersMu.Unlock()
+
+	delayed, err := b.wait(ctx)
+	if delayed {
+		metrics.RateLimitWaits.Inc(server)
+	}
+	return err
+}
+
+// bucket is a token bucket refilled at rate tokens per second, holding up to
+// one second's worth of tokens
+type bucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newBucket(rate float64) *bucket {
+	burst := max(1, rate)
+	return &bucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes a token, sleeping until one is available; delayed reports
+// whether it had to sleep
+func (b *bucket) wait(ctx context.Context) (delayed bool, err error) {
+	if b.rate <= 0 {
+		return false, nil
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	// Reserve the token now; a negative balance queues later callers behind us
+	b.tokens--
+	delay := time.Duration(0)
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if delay == 0 {
+		return false, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+}
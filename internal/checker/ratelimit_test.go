@@ -0,0 +1,78 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBucketBurst(t *testing.T) {
+	b := newBucket(20)
+	for i := range 20 {
+		if delayed, err := b.wait(context.Background()); delayed || err != nil {
+			t.Fatalf("wait %d of the burst = %v, %v, want no delay", i+1, delayed, err)
+		}
+	}
+	start := time.Now()
+	delayed, err := b.wait(context.Background())
+	if !delayed || err != nil {
+		t.Fatalf("wait past the burst = %v, %v, want a delay", delayed, err)
+	}
+	// One token takes 50ms to refill at 20 per second
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond || elapsed > time.Second {
+		t.Errorf("wait past the burst took %s, want about 50ms", elapsed)
+	}
+}
+
+func TestBucketFractionalRate(t *testing.T) {
+	// Rates below one still allow a single query at once
+	b := newBucket(0.5)
+	if delayed, err := b.wait(context.Background()); delayed || err != nil {
+		t.Errorf("first wait = %v, %v, want no delay", delayed, err)
+	}
+}
+
+func TestBucketDisabled(t *testing.T) {
+	b := newBucket(0)
+	for range 100 {
+		if delayed, err := b.wait(context.Background()); delayed || err != nil {
+			t.Fatalf("wait = %v, %v, want no delay without a rate", delayed, err)
+		}
+	}
+}
+
+func TestBucketCancelled(t *testing.T) {
+	b := newBucket(1)
+	b.wait(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	delayed, err := b.wait(ctx)
+	if !delayed || !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled wait = %v, %v, want a delay ended by context.Canceled", delayed, err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("cancelled wait took %s, want it to return at once", elapsed)
+	}
+}
+
+func TestWaitServerPerServer(t *testing.T) {
+	SetRateLimit(1)
+	defer SetRateLimit(DefaultRateLimit)
+
+	ctx := context.Background()
+	if err := WaitServer(ctx, "whois.example.com"); err != nil {
+		t.Fatalf("first query to whois.example.com: %v", err)
+	}
+	// The server's bucket is empty, but another server's is not
+	if err := WaitServer(ctx, "whois.example.net"); err != nil {
+		t.Errorf("first query to whois.example.net: %v", err)
+	}
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := WaitServer(short, "WHOIS.example.com"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second query to whois.example.com = %v, want it to wait past the deadline", err)
+	}
+}
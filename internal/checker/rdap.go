@@ -0,0 +1,255 @@
+package checker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/metrics"
+)
+
+const RDAPBootstrapURL = "https://data.iana.org/rdap/dns.json"
+
+// ErrNoRDAPServer is returned when the IANA bootstrap lists no RDAP server for a TLD
+var ErrNoRDAPServer = errors.New("no RDAP server for TLD")
+
+var rdapClient = &http.Client{Timeout: 15 * time.Second}
+
+var (
+	bootstrapMu      sync.Mutex
+	bootstrapServers map[string]string
+)
+
+// RDAPResponse holds the outcome of an RDAP domain query
+type RDAPResponse struct {
+	Server     string
+	StatusCode int
+	Raw        []byte
+	Domain     *RDAPDomain // nil when the registry has no record
+}
+
+// RDAPDomain is the subset of an RDAP domain object used by gofindadomain
+type RDAPDomain struct {
+	LDHName     string           `json:"ldhName"`
+	Handle      string           `json:"handle"`
+	Status      []string         `json:"status"`
+	Events      []RDAPEvent      `json:"events"`
+	Nameservers []RDAPNameserver `json:"nameservers"`
+	Entities    []RDAPEntity     `json:"entities"`
+	SecureDNS   *RDAPSecureDNS   `json:"secureDNS"`
+	Redacted    []RDAPRedaction  `json:"redacted"`
+}
+
+// RDAPRedaction names a field the server withheld (RFC 9537), such as
+// "Registrant Name"
+type RDAPRedaction struct {
+	Name struct {
+		Type        string `json:"type"`
+		Description string `json:"description"`
+	} `json:"name"`
+}
+
+// RDAPSecureDNS holds the DNSSEC state of the delegation
+type RDAPSecureDNS struct {
+	DelegationSigned bool `json:"delegationSigned"`
+}
+
+// RDAPEvent is a dated event such as registration or expiration
+type RDAPEvent struct {
+	Action string `json:"eventAction"`
+	Date   string `json:"eventDate"`
+}
+
+// RDAPNameserver is a nameserver delegated for the domain
+type RDAPNameserver struct {
+	LDHName string `json:"ldhName"`
+}
+
+// RDAPEntity is a contact attached to the domain (registrar, registrant, ...)
+type RDAPEntity struct {
+	Roles      []string        `json:"roles"`
+	VCardArray json.RawMessage `json:"vcardArray"`
+	Entities   []RDAPEntity    `json:"entities"`
+}
+
+// Available reports whether the registry had no record for the domain
+func (r *RDAPResponse) Available() bool {
+	return r.StatusCode == http.StatusNotFound
+}
+
+// EventDate returns the date of the first event with the given action, or ""
+func (d *RDAPDomain) EventDate(action string) string {
+	for _, e := range d.Events {
+		if strings.EqualFold(e.Action, action) {
+			return e.Date
+		}
+	}
+	return ""
+}
+
+// NameserverNames returns the lower-cased nameserver host names
+func (d *RDAPDomain) NameserverNames() []string {
+	var names []string
+	for _, ns := range d.Nameservers {
+		names = append(names, strings.ToLower(strings.TrimSuffix(ns.LDHName, ".")))
+	}
+	return names
+}
+
+// Signed reports whether the registry says the delegation is DNSSEC signed
+func (d *RDAPDomain) Signed() bool {
+	return d.SecureDNS != nil && d.SecureDNS.DelegationSigned
+}
+
+// Registrar returns the name of the registrar entity, or ""
+func (d *RDAPDomain) Registrar() string {
+	for _, e := range d.Entities {
+		for _, role := range e.Roles {
+			if role == "registrar" {
+				return e.Name()
+			}
+		}
+	}
+	return ""
+}
+
+// Name returns the formatted name (vCard "fn") of the entity, or ""
+func (e RDAPEntity) Name() string {
+	p, ok := e.vcardProperty("fn")
+	if !ok {
+		return ""
+	}
+	var value string
+	if json.Unmarshal(p[3], &value) != nil {
+		return ""
+	}
+	return value
+}
+
+// LookupRDAP queries the authoritative RDAP server for a domain
+func LookupRDAP(ctx context.Context, domain string) (*RDAPResponse, error) {
+	server, err := RDAPServer(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSuffix(server, "/") + "/domain/" + domain
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/rdap+json")
+
+	if err := WaitServer(ctx, req.URL.Host); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result, err := queryRDAP(req, server)
+	metrics.ObserveQuery(metrics.RDAP, req.URL.Host, time.Since(start), err != nil, errors.Is(err, ErrRateLimited))
+	return result, err
+}
+
+// queryRDAP sends an RDAP request to server
+func queryRDAP(req *http.Request, server string) (*RDAPResponse, error) {
+	resp, err := rdapClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("RDAP query failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RDAP response: %w", err)
+	}
+
+	result := &RDAPResponse{Server: server, StatusCode: resp.StatusCode, Raw: body}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var d RDAPDomain
+		if err := json.Unmarshal(body, &d); err != nil {
+			return nil, fmt.Errorf("failed to parse RDAP response: %w", err)
+		}
+		result.Domain = &d
+	case http.StatusNotFound:
+	case http.StatusTooManyRequests:
+		return nil, fmt.Errorf("RDAP query to %s failed: %w", server, ErrRateLimited)
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return nil, fmt.Errorf("RDAP query to %s failed: %w (HTTP %d)", server, ErrServerUnavailable, resp.StatusCode)
+	default:
+		return nil, fmt.Errorf("RDAP query failed: HTTP %d", resp.StatusCode)
+	}
+
+	return result, nil
+}
+
+// RDAPServer returns the RDAP base URL responsible for a domain's TLD
+func RDAPServer(ctx context.Context, domain string) (string, error) {
+	servers, err := loadBootstrap(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// The bootstrap lists TLDs only; a suffix with its own registry is not
+	// served by the TLD's RDAP server
+	if suffix, _, ok := suffixServer(domain); ok {
+		return "", fmt.Errorf("%w .%s", ErrNoRDAPServer, suffix)
+	}
+
+	tld := strings.ToLower(domain[strings.LastIndex(domain, ".")+1:])
+	server, ok := servers[tld]
+	if !ok {
+		return "", fmt.Errorf("%w .%s", ErrNoRDAPServer, tld)
+	}
+	return server, nil
+}
+
+// loadBootstrap fetches the IANA RDAP bootstrap file once per process
+func loadBootstrap(ctx context.Context) (map[string]string, error) {
+	bootstrapMu.Lock()
+	defer bootstrapMu.Unlock()
+
+	if bootstrapServers != nil {
+		return bootstrapServers, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, RDAPBootstrapURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := rdapClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch RDAP bootstrap: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch RDAP bootstrap: HTTP %d", resp.StatusCode)
+	}
+
+	var bootstrap struct {
+		Services [][][]string `json:"services"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&bootstrap); err != nil {
+		return nil, fmt.Errorf("failed to parse RDAP bootstrap: %w", err)
+	}
+
+	servers := make(map[string]string)
+	for _, service := range bootstrap.Services {
+		if len(service) < 2 || len(service[1]) == 0 {
+			continue
+		}
+		for _, tld := range service[0] {
+			servers[strings.ToLower(tld)] = service[1][0]
+		}
+	}
+
+	bootstrapServers = servers
+	return servers, nil
+}
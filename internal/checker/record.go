@@ -0,0 +1,73 @@
+package checker
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	registrarPattern  = regexp.MustCompile(`(?im)^\s*(?:Registrar|Registrar Name|Sponsoring Registrar)[ \t]*:[ \t]*(\S.*?)\s*$`)
+	updatedPattern    = regexp.MustCompile(`(?i)(Updated Date|Last Updated On|Last Updated|Last Modified|Last-Update|Changed|Modified)[:\s]+([0-9]{4}[-./][0-9]{2}[-./][0-9]{2}|[0-9]{2}[-./][0-9]{2}[-./][0-9]{4}|[0-9]{2}-[A-Za-z]{3}-[0-9]{4})`)
+	nameserverPattern = regexp.MustCompile(`(?im)^\s*(?:Name Server|Nameservers?|nserver|Host Name)[ \t]*:[ \t]*([A-Za-z0-9.-]+\.[A-Za-z0-9-]+)`)
+	statusPattern     = regexp.MustCompile(`(?im)^\s*(?:Domain Status|Status|state)[ \t]*:[ \t]*([A-Za-z][A-Za-z-]*)`)
+	dnssecPattern     = regexp.MustCompile(`(?im)^\s*DNSSEC[ \t]*:[ \t]*(\S+)`)
+)
+
+// extractRegistrar extracts the sponsoring registrar's name from whois output
+func extractRegistrar(whoisOutput string) string {
+	if m := registrarPattern.FindStringSubmatch(whoisOutput); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// extractUpdatedDate extracts the last-updated date from whois output
+func extractUpdatedDate(whoisOutput string) string {
+	if m := updatedPattern.FindStringSubmatch(whoisOutput); len(m) >= 3 {
+		return m[2]
+	}
+	return ""
+}
+
+// extractNameservers extracts the delegated nameservers from whois output,
+// lower-cased and without duplicates from the registrar's answer
+func extractNameservers(whoisOutput string) []string {
+	var nameservers []string
+	seen := make(map[string]bool)
+	for _, m := range nameserverPattern.FindAllStringSubmatch(whoisOutput, -1) {
+		ns := strings.ToLower(m[1])
+		if !seen[ns] {
+			seen[ns] = true
+			nameservers = append(nameservers, ns)
+		}
+	}
+	return nameservers
+}
+
+// extractStatus extracts the EPP status codes from whois output, dropping the
+// ICANN explanation URLs that follow them
+func extractStatus(whoisOutput string) []string {
+	var status []string
+	seen := make(map[string]bool)
+	for _, m := range statusPattern.FindAllStringSubmatch(whoisOutput, -1) {
+		code := m[1]
+		if !seen[strings.ToLower(code)] {
+			seen[strings.ToLower(code)] = true
+			status = append(status, code)
+		}
+	}
+	return status
+}
+
+// extractDNSSEC reports whether whois output says the delegation is signed
+func extractDNSSEC(whoisOutput string) bool {
+	m := dnssecPattern.FindStringSubmatch(whoisOutput)
+	if m == nil {
+		return false
+	}
+	switch strings.ToLower(m[1]) {
+	case "signeddelegation", "signed", "yes", "true":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package checker
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	registrantOrgPattern     = regexp.MustCompile(`(?im)^\s*Registrant\s+Organi[sz]ation\s*:[ \t]*(.*?)\s*$`)
+	registrantCountryPattern = regexp.MustCompile(`(?im)^\s*Registrant\s+Country(?:\s+Code)?\s*:[ \t]*(.*?)\s*$`)
+	// registrantFieldPattern matches every field of the registrant contact
+	// block ("Registrant Name:", "Registrant Email:", "Registrant:")
+	registrantFieldPattern = regexp.MustCompile(`(?im)^\s*Registrant(?:\s+[A-Za-z/ ]+?)?\s*:[ \t]*(\S.*?)\s*$`)
+	abuseEmailPattern      = regexp.MustCompile(`(?im)^\s*(?:Registrar\s+Abuse\s+Contact\s+Email|Abuse\s+(?:Contact\s+)?E-?mail|abuse-mailbox)\s*:[ \t]*([^\s@]+@[^\s@]+\.[^\s@]+)`)
+)
+
+// redactionMarkers are fragments of the placeholders registries and privacy
+// services publish instead of real registrant data
+var redactionMarkers = []string{
+	"redacted",
+	"privacy",
+	"private",
+	"proxy",
+	"withheld",
+	"not disclosed",
+	"data protected",
+	"gdpr",
+	"masked",
+	"masking",
+	"non-public",
+}
+
+// extractRegistrant extracts the registrant organization and country from
+// whois output, skipping redacted values
+func extractRegistrant(whoisOutput string) (org, country string) {
+	if m := registrantOrgPattern.FindStringSubmatch(whoisOutput); m != nil {
+		org = published(m[1])
+	}
+	if m := registrantCountryPattern.FindStringSubmatch(whoisOutput); m != nil {
+		country = published(m[1])
+	}
+	return org, country
+}
+
+// extractRegistrantPrivacy reports whether any field of the registrant
+// contact block in whois output is redacted ("REDACTED FOR PRIVACY") or
+// names a privacy service
+func extractRegistrantPrivacy(whoisOutput string) bool {
+	for _, m := range registrantFieldPattern.FindAllStringSubmatch(whoisOutput, -1) {
+		if published(m[1]) == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// extractAbuseEmail extracts the registrar's abuse contact email from whois
+// output
+func extractAbuseEmail(whoisOutput string) string {
+	if m := abuseEmailPattern.FindStringSubmatch(whoisOutput); m != nil {
+		return strings.ToLower(m[1])
+	}
+	return ""
+}
+
+// published returns v, or "" when it is empty or a redaction placeholder
+func published(v string) string {
+	lower := strings.ToLower(v)
+	for _, m := range redactionMarkers {
+		if strings.Contains(lower, m) {
+			return ""
+		}
+	}
+	return v
+}
+
+// Registrant returns the registrant organization and country, or "" for
+// values that are missing or redacted
+func (d *RDAPDomain) Registrant() (org, country string) {
+	for _, e := range d.Entities {
+		for _, role := range e.Roles {
+			if role != "registrant" {
+				continue
+			}
+			if v, ok := e.vcardProperty("org"); ok {
+				var name string
+				if json.Unmarshal(v[3], &name) == nil {
+					org = published(name)
+				}
+			}
+			if v, ok := e.vcardProperty("adr"); ok {
+				country = published(adrCountry(v))
+			}
+			return org, country
+		}
+	}
+	return "", ""
+}
+
+// vcardProperty returns the first property of the entity's jCard with the
+// given name, as [name, parameters, type, value]
+func (e RDAPEntity) vcardProperty(name string) ([]json.RawMessage, bool) {
+	var vcard []json.RawMessage
+	if err := json.Unmarshal(e.VCardArray, &vcard); err != nil || len(vcard) < 2 {
+		return nil, false
+	}
+	var props [][]json.RawMessage
+	if err := json.Unmarshal(vcard[1], &props); err != nil {
+		return nil, false
+	}
+	for _, p := range props {
+		if len(p) < 4 {
+			continue
+		}
+		var n string
+		if json.Unmarshal(p[0], &n) == nil && n == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// adrCountry returns the country of a jCard adr property: the "cc" parameter
+// if present, otherwise the country-name component of the structured value
+func adrCountry(prop []json.RawMessage) string {
+	var params map[string]any
+	if json.Unmarshal(prop[1], &params) == nil {
+		if cc, ok := params["cc"].(string); ok && cc != "" {
+			return cc
+		}
+	}
+	var value []any
+	if json.Unmarshal(prop[3], &value) == nil && len(value) >= 7 {
+		if name, ok := value[6].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// RegistrantPrivacy reports whether the registrant's contact details are
+// withheld: redacted as listed in the response's RFC 9537 "redacted"
+// member, or replaced by placeholders or a privacy service
+func (d *RDAPDomain) RegistrantPrivacy() bool {
+	for _, r := range d.Redacted {
+		name := r.Name.Type + " " + r.Name.Description
+		if strings.Contains(strings.ToLower(name), "registrant") {
+			return true
+		}
+	}
+	for _, e := range d.Entities {
+		if !slices.Contains(e.Roles, "registrant") {
+			continue
+		}
+		for _, prop := range []string{"fn", "org", "email"} {
+			v, ok := e.vcardProperty(prop)
+			if !ok {
+				continue
+			}
+			var value string
+			if json.Unmarshal(v[3], &value) == nil && value != "" && published(value) == "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AbuseEmail returns the email of the registrar's abuse contact, or ""
+func (d *RDAPDomain) AbuseEmail() string {
+	for _, e := range d.Entities {
+		if !slices.Contains(e.Roles, "registrar") {
+			continue
+		}
+		for _, c := range e.Entities {
+			if !slices.Contains(c.Roles, "abuse") {
+				continue
+			}
+			v, ok := c.vcardProperty("email")
+			if !ok {
+				continue
+			}
+			var email string
+			if json.Unmarshal(v[3], &email) == nil {
+				return strings.ToLower(email)
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,311 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Result represents the result of a domain availability check
+type Result struct {
+	Domain      string
+	Available   bool
+	ExpiryDate  string
+	CreatedDate string
+	// Reregistered is the date the domain was last re-registered after a
+	// drop, when the registry publishes it
+	Reregistered string
+	// RegistrantOrg and RegistrantCountry are only set when the registry
+	// publishes them unredacted
+	RegistrantOrg     string
+	RegistrantCountry string
+	// RegistrantPrivacy is true when the registrant's contact details are
+	// redacted or replaced by a privacy or proxy service
+	RegistrantPrivacy bool
+	Registrar         string
+	// AbuseEmail is the registrar's abuse contact
+	AbuseEmail  string
+	UpdatedDate string
+	Nameservers []string
+	// Status holds the EPP status codes (clientTransferProhibited, ...)
+	Status []string
+	DNSSEC bool
+	Error  error
+
+	// Parked is set by the parking probe for taken domains
+	Parked bool
+	// ForSale, SaleURL and SaleContact are set by the for-sale probe for
+	// taken domains
+	ForSale     bool
+	SaleURL     string
+	SaleContact string
+	// Usage is set by the usage probe for taken domains
+	Usage *Usage
+	// DNS is set by the DNS enrichment for taken domains
+	DNS *DNSRecords
+	// Listing is set by the aftermarket enrichment for taken domains listed
+	// on a marketplace
+	Listing *Listing
+	// Certs is set by the certificate history enrichment for available
+	// domains
+	Certs *CertHistory
+	// Archive is set by the Wayback Machine enrichment for available domains
+	Archive *ArchiveHistory
+	// Reputation is set by the blocklist enrichment for available domains
+	Reputation *Reputation
+	// Trademarks are set by the trademark enrichment for available domains
+	// whose name matches a live trademark
+	Trademarks []TrademarkHit
+	// TrademarkError is set when a trademark register could not be searched,
+	// so Trademarks may be missing marks
+	TrademarkError error
+
+	// Premium and Reserved mark names nobody has registered that the
+	// registry does not sell at its base price: premium names cost more,
+	// reserved ones are withheld. Neither is Available.
+	Premium  bool
+	Reserved bool
+	// Confidence is how far the result can be trusted
+	Confidence Confidence
+	// Backend names the backend that produced the result, such as "rdap"
+	// when a chain fell back to it
+	Backend string
+}
+
+// Restriction names why an unregistered domain is not available:
+// "premium", "reserved" or "" for neither
+func (r Result) Restriction() string {
+	switch {
+	case r.Reserved:
+		return "reserved"
+	case r.Premium:
+		return "premium"
+	}
+	return ""
+}
+
+// HasStatus reports whether the result carries an EPP status code. Case and
+// spaces are ignored, so RDAP's "pending delete" matches pendingDelete.
+func (r Result) HasStatus(code string) bool {
+	code = normalizeStatus(code)
+	for _, s := range r.Status {
+		if normalizeStatus(s) == code {
+			return true
+		}
+	}
+	return false
+}
+
+// Dropping reports whether a registered domain is pending deletion, which
+// releases it within days
+func (r Result) Dropping() bool {
+	return r.Error == nil && !r.Available && r.HasStatus("pendingDelete")
+}
+
+func normalizeStatus(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+}
+
+// Usage holds signals that a taken domain is actively used
+type Usage struct {
+	// MX is true when the domain has mail exchangers
+	MX bool
+	// Web is true when the domain serves a website that is not a parking page
+	Web bool
+	// HTTPS is true when the domain presents a valid TLS certificate
+	HTTPS bool
+}
+
+// Dormant reports whether no usage signal was found
+func (u Usage) Dormant() bool {
+	return !u.MX && !u.Web && !u.HTTPS
+}
+
+// String lists the signals found ("mx web https"), or "dormant" when there are none
+func (u Usage) String() string {
+	if u.Dormant() {
+		return "dormant"
+	}
+	var signals []string
+	if u.MX {
+		signals = append(signals, "mx")
+	}
+	if u.Web {
+		signals = append(signals, "web")
+	}
+	if u.HTTPS {
+		signals = append(signals, "https")
+	}
+	return strings.Join(signals, " ")
+}
+
+// DNSRecords are the address and mail records a taken domain resolves to
+type DNSRecords struct {
+	A    []string
+	AAAA []string
+	// MX are the mail exchanger hosts, by preference; a null MX (".")
+	// declaring that the domain takes no mail is left out
+	MX []string
+}
+
+// Hosts reports whether the domain resolves to an address
+func (d DNSRecords) Hosts() bool {
+	return len(d.A) > 0 || len(d.AAAA) > 0
+}
+
+// Mail reports whether the domain has mail exchangers
+func (d DNSRecords) Mail() bool {
+	return len(d.MX) > 0
+}
+
+// String summarizes the records ("hosts 2 A, 1 AAAA; mail via mx1.example.com"),
+// or says that the domain neither hosts anything nor receives mail
+func (d DNSRecords) String() string {
+	if !d.Hosts() && !d.Mail() {
+		return "no A, AAAA or MX records"
+	}
+	var parts []string
+	if d.Hosts() {
+		var counts []string
+		if len(d.A) > 0 {
+			counts = append(counts, fmt.Sprintf("%d A", len(d.A)))
+		}
+		if len(d.AAAA) > 0 {
+			counts = append(counts, fmt.Sprintf("%d AAAA", len(d.AAAA)))
+		}
+		parts = append(parts, "hosts "+strings.Join(counts, ", "))
+	} else {
+		parts = append(parts, "no address")
+	}
+	if d.Mail() {
+		parts = append(parts, "mail via "+d.MX[0])
+	} else {
+		parts = append(parts, "no mail")
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Listing is an aftermarket offer for a taken domain
+type Listing struct {
+	// Marketplace names where the domain is listed, such as "sedo"
+	Marketplace string
+	// Price is the asking price; 0 means the seller takes offers
+	Price    float64
+	Currency string
+	URL      string
+}
+
+// String describes the offer ("sedo $2500", "sedo 1800 EUR" or
+// "sedo, make an offer")
+func (l Listing) String() string {
+	switch {
+	case l.Price <= 0:
+		return l.Marketplace + ", make an offer"
+	case l.Currency == "" || l.Currency == "USD":
+		return fmt.Sprintf("%s $%.0f", l.Marketplace, l.Price)
+	}
+	return fmt.Sprintf("%s %.0f %s", l.Marketplace, l.Price, l.Currency)
+}
+
+// CertHistory summarizes the certificates logged in certificate transparency
+// for a domain, which reveal that a now available domain was used before
+type CertHistory struct {
+	Count int
+	// FirstIssued and LastIssued are the earliest and latest issue dates
+	// (YYYY-MM-DD)
+	FirstIssued string
+	LastIssued  string
+	// Issuers are the issuing organizations, most frequent first
+	Issuers []string
+}
+
+// String summarizes the history ("14 issued 2016-03-01 to 2021-08-30 by
+// Let's Encrypt, Sectigo Limited"), or says there is none
+func (c CertHistory) String() string {
+	if c.Count == 0 {
+		return "none issued"
+	}
+	s := fmt.Sprintf("%d issued %s to %s", c.Count, c.FirstIssued, c.LastIssued)
+	if c.Count == 1 {
+		s = "1 issued " + c.FirstIssued
+	}
+	if len(c.Issuers) > 0 {
+		s += " by " + strings.Join(c.Issuers, ", ")
+	}
+	return s
+}
+
+// ArchiveHistory summarizes the Wayback Machine snapshots of a domain's home
+// page, which show whether an available domain had content before
+type ArchiveHistory struct {
+	// Months counts the months with at least one snapshot that served content
+	Months int
+	// FirstSnapshot and LastSnapshot are the dates of the earliest and latest
+	// such snapshots (YYYY-MM-DD)
+	FirstSnapshot string
+	LastSnapshot  string
+}
+
+// String summarizes the history ("archived in 38 months from 2009-04-12,
+// last snapshot 2019-11-03"), or says there is none
+func (a ArchiveHistory) String() string {
+	switch a.Months {
+	case 0:
+		return "never archived"
+	case 1:
+		return "archived once, " + a.LastSnapshot
+	}
+	return fmt.Sprintf("archived in %d months from %s, last snapshot %s", a.Months, a.FirstSnapshot, a.LastSnapshot)
+}
+
+// Reputation is what blocklists say about a domain, which reveals an
+// available domain abused by its previous owner
+type Reputation struct {
+	// Listings name the blocklists listing the domain, with the reason when
+	// known ("Spamhaus DBL: phishing")
+	Listings []string
+	// Checked counts the blocklists that answered
+	Checked int
+}
+
+// Clean reports whether no blocklist that answered lists the domain
+func (r Reputation) Clean() bool {
+	return len(r.Listings) == 0
+}
+
+// String summarizes the reputation ("listed on Spamhaus DBL: phishing" or
+// "clean on 3 blocklists")
+func (r Reputation) String() string {
+	if !r.Clean() {
+		return "listed on " + strings.Join(r.Listings, ", ")
+	}
+	if r.Checked == 1 {
+		return "clean on 1 blocklist"
+	}
+	return fmt.Sprintf("clean on %d blocklists", r.Checked)
+}
+
+// TrademarkHit is a live trademark matching a domain's name
+type TrademarkHit struct {
+	// Mark is the trademark's word element as registered
+	Mark string
+	// Office is the register holding it, such as "EUIPO"
+	Office string
+	Number string
+	Status string
+	// Exact is true when the mark equals the name, ignoring case, spaces
+	// and punctuation; otherwise it is close
+	Exact bool
+}
+
+// String describes the hit ("exact SWIFTPANDA (EUIPO 018123456, registered)")
+func (t TrademarkHit) String() string {
+	kind := "close"
+	if t.Exact {
+		kind = "exact"
+	}
+	ref := strings.TrimSpace(t.Office + " " + t.Number)
+	if t.Status != "" {
+		ref += ", " + strings.ToLower(strings.ReplaceAll(t.Status, "_", " "))
+	}
+	return fmt.Sprintf("%s %s (%s)", kind, t.Mark, ref)
+}
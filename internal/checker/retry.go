@@ -0,0 +1,95 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	// ErrRateLimited is returned when a server refuses a query because too
+	// many were sent
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServerUnavailable is returned when a server fails to answer a query
+	// it may answer later
+	ErrServerUnavailable = errors.New("server temporarily unavailable")
+)
+
+// rateLimitedPattern matches whois answers refusing a query over quota
+var rateLimitedPattern = regexp.MustCompile(`(?i)(quota exceeded|limit exceeded|exceeded the (?:query|maximum|allowed)|too many (?:requests|queries|connections)|rate limit|try again later|temporarily (?:denied|blocked|unavailable))`)
+
+// RetryBackend retries checks that fail transiently, waiting with
+// exponential backoff and jitter between attempts
+type RetryBackend struct {
+	Backend
+	// Retries is how many times a failed check is retried
+	Retries int
+	// Delay is the wait before the first retry; it doubles with each retry
+	Delay time.Duration
+}
+
+// Check implements Backend
+func (b RetryBackend) Check(ctx context.Context, domain string) Result {
+	r := b.Backend.Check(ctx, domain)
+	for attempt := 0; attempt < b.Retries && r.Error != nil && Transient(r.Error); attempt++ {
+		timer := time.NewTimer(backoff(b.Delay, attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return r
+		}
+		r = b.Backend.Check(ctx, domain)
+	}
+	return r
+}
+
+// backoff returns delay doubled attempt times, spread by up to ±50% so
+// parallel checks do not retry in lockstep
+func backoff(delay time.Duration, attempt int) time.Duration {
+	d := delay << attempt
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d)
+}
+
+// Transient reports whether a check failed for a reason that may go away
+// when it is retried: rate limiting, timeouts and dropped connections
+func Transient(err error) bool {
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServerUnavailable) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsTemporary {
+		return true
+	}
+
+	// Plugin backends report errors as text
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "connection reset") || strings.Contains(msg, "timeout") ||
+		strings.Contains(msg, "timed out") || rateLimitedPattern.MatchString(msg)
+}
+
+// rateLimited reports whether a whois answer refuses the query over quota
+func rateLimited(whoisOutput string) bool {
+	return rateLimitedPattern.MatchString(whoisOutput)
+}
@@ -0,0 +1,47 @@
+package checker
+
+import "strings"
+
+// registrySuffixServers are the whois servers of second-level suffixes run
+// by their own registry rather than the TLD's. A query for foo.uk.com sent
+// to the .com registry would wrongly find it unregistered.
+var registrySuffixServers = map[string]string{
+	"ae.org":  "whois.centralnic.com",
+	"br.com":  "whois.centralnic.com",
+	"cn.com":  "whois.centralnic.com",
+	"com.de":  "whois.centralnic.com",
+	"de.com":  "whois.centralnic.com",
+	"eu.com":  "whois.centralnic.com",
+	"gb.net":  "whois.centralnic.com",
+	"gr.com":  "whois.centralnic.com",
+	"hu.net":  "whois.centralnic.com",
+	"in.net":  "whois.centralnic.com",
+	"jp.net":  "whois.centralnic.com",
+	"jpn.com": "whois.centralnic.com",
+	"mex.com": "whois.centralnic.com",
+	"ru.com":  "whois.centralnic.com",
+	"sa.com":  "whois.centralnic.com",
+	"se.net":  "whois.centralnic.com",
+	"uk.com":  "whois.centralnic.com",
+	"uk.net":  "whois.centralnic.com",
+	"us.com":  "whois.centralnic.com",
+	"us.org":  "whois.centralnic.com",
+	"za.com":  "whois.centralnic.com",
+}
+
+// suffixServer returns the whois server of the longest multi-label suffix
+// of domain that has one of its own, such as uk.com in foo.uk.com. Suffixes
+// run by the TLD's registry, such as co.uk, have none.
+func suffixServer(domain string) (suffix, server string, ok bool) {
+	whoisServersMu.Lock()
+	defer whoisServersMu.Unlock()
+
+	_, suffix, _ = strings.Cut(strings.ToLower(domain), ".")
+	for strings.Contains(suffix, ".") {
+		if server, ok := whoisServers[suffix]; ok {
+			return suffix, server, true
+		}
+		_, suffix, _ = strings.Cut(suffix, ".")
+	}
+	return "", "", false
+}
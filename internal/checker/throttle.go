@@ -0,0 +1,195 @@
+package checker
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/james-see/gofindadomain/internal/metrics"
+)
+
+// adaptiveStart is the concurrency an adaptive throttle starts at
+const adaptiveStart = 4
+
+// adaptive makes NewRunThrottle return adaptive throttles
+var adaptive atomic.Bool
+
+func init() {
+	adaptive.Store(true)
+}
+
+// SetAdaptiveConcurrency sets whether runs adapt their concurrency to the
+// errors they see (the default) or keep it fixed
+func SetAdaptiveConcurrency(enabled bool) {
+	adaptive.Store(enabled)
+}
+
+// Throttle limits how many checks of a run are in flight. It can be paused
+// and its limit changed while the run goes on; checks already in flight
+// finish either way.
+type Throttle struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	limit  int
+	active int
+	paused bool
+
+	// An adaptive throttle moves limit between 1 and ceiling: it grows by
+	// one for each limit checks that succeed and halves when a check is
+	// rate limited or the server refuses or drops it (AIMD). Until the
+	// first such error it grows by one for every success (slow start).
+	adaptive  bool
+	ceiling   int
+	slowStart bool
+	acked     int
+	// checks that finish before the ones in flight at the last cut cannot
+	// cut again, so one burst of errors halves the limit once
+	sinceCut, inFlightAtCut int
+}
+
+// NewThrottle returns a Throttle allowing limit concurrent checks
+func NewThrottle(limit int) *Throttle {
+	t := &Throttle{limit: max(1, limit)}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+// NewAdaptiveThrottle returns a Throttle that ramps up to ceiling concurrent
+// checks while they succeed and backs off when servers push back
+func NewAdaptiveThrottle(ceiling int) *Throttle {
+	ceiling = max(1, ceiling)
+	t := &Throttle{limit: min(adaptiveStart, ceiling), adaptive: true, ceiling: ceiling, slowStart: true}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+// NewRunThrottle returns an adaptive Throttle with a ceiling of limit, or a
+// fixed one after SetAdaptiveConcurrency(false)
+func NewRunThrottle(limit int) *Throttle {
+	if adaptive.Load() {
+		return NewAdaptiveThrottle(limit)
+	}
+	return NewThrottle(limit)
+}
+
+// acquire waits until a check may start, or ctx is done
+func (t *Throttle) acquire(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for t.paused || t.active >= t.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		t.cond.Wait()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	t.active++
+	return nil
+}
+
+func (t *Throttle) release() {
+	t.mu.Lock()
+	t.active--
+	t.mu.Unlock()
+	t.cond.Broadcast()
+}
+
+// wake lets waiting acquires see that the run was cancelled
+func (t *Throttle) wake() {
+	t.mu.Lock()
+	t.cond.Broadcast()
+	t.mu.Unlock()
+}
+
+// SetPaused stops or resumes starting new checks
+func (t *Throttle) SetPaused(paused bool) {
+	t.mu.Lock()
+	t.paused = paused
+	t.mu.Unlock()
+	t.cond.Broadcast()
+}
+
+// SetLimit changes the number of concurrent checks, at least one, or the
+// ceiling of an adaptive throttle. Lowering it takes effect as checks in
+// flight finish.
+func (t *Throttle) SetLimit(limit int) {
+	t.mu.Lock()
+	if t.adaptive {
+		t.ceiling = max(1, limit)
+		t.limit = min(t.limit, t.ceiling)
+	} else {
+		t.limit = max(1, limit)
+	}
+	t.mu.Unlock()
+	t.cond.Broadcast()
+}
+
+// observe adapts the limit of an adaptive throttle to a finished check
+func (t *Throttle) observe(r Result) {
+	if !t.adaptive {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.sinceCut++
+	if r.Error != nil && errorReason(r.Error) != "other" {
+		if t.sinceCut >= t.inFlightAtCut {
+			t.limit = max(1, t.limit/2)
+			t.slowStart = false
+			t.acked = 0
+			t.sinceCut, t.inFlightAtCut = 0, t.active
+			metrics.ConcurrencyBackoffs.Inc()
+		}
+		return
+	}
+	if t.slowStart {
+		t.limit = min(t.limit+1, t.ceiling)
+		t.cond.Broadcast()
+		return
+	}
+	if t.acked++; t.acked >= t.limit {
+		t.acked = 0
+		t.limit = min(t.limit+1, t.ceiling)
+		t.cond.Broadcast()
+	}
+}
+
+// State returns the limit, the checks in flight and whether it is paused
+func (t *Throttle) State() (limit, active int, paused bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limit, t.active, t.paused
+}
+
+// CheckDomainsThrottled checks domains in the order set with SetQueryOrder,
+// with at most the throttle's limit in flight, starting no new check while
+// it is paused
+func CheckDomainsThrottled(ctx context.Context, backend Backend, domains []string, t *Throttle, resultChan chan<- Result) {
+	stop := context.AfterFunc(ctx, t.wake)
+	defer stop()
+
+	var wg sync.WaitGroup
+	for _, domain := range OrderDomains(domains, queryOrder.Load().(string)) {
+		if t.acquire(ctx) != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(d string) {
+			defer wg.Done()
+			defer t.release()
+
+			result := backend.Check(ctx, d)
+			ObserveCheck(result)
+			t.observe(result)
+			select {
+			case resultChan <- result:
+			case <-ctx.Done():
+			}
+		}(domain)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,68 @@
+package checker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestThrottleObserve(t *testing.T) {
+	ok := Result{Domain: "example.com"}
+	limited := Result{Domain: "example.com", Error: ErrRateLimited}
+	other := Result{Domain: "example.com", Error: errors.New("malformed reply")}
+
+	tests := []struct {
+		name      string
+		limit     int
+		ceiling   int
+		slowStart bool
+		// active is the number of checks in flight when the results arrive
+		active  int
+		results []Result
+		want    int
+	}{
+		{"slow start adds one per success", 4, 16, true, 0, []Result{ok, ok, ok}, 7},
+		{"slow start stops at the ceiling", 4, 5, true, 0, []Result{ok, ok, ok}, 5},
+		{"additive increase after a full window", 4, 16, false, 0, []Result{ok, ok, ok, ok}, 5},
+		{"no increase before a full window", 4, 16, false, 0, []Result{ok, ok, ok}, 4},
+		{"window grows with the limit", 4, 16, false, 0, []Result{ok, ok, ok, ok, ok, ok, ok, ok, ok}, 6},
+		{"additive increase stops at the ceiling", 6, 6, false, 0, []Result{ok, ok, ok, ok, ok, ok}, 6},
+		{"rate limit halves", 8, 16, false, 0, []Result{limited}, 4},
+		{"rate limit ends slow start", 8, 16, true, 0, []Result{limited, ok, ok}, 4},
+		{"each cut after the checks in flight", 8, 16, false, 0, []Result{limited, limited}, 2},
+		{"one burst cuts once", 8, 16, false, 3, []Result{limited, limited, limited}, 4},
+		{"burst over cuts again", 8, 16, false, 2, []Result{limited, limited, limited}, 2},
+		{"floor of one", 1, 16, false, 0, []Result{limited, limited}, 1},
+		{"other errors count as successes", 4, 16, true, 0, []Result{other}, 5},
+	}
+	for _, tt := range tests {
+		th := NewAdaptiveThrottle(tt.ceiling)
+		th.limit, th.slowStart, th.active = tt.limit, tt.slowStart, tt.active
+		for _, r := range tt.results {
+			th.observe(r)
+		}
+		if limit, _, _ := th.State(); limit != tt.want {
+			t.Errorf("%s: limit = %d, want %d", tt.name, limit, tt.want)
+		}
+	}
+}
+
+func TestThrottleFixed(t *testing.T) {
+	th := NewThrottle(4)
+	th.observe(Result{Domain: "example.com", Error: ErrRateLimited})
+	if limit, _, _ := th.State(); limit != 4 {
+		t.Errorf("fixed throttle limit = %d after a rate limit, want 4", limit)
+	}
+}
+
+func TestThrottleSetLimit(t *testing.T) {
+	th := NewAdaptiveThrottle(16)
+	th.limit = 12
+	th.SetLimit(8)
+	if limit, _, _ := th.State(); limit != 8 {
+		t.Errorf("limit = %d after lowering the ceiling to 8, want 8", limit)
+	}
+	th.SetLimit(0)
+	if limit, _, _ := th.State(); limit != 1 {
+		t.Errorf("limit = %d after setting the ceiling to 0, want 1", limit)
+	}
+}
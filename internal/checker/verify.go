@@ -0,0 +1,80 @@
+package checker
+
+import (
+	"context"
+	"net"
+)
+
+// Confidence is how far a result can be trusted
+type Confidence int
+
+const (
+	// ConfidenceNone is the confidence of failed checks
+	ConfidenceNone Confidence = iota
+	// ConfidenceLow marks guesses: whois output no pattern matched, which is
+	// assumed available, or a domain without nameservers
+	ConfidenceLow
+	// ConfidenceMedium marks whois output a pattern matched
+	ConfidenceMedium
+	// ConfidenceHigh marks authoritative answers (an RDAP record or its
+	// absence, delegated nameservers) and results confirmed by a second method
+	ConfidenceHigh
+)
+
+// String returns "low", "medium", "high", or "" for none
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	}
+	return ""
+}
+
+// VerifyBackend double-checks the domains its backend reports available: a
+// delegated domain is taken whatever whois said, and the rest are asked of
+// the Verifier, a second method such as RDAP. A domain both methods find
+// available gets high confidence; one the verifier finds registered is
+// reported as the verifier saw it. When the verifier fails the first answer
+// stands with the confidence it had.
+type VerifyBackend struct {
+	Backend
+	Verifier Backend
+	// Resolver is used for the NS lookups; nil means net.DefaultResolver
+	Resolver *net.Resolver
+}
+
+// VerifierFor picks the second method for double-checking b: whois when b
+// is RDAP, else RDAP (which itself falls back to whois for TLDs without an
+// RDAP server)
+func VerifierFor(b Backend) Backend {
+	if _, ok := Unwrap(b).(RDAPBackend); ok {
+		return NamedBackend{Name: "whois", Backend: WhoisBackend{}}
+	}
+	return NamedBackend{Name: "rdap", Backend: RDAPBackend{}}
+}
+
+// Check implements Backend
+func (b VerifyBackend) Check(ctx context.Context, domain string) Result {
+	r := b.Backend.Check(ctx, domain)
+	if r.Error != nil || !r.Available {
+		return r
+	}
+	if ns, err := lookupNS(ctx, b.Resolver, domain); err == nil && len(ns) > 0 {
+		return Result{Domain: domain, Nameservers: ns, Confidence: ConfidenceHigh, Backend: "dns"}
+	}
+
+	second := b.Verifier.Check(ctx, domain)
+	switch {
+	case second.Error != nil:
+		return r
+	case second.Available:
+		r.Confidence = ConfidenceHigh
+		return r
+	}
+	second.Confidence = ConfidenceHigh
+	return second
+}
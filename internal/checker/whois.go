@@ -2,54 +2,149 @@ package checker
 
 import (
 	"context"
+	"errors"
+	"net"
 	"os/exec"
 	"regexp"
 	"strings"
-	"sync"
+
+	"github.com/james-see/gofindadomain/internal/metrics"
 )
 
-// Result represents the result of a domain availability check
-type Result struct {
-	Domain     string
-	Available  bool
-	ExpiryDate string
-	Error      error
+// unregistered is the result for a domain the registry has no record of:
+// available, unless the name is on the registry's block list
+func unregistered(domain string, c Confidence) Result {
+	if blocked(domain) {
+		return Result{Domain: domain, Reserved: true, Confidence: c}
+	}
+	return Result{Domain: domain, Available: true, Confidence: c}
+}
+
+// CheckDomain checks if a domain is available using the native whois client
+func CheckDomain(ctx context.Context, domain string) Result {
+	resp, err := LookupWhois(ctx, domain)
+	if err != nil {
+		return Result{Domain: domain, Error: err}
+	}
+	text, _ := Decode(domain, resp.Raw)
+	return Parse(domain, text)
 }
 
-// CheckDomain checks if a domain is available using whois
-func CheckDomain(domain string) Result {
-	result := Result{Domain: domain}
+// CheckDomainSystem checks if a domain is available using the system whois command
+func CheckDomainSystem(ctx context.Context, domain string) Result {
+	raw, err := LookupSystem(ctx, domain)
+	if err != nil {
+		return Result{Domain: domain, Error: err}
+	}
+	text, _ := Decode(domain, raw)
+	return Parse(domain, text)
+}
 
-	cmd := exec.Command("whois", domain)
+// LookupSystem runs the system whois command and returns its output as
+// received. An override set with SetWhoisOverrides is passed with -h, and
+// the command is killed once ctx is done.
+func LookupSystem(ctx context.Context, domain string) ([]byte, error) {
+	args := []string{domain}
+	if server, ok := WhoisOverride(domain); ok {
+		if host, port, err := net.SplitHostPort(server); err == nil {
+			args = []string{"-h", host, "-p", port, domain}
+		} else {
+			args = []string{"-h", server, domain}
+		}
+	}
+	cmd := exec.CommandContext(ctx, "whois", args...)
 	output, err := cmd.Output()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
 	if err != nil {
 		// whois might return non-zero for some domains, check output anyway
 		if output == nil {
-			result.Error = err
-			return result
+			return nil, err
 		}
 	}
+	return output, nil
+}
 
-	whoisOutput := string(output)
+// WhoisVersion returns the first line of `whois --version`, or "" if the
+// installed whois does not report one
+func WhoisVersion() string {
+	output, err := exec.Command("whois", "--version").Output()
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(string(output), "\n")
+	return strings.TrimSpace(line)
+}
 
-	// First check for clear "not found" / "available" indicators
-	availablePatterns := regexp.MustCompile(`(?i)(No match|NOT FOUND|No entries found|No Data Found|not registered|Status:\s*free|Status:\s*available|No Object Found|Domain not found|is free|No information available|not been registered|not exist)`)
-	if availablePatterns.MatchString(whoisOutput) {
-		result.Available = true
+// Parse builds a Result for domain from raw whois output
+func Parse(domain, whoisOutput string) Result {
+	result := Result{Domain: domain, Confidence: ConfidenceMedium}
+	if premium, reserved, _ := Restriction(domain, whoisOutput); premium || reserved {
+		result.Premium, result.Reserved = premium, reserved
 		return result
 	}
+	var indicator string
+	result.Available, indicator = Classify(domain, whoisOutput)
+	// An over-quota refusal carries no indicator and must not pass as available
+	if indicator == "" && rateLimited(whoisOutput) {
+		return Result{Domain: domain, Error: ErrRateLimited}
+	}
+	if result.Available {
+		// Nothing matched at all: available is only a guess
+		if indicator == "" {
+			return unregistered(domain, ConfidenceLow)
+		}
+		return unregistered(domain, ConfidenceMedium)
+	}
+	result.Confidence = ConfidenceHigh
+	result.ExpiryDate = extractExpiryDate(whoisOutput)
+	result.CreatedDate = extractCreatedDate(whoisOutput)
+	result.RegistrantOrg, result.RegistrantCountry = extractRegistrant(whoisOutput)
+	result.RegistrantPrivacy = extractRegistrantPrivacy(whoisOutput)
+	result.Registrar = extractRegistrar(whoisOutput)
+	result.AbuseEmail = extractAbuseEmail(whoisOutput)
+	result.UpdatedDate = extractUpdatedDate(whoisOutput)
+	result.Nameservers = extractNameservers(whoisOutput)
+	result.Status = extractStatus(whoisOutput)
+	result.DNSSEC = extractDNSSEC(whoisOutput)
+	return result
+}
+
+// Restriction reports whether whois output for domain marks the name as
+// premium or reserved, trying the TLD's own patterns before the default
+// ones. It also returns the text that matched.
+func Restriction(domain, whoisOutput string) (premium, reserved bool, indicator string) {
+	for _, p := range patternsFor(domain) {
+		if match := firstMatch(p.reserved, whoisOutput); match != "" {
+			return false, true, match
+		}
+		if match := firstMatch(p.premium, whoisOutput); match != "" {
+			return true, false, match
+		}
+	}
+	return false, false, ""
+}
+
+// Classify decides whether whois output for domain describes an available
+// domain, trying the TLD's own patterns before the default ones. It also
+// returns the text that decided the classification, which is empty when no
+// pattern matched and the domain is assumed available.
+func Classify(domain, whoisOutput string) (available bool, indicator string) {
+	for _, p := range patternsFor(domain) {
+		// First check for clear "not found" / "available" indicators
+		if match := firstMatch(p.available, whoisOutput); match != "" {
+			return true, match
+		}
 
-	// Check for indicators that domain is registered
-	registeredPattern := regexp.MustCompile(`(?i)(Name Server|nserver|nameservers|status:\s*active|Registrant|Creation Date|Created:|Domain Name:|Registry Domain ID)`)
-	if registeredPattern.MatchString(whoisOutput) {
-		result.Available = false
-		result.ExpiryDate = extractExpiryDate(whoisOutput)
-	} else {
-		// If no clear indicators either way, assume available
-		result.Available = true
+		// Check for indicators that domain is registered
+		if match := firstMatch(p.registered, whoisOutput); match != "" {
+			return false, match
+		}
 	}
 
-	return result
+	// If no clear indicators either way, assume available
+	return true, ""
 }
 
 // extractExpiryDate extracts the expiry date from whois output
@@ -76,47 +171,46 @@ func extractExpiryDate(whoisOutput string) string {
 	return ""
 }
 
-// CheckDomains checks multiple domains concurrently with a worker pool
-func CheckDomains(ctx context.Context, domains []string, concurrency int, resultChan chan<- Result) {
-	var wg sync.WaitGroup
-	semaphore := make(chan struct{}, concurrency)
-
-	for _, domain := range domains {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-		}
+// CheckDomains checks multiple domains concurrently with a worker pool of
+// up to concurrency workers, adapted to the errors seen (see NewRunThrottle)
+func CheckDomains(ctx context.Context, backend Backend, domains []string, concurrency int, resultChan chan<- Result) {
+	CheckDomainsThrottled(ctx, backend, domains, NewRunThrottle(concurrency), resultChan)
+}
 
-		wg.Add(1)
-		semaphore <- struct{}{}
-
-		go func(d string) {
-			defer wg.Done()
-			defer func() { <-semaphore }()
-
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				result := CheckDomain(d)
-				select {
-				case resultChan <- result:
-				case <-ctx.Done():
-				}
-			}
-		}(domain)
+// ObserveCheck counts a check result in the metrics
+func ObserveCheck(r Result) {
+	switch {
+	case r.Error != nil:
+		metrics.Checks.Inc("error")
+		metrics.CheckErrors.Inc(errorReason(r.Error))
+	case r.Available:
+		metrics.Checks.Inc("available")
+	case r.Restriction() != "":
+		metrics.Checks.Inc(r.Restriction())
+	default:
+		metrics.Checks.Inc("taken")
 	}
+}
 
-	wg.Wait()
+// errorReason classifies a check error for the metrics
+func errorReason(err error) string {
+	switch {
+	case errors.Is(err, ErrRateLimited) || rateLimitedPattern.MatchString(err.Error()):
+		return "rate_limited"
+	case errors.Is(err, ErrServerUnavailable):
+		return "unavailable"
+	case Transient(err):
+		return "transient"
+	}
+	return "other"
 }
 
 // CheckDomainsWithCallback checks domains and calls a callback for each result
-func CheckDomainsWithCallback(ctx context.Context, domains []string, concurrency int, callback func(Result)) {
+func CheckDomainsWithCallback(ctx context.Context, backend Backend, domains []string, concurrency int, callback func(Result)) {
 	resultChan := make(chan Result, len(domains))
 
 	go func() {
-		CheckDomains(ctx, domains, concurrency, resultChan)
+		CheckDomains(ctx, backend, domains, concurrency, resultChan)
 		close(resultChan)
 	}()
 
@@ -0,0 +1,141 @@
+// Package checkpoint records which domains a scan has checked, so an
+// interrupted scan can resume where it left off.
+package checkpoint
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// flushInterval is how often completed domains are written to disk
+const flushInterval = 2 * time.Second
+
+// Checkpoint is the on-disk list of domains a scan has completed, one per line
+type Checkpoint struct {
+	Path string
+
+	mu        sync.Mutex
+	file      *os.File
+	w         *bufio.Writer
+	lastFlush time.Time
+	done      map[string]bool
+}
+
+// Dir returns the directory checkpoints are kept in
+// (~/.config/gofindadomain/checkpoints)
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "checkpoints"), nil
+}
+
+// PathFor returns the checkpoint file of a scan, named after a hash of its
+// domain list so the same scan finds it again
+func PathFor(domains []string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.Join(domains, "\n")))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+".txt"), nil
+}
+
+// Open opens the checkpoint at path. With resume it keeps and loads the
+// domains already completed; otherwise it starts an empty checkpoint.
+func Open(path string, resume bool) (*Checkpoint, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+
+	c := &Checkpoint{Path: path, done: make(map[string]bool), lastFlush: time.Now()}
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				c.done[line] = true
+			}
+		}
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	f, err := os.OpenFile(path, flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint: %w", err)
+	}
+	c.file = f
+	c.w = bufio.NewWriter(f)
+	return c, nil
+}
+
+// Len returns how many domains are recorded as completed
+func (c *Checkpoint) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.done)
+}
+
+// Done reports whether domain was completed in an earlier run
+func (c *Checkpoint) Done(domain string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[domain]
+}
+
+// Record marks domain as completed, writing the checkpoint to disk at most
+// every couple of seconds
+func (c *Checkpoint) Record(domain string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done[domain] = true
+	if _, err := c.w.WriteString(domain + "\n"); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if time.Since(c.lastFlush) < flushInterval {
+		return nil
+	}
+	c.lastFlush = time.Now()
+	if err := c.w.Flush(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Close writes any pending domains and closes the checkpoint file
+func (c *Checkpoint) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	flushErr := c.w.Flush()
+	if err := c.file.Close(); err != nil {
+		return fmt.Errorf("failed to close checkpoint: %w", err)
+	}
+	if flushErr != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", flushErr)
+	}
+	return nil
+}
+
+// Remove closes and deletes the checkpoint once its scan has completed
+func (c *Checkpoint) Remove() error {
+	c.Close()
+	if err := os.Remove(c.Path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func record(t *testing.T, c *Checkpoint, domains ...string) {
+	t.Helper()
+	for _, d := range domains {
+		if err := c.Record(d); err != nil {
+			t.Fatalf("Record(%q): %v", d, err)
+		}
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+func TestResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoints", "scan.txt")
+
+	c, err := Open(path, false)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	record(t, c, "a.com", "b.net", "a.com")
+
+	c, err = Open(path, true)
+	if err != nil {
+		t.Fatalf("Open with resume: %v", err)
+	}
+	if n := c.Len(); n != 2 {
+		t.Errorf("Len() = %d after resuming, want 2", n)
+	}
+	for _, d := range []string{"a.com", "b.net"} {
+		if !c.Done(d) {
+			t.Errorf("Done(%q) = false after resuming, want true", d)
+		}
+	}
+	if c.Done("c.org") {
+		t.Error("Done(c.org) = true, want false for a domain never recorded")
+	}
+	// A resumed checkpoint appends to the earlier one
+	record(t, c, "c.org")
+
+	c, err = Open(path, true)
+	if err != nil {
+		t.Fatalf("Open with resume: %v", err)
+	}
+	if n := c.Len(); n != 3 || !c.Done("a.com") || !c.Done("c.org") {
+		t.Errorf("Len() = %d after resuming twice, want 3 with a.com and c.org done", n)
+	}
+	c.Close()
+
+	// Without resume the checkpoint starts over
+	c, err = Open(path, false)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if n := c.Len(); n != 0 || c.Done("a.com") {
+		t.Errorf("Len() = %d without resume, want 0", n)
+	}
+	c.Close()
+	c, err = Open(path, true)
+	if err != nil {
+		t.Fatalf("Open with resume: %v", err)
+	}
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len() = %d resuming a checkpoint that started over, want 0", n)
+	}
+
+	if err := c.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("checkpoint still exists after Remove: %v", err)
+	}
+}
+
+func TestResumeMissing(t *testing.T) {
+	c, err := Open(filepath.Join(t.TempDir(), "scan.txt"), true)
+	if err != nil {
+		t.Fatalf("Open with resume and no checkpoint: %v", err)
+	}
+	defer c.Close()
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len() = %d, want 0", n)
+	}
+}
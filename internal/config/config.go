@@ -0,0 +1,310 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Config is the user configuration loaded from config.json
+type Config struct {
+	Hooks     Hooks     `json:"hooks"`
+	Branding  Branding  `json:"branding"`
+	Ranking   Ranking   `json:"ranking"`
+	Pricing   Pricing   `json:"pricing"`
+	Store     Store     `json:"store"`
+	Whois     Whois     `json:"whois"`
+	Notify    Notify    `json:"notify"`
+	Registrar Registrar `json:"registrar"`
+	Namecheap Namecheap `json:"namecheap"`
+	TUI       TUI       `json:"tui"`
+	// Aftermarket configures the marketplaces asked by --enrich aftermarket
+	Aftermarket Aftermarket `json:"aftermarket"`
+	// Reputation configures the lookups of --enrich reputation
+	Reputation Reputation `json:"reputation"`
+	// Trademark configures the registers searched by --enrich trademarks
+	Trademark Trademark `json:"trademark"`
+	// Backends are per-TLD backend chains in --backend syntax, keyed by TLD
+	// or suffix ("io", ".co.uk"). They take precedence over --backend.
+	Backends map[string]string `json:"backends"`
+	// Profiles are named bundles of settings selected with --profile
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// Hooks configures the per-result scripting hook
+type Hooks struct {
+	// Script is the path to a Starlark file defining on_result(result)
+	Script string `json:"script"`
+	// Notifier is the notification sink used when a hook asks to notify: a
+	// notifier plugin name, or any sink such as slack:<webhook url>
+	Notifier string `json:"notifier"`
+}
+
+// Branding replaces the banner and color scheme for white-labeled deployments
+type Branding struct {
+	// HideBanner suppresses the ASCII banner entirely
+	HideBanner bool `json:"hide_banner"`
+	// Banner is a text/template replacing the default banner
+	Banner string `json:"banner"`
+	// Banners holds per-locale banners keyed by locale ("de" or "de_DE"),
+	// taking precedence over Banner
+	Banners map[string]string `json:"banners"`
+	Colors  BrandColors       `json:"colors"`
+}
+
+// BrandColors are hex colors ("#00D4AA") overriding the default palette
+type BrandColors struct {
+	Primary   string `json:"primary"`
+	Secondary string `json:"secondary"`
+	Accent    string `json:"accent"`
+	Dim       string `json:"dim"`
+	Available string `json:"available"`
+}
+
+// Ranking weighs quality score against price:
+// composite = score_weight*score - price_weight*price
+type Ranking struct {
+	ScoreWeight float64 `json:"score_weight"`
+	PriceWeight float64 `json:"price_weight"`
+}
+
+// Pricing selects where registration prices come from
+type Pricing struct {
+	// Source is a built-in source: static (a bundled table of approximate
+	// prices), porkbun, namecheap, gandi, or plugin:<name>
+	Source string `json:"source"`
+	// Plugin is the pricing plugin used to look up prices when Source is empty
+	Plugin string `json:"plugin"`
+	// Table is a JSON price table laid over the bundled one of the static source
+	Table string       `json:"table"`
+	Gandi GandiPricing `json:"gandi"`
+}
+
+// GandiPricing holds the Gandi API credentials
+type GandiPricing struct {
+	// Token is a personal access token. The GOFINDADOMAIN_GANDI_TOKEN
+	// environment variable takes precedence.
+	Token string `json:"token"`
+}
+
+// Aftermarket selects the marketplaces asked whether a taken domain is
+// listed for sale
+type Aftermarket struct {
+	// Sources are asked in order until one lists the domain: sedo or
+	// plugin:<name>. Empty asks Sedo.
+	Sources []string `json:"sources"`
+	Sedo    Sedo     `json:"sedo"`
+}
+
+// Sedo holds the Sedo partner API credentials. The
+// GOFINDADOMAIN_SEDO_PARTNER_ID and GOFINDADOMAIN_SEDO_SIGNKEY environment
+// variables take precedence.
+type Sedo struct {
+	PartnerID string `json:"partner_id"`
+	SignKey   string `json:"sign_key"`
+}
+
+// Reputation configures the blocklists checked for available domains
+type Reputation struct {
+	// SafeBrowsingKey is a Google Safe Browsing API key; without one only
+	// the DNS blocklists are checked. The GOFINDADOMAIN_SAFE_BROWSING_KEY
+	// environment variable takes precedence.
+	SafeBrowsingKey string `json:"safe_browsing_key"`
+}
+
+// Trademark selects the trademark registers searched for available names
+type Trademark struct {
+	// Sources are searched in turn and their hits combined: uspto, euipo or
+	// plugin:<name>. Empty searches the USPTO, and EUIPO too when its
+	// credentials are set.
+	Sources []string `json:"sources"`
+	EUIPO   EUIPO    `json:"euipo"`
+}
+
+// EUIPO holds the credentials of an EUIPO API portal application. The
+// GOFINDADOMAIN_EUIPO_CLIENT_ID and GOFINDADOMAIN_EUIPO_CLIENT_SECRET
+// environment variables take precedence.
+type EUIPO struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// Store selects where the shortlist and watchlist are kept
+type Store struct {
+	// URL points at a shared gofindadomain serve instance; empty keeps the
+	// lists in local files
+	URL string `json:"url"`
+	// Token authenticates against the shared instance. The
+	// GOFINDADOMAIN_STORE_TOKEN environment variable takes precedence.
+	Token string `json:"token"`
+}
+
+// Whois tunes how whois responses are queried and classified
+type Whois struct {
+	// Patterns is a JSON file of per-TLD availability patterns laid over
+	// the embedded ones
+	Patterns string `json:"patterns"`
+	// RateLimits overrides the queries per second sent to individual
+	// servers, keyed by host name
+	RateLimits map[string]float64 `json:"rate_limits"`
+	// Servers overrides the whois server queried for a TLD or suffix
+	// (without the dot), such as a registrar's server or an internal
+	// mirror, given as host or host:port
+	Servers map[string]string `json:"servers"`
+	// Proxy is the SOCKS5 or HTTP proxy whois and RDAP queries go through,
+	// unless --proxy is given
+	Proxy string `json:"proxy"`
+}
+
+// Notify configures where alerts about available domains are sent
+type Notify struct {
+	// Sinks are notification targets in the --notify syntax, such as
+	// "slack:<webhook url>" or "email:<address>"
+	Sinks []string `json:"sinks"`
+	SMTP  SMTP     `json:"smtp"`
+}
+
+// SMTP is the mail server email notifications are sent through
+type SMTP struct {
+	Host string `json:"host"`
+	// Port defaults to 587
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	// Password authenticates with Username. The GOFINDADOMAIN_SMTP_PASSWORD
+	// environment variable takes precedence.
+	Password string `json:"password"`
+	// From defaults to Username
+	From string `json:"from"`
+}
+
+// Registrar selects where the TUI sends available domains to register
+type Registrar struct {
+	// URL is a preset name (namecheap, porkbun, cloudflare, dynadot,
+	// godaddy, gandi) or a search URL in which {domain} is replaced
+	URL string `json:"url"`
+}
+
+// Namecheap holds the Namecheap API credentials used by the namecheap
+// backend, price source and the register command. ClientIP must be
+// whitelisted for the key.
+type Namecheap struct {
+	APIUser string `json:"api_user"`
+	// APIKey authenticates APIUser. The GOFINDADOMAIN_NAMECHEAP_API_KEY
+	// environment variable takes precedence.
+	APIKey string `json:"api_key"`
+	// Username defaults to APIUser
+	Username string `json:"username"`
+	ClientIP string `json:"client_ip"`
+	// Sandbox sends every call to the Namecheap sandbox, where registrations
+	// are not charged
+	Sandbox bool `json:"sandbox"`
+	// Contact is the registrant, technical, admin and billing contact of
+	// registered domains
+	Contact Contact `json:"contact"`
+}
+
+// Contact is a domain contact as registries require it
+type Contact struct {
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Organization string `json:"organization"`
+	Address1     string `json:"address1"`
+	Address2     string `json:"address2"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	PostalCode   string `json:"postal_code"`
+	// Country is the two-letter ISO code
+	Country string `json:"country"`
+	// Phone is in the form +1.5555555555
+	Phone string `json:"phone"`
+	Email string `json:"email"`
+}
+
+// TUI configures the interactive terminal UI
+type TUI struct {
+	// Theme is the palette: default, light, monochrome or high-contrast
+	Theme string `json:"theme"`
+}
+
+// Profile bundles the TLDs, concurrency and filters of a workflow. Flags
+// given on the command line win over it.
+type Profile struct {
+	// TLDs are checked when neither -e nor -E is given; TLDFile, relative to
+	// the config file, is used instead when set
+	TLDs          []string `json:"tlds"`
+	TLDFile       string   `json:"tld_file"`
+	Concurrency   int      `json:"concurrency"`
+	RateLimit     float64  `json:"rate_limit"`
+	Backend       string   `json:"backend"`
+	NotRegistered bool     `json:"not_registered"`
+	MinScore      int      `json:"min_score"`
+	MaxPrice      float64  `json:"max_price"`
+	Sort          string   `json:"sort"`
+}
+
+// Default returns the configuration used when no config file exists
+func Default() *Config {
+	return &Config{
+		Ranking: Ranking{ScoreWeight: 1, PriceWeight: 1},
+	}
+}
+
+// Dir returns the gofindadomain config directory (~/.config/gofindadomain)
+func Dir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "gofindadomain"), nil
+}
+
+// DefaultPath returns the default config file location
+func DefaultPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+// Load reads the config file at path, or the default location when path is
+// empty. A missing default config file yields the Default config.
+func Load(path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return Default(), nil
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && !explicit {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	cfg := *Default()
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	// Resolve relative paths against the config file's directory
+	if cfg.Hooks.Script != "" && !filepath.IsAbs(cfg.Hooks.Script) {
+		cfg.Hooks.Script = filepath.Join(filepath.Dir(path), cfg.Hooks.Script)
+	}
+	if cfg.Pricing.Table != "" && !filepath.IsAbs(cfg.Pricing.Table) {
+		cfg.Pricing.Table = filepath.Join(filepath.Dir(path), cfg.Pricing.Table)
+	}
+	for name, p := range cfg.Profiles {
+		if p.TLDFile != "" && !filepath.IsAbs(p.TLDFile) {
+			p.TLDFile = filepath.Join(filepath.Dir(path), p.TLDFile)
+			cfg.Profiles[name] = p
+		}
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,141 @@
+// Package crtsh looks up the certificate transparency history of domains on
+// crt.sh, which shows whether an available domain was in use before.
+package crtsh
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+const SearchURL = "https://crt.sh/"
+
+// maxConcurrent limits the searches in flight; crt.sh is a shared free
+// service that refuses clients hammering it
+const maxConcurrent = 2
+
+var (
+	client = &http.Client{Timeout: 60 * time.Second}
+	slots  = make(chan struct{}, maxConcurrent)
+)
+
+// entry is a certificate as listed by the crt.sh JSON output. A
+// precertificate and its final certificate are listed separately with the
+// same serial number.
+type entry struct {
+	IssuerName   string `json:"issuer_name"`
+	NotBefore    string `json:"not_before"`
+	SerialNumber string `json:"serial_number"`
+}
+
+// History returns the certificates logged for domain
+func History(ctx context.Context, domain string) (checker.CertHistory, error) {
+	select {
+	case slots <- struct{}{}:
+		defer func() { <-slots }()
+	case <-ctx.Done():
+		return checker.CertHistory{}, ctx.Err()
+	}
+
+	q := url.Values{"q": {domain}, "output": {"json"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, SearchURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return checker.CertHistory{}, err
+	}
+	req.Header.Set("User-Agent", "gofindadomain")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return checker.CertHistory{}, fmt.Errorf("failed to search crt.sh: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return checker.CertHistory{}, fmt.Errorf("failed to search crt.sh: HTTP %d", resp.StatusCode)
+	}
+
+	var entries []entry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return checker.CertHistory{}, fmt.Errorf("failed to parse crt.sh results: %w", err)
+	}
+	return summarize(entries), nil
+}
+
+// summarize counts the distinct certificates and their issue dates and issuers
+func summarize(entries []entry) checker.CertHistory {
+	var h checker.CertHistory
+	seen := make(map[string]bool)
+	issuers := make(map[string]int)
+	for _, e := range entries {
+		if e.SerialNumber != "" {
+			if seen[e.SerialNumber] {
+				continue
+			}
+			seen[e.SerialNumber] = true
+		}
+		h.Count++
+		issued, _, _ := strings.Cut(e.NotBefore, "T")
+		if issued != "" && (h.FirstIssued == "" || issued < h.FirstIssued) {
+			h.FirstIssued = issued
+		}
+		if issued > h.LastIssued {
+			h.LastIssued = issued
+		}
+		if org := organization(e.IssuerName); org != "" {
+			issuers[org]++
+		}
+	}
+
+	for org := range issuers {
+		h.Issuers = append(h.Issuers, org)
+	}
+	sort.Slice(h.Issuers, func(i, j int) bool {
+		a, b := h.Issuers[i], h.Issuers[j]
+		if issuers[a] != issuers[b] {
+			return issuers[a] > issuers[b]
+		}
+		return a < b
+	})
+	return h
+}
+
+// organization returns the O= attribute of an issuer's distinguished name
+// ("C=US, O=Let's Encrypt, CN=R3"), or its CN= when it has none
+func organization(dn string) string {
+	var cn string
+	for _, attr := range strings.Split(dn, ", ") {
+		key, value, _ := strings.Cut(attr, "=")
+		switch key {
+		case "O":
+			return strings.Trim(value, `"`)
+		case "CN":
+			cn = strings.Trim(value, `"`)
+		}
+	}
+	return cn
+}
+
+// Backend wraps a checker.Backend and looks up the certificate history of
+// the domains it finds available. A failed lookup leaves the history unset
+// rather than failing the check.
+type Backend struct {
+	checker.Backend
+}
+
+// Check implements checker.Backend
+func (b Backend) Check(ctx context.Context, domain string) checker.Result {
+	r := b.Backend.Check(ctx, domain)
+	if !r.Available || r.Error != nil {
+		return r
+	}
+	if h, err := History(ctx, domain); err == nil {
+		r.Certs = &h
+	}
+	return r
+}
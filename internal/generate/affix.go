@@ -0,0 +1,98 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/score"
+)
+
+// namePrefixes and nameSuffixes are the words startups commonly wrap a
+// name in
+var (
+	namePrefixes = []string{"get", "try", "use", "go", "my", "join", "hey", "the", "meet"}
+	nameSuffixes = []string{"app", "hq", "ly", "ify", "hub", "labs", "now", "kit", "io", "base"}
+)
+
+// Affixes returns the base with common prefixes and suffixes
+// (e.g. "panda" -> "getpanda", "pandaapp", "pandahq")
+func Affixes(base string) []string {
+	out := make([]string, 0, len(namePrefixes)+len(nameSuffixes))
+	for _, p := range namePrefixes {
+		out = append(out, p+base)
+	}
+	for _, s := range nameSuffixes {
+		out = append(out, base+s)
+	}
+	return out
+}
+
+// Plurals returns the English plural of the base, or its singular when it
+// already ends in s
+func Plurals(base string) []string {
+	n := len(base)
+	if n < 2 {
+		return nil
+	}
+	var out []string
+	switch {
+	case strings.HasSuffix(base, "ies") && n > 3:
+		out = append(out, base[:n-3]+"y")
+	case strings.HasSuffix(base, "es") && n > 3 && endsInSibilant(base[:n-2]):
+		out = append(out, base[:n-2])
+	case strings.HasSuffix(base, "s") && !strings.HasSuffix(base, "ss"):
+		out = append(out, base[:n-1])
+	case endsInSibilant(base):
+		out = append(out, base+"es")
+	case base[n-1] == 'y' && !isVowel(base[n-2]):
+		out = append(out, base[:n-1]+"ies")
+	default:
+		out = append(out, base+"s")
+	}
+	return out
+}
+
+// endsInSibilant reports whether a word takes -es in the plural
+func endsInSibilant(w string) bool {
+	for _, s := range []string{"s", "x", "z", "ch", "sh"} {
+		if strings.HasSuffix(w, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Hyphenations returns the base split with a hyphen where it divides into
+// two dictionary words (e.g. "pandaswift" -> "panda-swift")
+func Hyphenations(base string) []string {
+	var out []string
+	for i := 3; i <= len(base)-3; i++ {
+		if score.IsWord(base[:i]) && score.IsWord(base[i:]) {
+			out = append(out, base[:i]+"-"+base[i:])
+		}
+	}
+	return out
+}
+
+// Doubles returns the base with one letter doubled, the last letter first
+// (e.g. "dig" -> "digg", "ddig", "diig"); letters already doubled are left
+func Doubles(base string) []string {
+	n := len(base)
+	if n == 0 {
+		return nil
+	}
+	doubled := func(i int) bool {
+		return (i > 0 && base[i-1] == base[i]) || (i < n-1 && base[i+1] == base[i])
+	}
+
+	var out []string
+	if !doubled(n - 1) {
+		out = append(out, base+base[n-1:])
+	}
+	for i := 0; i < n-1; i++ {
+		if base[i] < 'a' || base[i] > 'z' || doubled(i) {
+			continue
+		}
+		out = append(out, base[:i+1]+base[i:])
+	}
+	return out
+}
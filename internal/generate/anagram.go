@@ -0,0 +1,134 @@
+package generate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/score"
+)
+
+// maxFullPermutation is the longest word whose every permutation is
+// generated; longer words only get partial rearrangements
+const maxFullPermutation = 8
+
+// Anagrams returns rearrangements of base: every permutation for short
+// words, otherwise reordered dictionary parts, rotations, adjacent swaps and
+// single-letter moves
+func Anagrams(base string) []string {
+	if len(base) < 2 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var out []string
+	add := func(s string) {
+		if s != base && !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+
+	if len(base) <= maxFullPermutation {
+		letters := []byte(base)
+		sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+		for {
+			add(string(letters))
+			if !nextPermutation(letters) {
+				break
+			}
+		}
+		return out
+	}
+
+	for _, s := range reorderedWords(base) {
+		add(s)
+	}
+	for i := 1; i < len(base); i++ {
+		add(base[i:] + base[:i])
+	}
+	for i := 0; i+1 < len(base); i++ {
+		b := []byte(base)
+		b[i], b[i+1] = b[i+1], b[i]
+		add(string(b))
+	}
+	for i := 0; i < len(base); i++ {
+		rest := base[:i] + base[i+1:]
+		for j := 0; j <= len(rest); j++ {
+			add(rest[:j] + string(base[i]) + rest[j:])
+		}
+	}
+	return out
+}
+
+// reorderedWords splits base into dictionary words and returns the other
+// orderings of those words ("pandaswift" -> "swiftpanda")
+func reorderedWords(base string) []string {
+	parts := splitWords(base)
+	if len(parts) < 2 || len(parts) > 4 {
+		return nil
+	}
+
+	idx := make([]byte, len(parts))
+	for i := range idx {
+		idx[i] = byte(i)
+	}
+	var out []string
+	for nextPermutation(idx) {
+		var b strings.Builder
+		for _, i := range idx {
+			b.WriteString(parts[i])
+		}
+		out = append(out, b.String())
+	}
+	return out
+}
+
+// splitWords segments s into dictionary words of three or more letters,
+// or returns nil when it cannot be fully segmented
+func splitWords(s string) []string {
+	n := len(s)
+	// prev[i] is the start of the last word ending at i, or -1 if unreachable
+	prev := make([]int, n+1)
+	for i := range prev {
+		prev[i] = -1
+	}
+	prev[0] = 0
+	for i := 3; i <= n; i++ {
+		for j := 0; j <= i-3; j++ {
+			if prev[j] >= 0 && score.IsWord(s[j:i]) {
+				prev[i] = j
+				break
+			}
+		}
+	}
+	if prev[n] < 0 {
+		return nil
+	}
+
+	var parts []string
+	for i := n; i > 0; i = prev[i] {
+		parts = append([]string{s[prev[i]:i]}, parts...)
+	}
+	return parts
+}
+
+// nextPermutation rearranges b into the next lexicographic permutation and
+// reports false when b was the last one
+func nextPermutation(b []byte) bool {
+	i := len(b) - 2
+	for i >= 0 && b[i] >= b[i+1] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	j := len(b) - 1
+	for b[j] <= b[i] {
+		j--
+	}
+	b[i], b[j] = b[j], b[i]
+	for l, r := i+1, len(b)-1; l < r; l, r = l+1, r-1 {
+		b[l], b[r] = b[r], b[l]
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package generate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/score"
+)
+
+// Combine joins every word of first with every word of second, once per
+// joiner ("" for plain concatenation, "-" for hyphenated pairs)
+func Combine(first, second, joiners []string) []string {
+	if len(joiners) == 0 {
+		joiners = []string{""}
+	}
+
+	seen := make(map[string]bool)
+	var out []string
+	for _, a := range first {
+		for _, b := range second {
+			for _, j := range joiners {
+				label := a + j + b
+				if !seen[label] {
+					seen[label] = true
+					out = append(out, label)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// FilterLabels keeps the labels within the filter's length bounds that are
+// pronounceable and score well enough. Hyphens are ignored when rating
+// pronounceability, so swift-panda reads like swiftpanda.
+func FilterLabels(labels []string, filter Filter) []string {
+	var out []string
+	for _, label := range labels {
+		if !filter.fitsLength(label) {
+			continue
+		}
+		if filter.MinPronounce > 0 && score.Pronounceability(strings.ReplaceAll(label, "-", "")) < filter.MinPronounce {
+			continue
+		}
+		if filter.MinScore > 0 && score.Score(label) < filter.MinScore {
+			continue
+		}
+		out = append(out, label)
+	}
+	return out
+}
+
+// LoadWords reads a wordlist with one word per line. Blank lines and lines
+// starting with # are skipped; words are lowercased and deduplicated.
+func LoadWords(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open wordlist: %w", err)
+	}
+	defer file.Close()
+
+	seen := make(map[string]bool)
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		words = append(words, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read wordlist: %w", err)
+	}
+
+	return words, nil
+}
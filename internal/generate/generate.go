@@ -0,0 +1,157 @@
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/score"
+)
+
+// Strategy produces keyword variants of a base word
+type Strategy func(base string) []string
+
+var strategies = map[string]Strategy{
+	"anagram":    Anagrams,
+	"vowel-drop": VowelDrops,
+	"leet":       Leet,
+	"affix":      Affixes,
+	"plural":     Plurals,
+	"hyphen":     Hyphenations,
+	"double":     Doubles,
+}
+
+// maxPerStrategy caps how many candidates a single strategy contributes, so
+// prolific strategies cannot crowd out the others
+const maxPerStrategy = 50
+
+// Candidate is a generated keyword rated by how natural it reads
+type Candidate struct {
+	Label            string
+	Strategy         string
+	Pronounceability float64
+	Dictionary       float64
+	// Score is the label's quality score (see score.Score)
+	Score int
+}
+
+// Rating combines pronounceability and dictionary-word content (0-2)
+func (c Candidate) Rating() float64 {
+	return c.Pronounceability + c.Dictionary
+}
+
+// Strategies returns the names of the available strategies
+func Strategies() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Filter drops weak candidates
+type Filter struct {
+	// MinPronounce is the minimum pronounceability (0-1)
+	MinPronounce float64
+	// MinScore is the minimum label quality score (0-100)
+	MinScore int
+	// MinLength and MaxLength bound the label length; 0 leaves it unbounded
+	MinLength, MaxLength int
+}
+
+// fitsLength reports whether label is within the length bounds
+func (f Filter) fitsLength(label string) bool {
+	return len(label) >= f.MinLength && (f.MaxLength <= 0 || len(label) <= f.MaxLength)
+}
+
+// Labels are the variants one strategy produced
+type Labels struct {
+	Strategy string
+	Labels   []string
+}
+
+// Generate runs the named strategies on base ("all" runs every one) and
+// returns unique candidates other than base itself, best rated first (see
+// Rank).
+func Generate(base string, names []string, filter Filter, limit int) ([]Candidate, error) {
+	sets, err := Variants(base, names)
+	if err != nil {
+		return nil, err
+	}
+	return Rank(base, sets, filter, limit), nil
+}
+
+// Variants runs the named strategies on base ("all" runs every one)
+func Variants(base string, names []string) ([]Labels, error) {
+	base = strings.ToLower(strings.TrimSpace(base))
+	if slices.Contains(names, "all") {
+		names = Strategies()
+	}
+	sets := make([]Labels, 0, len(names))
+	for _, name := range names {
+		strategy, ok := strategies[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown strategy %q (available: %s)", name, strings.Join(Strategies(), ", "))
+		}
+		sets = append(sets, Labels{Strategy: name, Labels: strategy(base)})
+	}
+	return sets, nil
+}
+
+// Rank rates the variants of base and returns unique candidates other than
+// base itself, best rated first. Each strategy contributes at most
+// maxPerStrategy candidates that pass the filter; limit <= 0 returns all of
+// them.
+func Rank(base string, sets []Labels, filter Filter, limit int) []Candidate {
+	base = strings.ToLower(strings.TrimSpace(base))
+	seen := map[string]bool{base: true}
+	var out []Candidate
+	for _, set := range sets {
+		name := set.Strategy
+		var found []Candidate
+		for _, label := range set.Labels {
+			if seen[label] {
+				continue
+			}
+			seen[label] = true
+			if !filter.fitsLength(label) {
+				continue
+			}
+
+			c := Candidate{
+				Label:            label,
+				Strategy:         name,
+				Pronounceability: score.Pronounceability(label),
+				Dictionary:       score.DictionaryCoverage(label),
+				Score:            score.Score(label),
+			}
+			if c.Pronounceability < filter.MinPronounce || c.Score < filter.MinScore {
+				continue
+			}
+			found = append(found, c)
+		}
+
+		sortCandidates(found)
+		if len(found) > maxPerStrategy {
+			found = found[:maxPerStrategy]
+		}
+		out = append(out, found...)
+	}
+
+	sortCandidates(out)
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+func sortCandidates(out []Candidate) {
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Rating() != out[j].Rating() {
+			return out[i].Rating() > out[j].Rating()
+		}
+		return out[i].Label < out[j].Label
+	})
+}
@@ -0,0 +1,60 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatamuseURL is the Datamuse words API, free and without a key
+const DatamuseURL = "https://api.datamuse.com/words"
+
+// Related is the strategy name of candidates from RelatedWords
+const Related = "related"
+
+var client = &http.Client{Timeout: 15 * time.Second}
+
+// RelatedWords asks Datamuse for up to max words meaning something like word
+// ("ship" -> "boat", "vessel", "cargo"). Phrases are joined into one label
+// and anything but letters and digits is dropped.
+func RelatedWords(ctx context.Context, word string, max int) ([]string, error) {
+	q := url.Values{"ml": {word}, "max": {strconv.Itoa(max)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, DatamuseURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query Datamuse: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query Datamuse: %s", resp.Status)
+	}
+
+	var words []struct {
+		Word string `json:"word"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&words); err != nil {
+		return nil, fmt.Errorf("failed to parse Datamuse response: %w", err)
+	}
+
+	var out []string
+	for _, w := range words {
+		label := strings.Map(func(r rune) rune {
+			if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+				return r
+			}
+			return -1
+		}, strings.ToLower(w.Word))
+		if label != "" {
+			out = append(out, label)
+		}
+	}
+	return out, nil
+}
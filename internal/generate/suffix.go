@@ -0,0 +1,82 @@
+package generate
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/score"
+)
+
+// suffixes are English word endings that read naturally when a TLD supplies them
+var suffixes = map[string]bool{
+	"able": true, "al": true, "ally": true, "er": true, "ers": true, "es": true,
+	"ed": true, "ery": true, "ful": true, "ify": true, "ing": true, "ion": true,
+	"ish": true, "ism": true, "ist": true, "ity": true, "ive": true, "ize": true,
+	"less": true, "ly": true, "ment": true, "ness": true, "ous": true, "s": true,
+	"y": true, "io": true, "ia": true, "ie": true, "ai": true, "st": true,
+}
+
+// Suggestion is a suggested domain for a keyword
+type Suggestion struct {
+	Domain string
+	// Reads is how the domain reads when the dot is ignored
+	Reads string
+	// Kind is "extends" when the TLD adds a suffix to the keyword,
+	// "completes" when the keyword already ends with the TLD, or "theme"
+	// for a compound with a wordpack word
+	Kind string
+	// Word is true when Reads is a dictionary word
+	Word bool
+	// Score is the quality score of Domain
+	Score int
+}
+
+// SuffixSuggestions finds TLDs that read as a natural ending of keyword:
+// "host" -> host.ing, "bit" -> bit.ly, "flicker" -> flick.er
+func SuffixSuggestions(keyword string, tlds []string) []Suggestion {
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+
+	var out []Suggestion
+	for _, t := range tlds {
+		t = strings.TrimPrefix(strings.ToLower(t), ".")
+		if t == "" || strings.Contains(t, ".") {
+			continue
+		}
+
+		if label, ok := strings.CutSuffix(keyword, t); ok && len(label) >= 2 {
+			out = append(out, Suggestion{
+				Domain: label + "." + t,
+				Reads:  keyword,
+				Kind:   "completes",
+				Word:   score.IsWord(keyword),
+				Score:  score.Score(label + "." + t),
+			})
+		}
+
+		reads := keyword + t
+		word := score.IsWord(reads)
+		if suffixes[t] || word {
+			out = append(out, Suggestion{
+				Domain: keyword + "." + t,
+				Reads:  reads,
+				Kind:   "extends",
+				Word:   word,
+				Score:  score.Score(keyword + "." + t),
+			})
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Word != out[j].Word {
+			return out[i].Word
+		}
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind == "completes"
+		}
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Domain < out[j].Domain
+	})
+	return out
+}
@@ -0,0 +1,89 @@
+package generate
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/score"
+)
+
+// themeFiles holds the embedded wordpacks, one word per line
+//
+//go:embed themes/*.txt
+var themeFiles embed.FS
+
+// Themes returns the names of the embedded wordpacks
+func Themes() []string {
+	entries, _ := themeFiles.ReadDir("themes")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".txt"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ThemeWords returns the words of an embedded wordpack
+func ThemeWords(theme string) ([]string, error) {
+	data, err := themeFiles.ReadFile(path.Join("themes", theme+".txt"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown theme %q (available: %s)", theme, strings.Join(Themes(), ", "))
+	}
+
+	var words []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line != "" && !strings.HasPrefix(line, "#") {
+			words = append(words, line)
+		}
+	}
+	return words, nil
+}
+
+// ThemedSuggestions compounds keyword with every word of the given themes,
+// as both prefix and suffix, in tld. Results are ordered by quality score.
+func ThemedSuggestions(keyword string, themes []string, tld string) ([]Suggestion, error) {
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	if !strings.HasPrefix(tld, ".") {
+		tld = "." + tld
+	}
+
+	var words []string
+	for _, theme := range themes {
+		w, err := ThemeWords(theme)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, w...)
+	}
+
+	labels := Combine([]string{keyword}, words, nil)
+	labels = append(labels, Combine(words, []string{keyword}, nil)...)
+
+	seen := make(map[string]bool)
+	var out []Suggestion
+	for _, label := range labels {
+		if seen[label] || label == keyword {
+			continue
+		}
+		seen[label] = true
+		out = append(out, Suggestion{
+			Domain: label + tld,
+			Reads:  label,
+			Kind:   "theme",
+			Word:   score.IsWord(label),
+			Score:  score.Score(label + tld),
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].Domain < out[j].Domain
+	})
+	return out, nil
+}
@@ -0,0 +1,196 @@
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// typoKinds generate the misspellings and lookalikes of a brand name that
+// typosquatters and phishers register
+var typoKinds = map[string]Strategy{
+	"omission":      Omissions,
+	"repetition":    Doubles,
+	"transposition": Transpositions,
+	"replacement":   Replacements,
+	"insertion":     Insertions,
+	"homoglyph":     Homoglyphs,
+	"hyphenation":   Hyphens,
+}
+
+// keyboardRows is the QWERTY layout used to find neighbouring keys
+var keyboardRows = []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// adjacentKeys maps each key to the keys around it
+var adjacentKeys = func() map[byte]string {
+	m := make(map[byte]string)
+	for r, row := range keyboardRows {
+		for c := 0; c < len(row); c++ {
+			var near []byte
+			for dr := -1; dr <= 1; dr++ {
+				if r+dr < 0 || r+dr >= len(keyboardRows) {
+					continue
+				}
+				other := keyboardRows[r+dr]
+				for dc := -1; dc <= 1; dc++ {
+					if (dr != 0 || dc != 0) && c+dc >= 0 && c+dc < len(other) {
+						near = append(near, other[c+dc])
+					}
+				}
+			}
+			m[row[c]] = string(near)
+		}
+	}
+	return m
+}()
+
+// lookalikes are the character sequences that read alike in most fonts
+var lookalikes = [][2]string{
+	{"m", "rn"}, {"rn", "m"}, {"w", "vv"}, {"vv", "w"}, {"d", "cl"}, {"cl", "d"},
+	{"o", "0"}, {"0", "o"}, {"l", "1"}, {"1", "l"}, {"l", "i"}, {"i", "l"}, {"i", "1"},
+}
+
+// cyrillic maps Latin letters to the Cyrillic letters drawn the same,
+// which IDN homograph attacks substitute
+var cyrillic = map[rune]rune{
+	'a': 'а', 'c': 'с', 'e': 'е', 'i': 'і', 'j': 'ј', 'o': 'о',
+	'p': 'р', 's': 'ѕ', 'x': 'х', 'y': 'у',
+}
+
+// TypoKinds returns the names of the typo generators
+func TypoKinds() []string {
+	names := make([]string, 0, len(typoKinds))
+	for name := range typoKinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Typos runs the named typo generators on label ("all" runs every one).
+// Each set leaves out label itself and the variants earlier sets produced.
+func Typos(label string, names []string) ([]Labels, error) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if slices.Contains(names, "all") {
+		names = TypoKinds()
+	}
+	seen := map[string]bool{label: true}
+	sets := make([]Labels, 0, len(names))
+	for _, name := range names {
+		kind, ok := typoKinds[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown typo kind %q (available: %s)", name, strings.Join(TypoKinds(), ", "))
+		}
+		var labels []string
+		for _, v := range kind(label) {
+			if !seen[v] {
+				seen[v] = true
+				labels = append(labels, v)
+			}
+		}
+		sets = append(sets, Labels{Strategy: name, Labels: labels})
+	}
+	return sets, nil
+}
+
+// Omissions returns the label with one character left out
+// (e.g. "google" -> "oogle", "gogle", "goole")
+func Omissions(label string) []string {
+	if len(label) < 2 {
+		return nil
+	}
+	var out []string
+	for i := range len(label) {
+		out = append(out, label[:i]+label[i+1:])
+	}
+	return out
+}
+
+// Transpositions returns the label with two neighbouring characters swapped
+// (e.g. "google" -> "ogogle", "gogole")
+func Transpositions(label string) []string {
+	var out []string
+	for i := 0; i < len(label)-1; i++ {
+		if label[i] == label[i+1] {
+			continue
+		}
+		b := []byte(label)
+		b[i], b[i+1] = b[i+1], b[i]
+		out = append(out, string(b))
+	}
+	return out
+}
+
+// Replacements returns the label with one character replaced by a key next
+// to it on a QWERTY keyboard (e.g. "google" -> "foogle", "giogle")
+func Replacements(label string) []string {
+	var out []string
+	for i := range len(label) {
+		for _, k := range []byte(adjacentKeys[label[i]]) {
+			out = append(out, label[:i]+string(k)+label[i+1:])
+		}
+	}
+	return out
+}
+
+// Insertions returns the label with a neighbouring key pressed along with
+// one of its characters (e.g. "google" -> "gfoogle", "gooigle")
+func Insertions(label string) []string {
+	var out []string
+	for i := range len(label) {
+		for _, k := range []byte(adjacentKeys[label[i]]) {
+			out = append(out, label[:i]+string(k)+label[i:], label[:i+1]+string(k)+label[i+1:])
+		}
+	}
+	return out
+}
+
+// Homoglyphs returns the label with one lookalike substituted ("rn" for
+// "m", "0" for "o") and the IDN forms with one Latin letter, and then all
+// of them, swapped for its Cyrillic double (e.g. "apple" -> "аpple")
+func Homoglyphs(label string) []string {
+	var out []string
+	for _, l := range lookalikes {
+		for i := 0; ; {
+			j := strings.Index(label[i:], l[0])
+			if j < 0 {
+				break
+			}
+			i += j
+			out = append(out, label[:i]+l[1]+label[i+len(l[0]):])
+			i++
+		}
+	}
+
+	runes := []rune(label)
+	all := slices.Clone(runes)
+	swapped := 0
+	for i, r := range runes {
+		c, ok := cyrillic[r]
+		if !ok {
+			continue
+		}
+		v := slices.Clone(runes)
+		v[i] = c
+		out = append(out, string(v))
+		all[i] = c
+		swapped++
+	}
+	if swapped > 1 {
+		out = append(out, string(all))
+	}
+	return out
+}
+
+// Hyphens returns the label with a hyphen between two of its characters
+// (e.g. "google" -> "g-oogle", "go-ogle")
+func Hyphens(label string) []string {
+	var out []string
+	for i := 1; i < len(label); i++ {
+		if label[i-1] != '-' && label[i] != '-' {
+			out = append(out, label[:i]+"-"+label[i:])
+		}
+	}
+	return out
+}
@@ -0,0 +1,75 @@
+package generate
+
+import "strings"
+
+var leetMap = map[byte]byte{
+	'a': '4',
+	'e': '3',
+	'i': '1',
+	'o': '0',
+	's': '5',
+	't': '7',
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// VowelDrops returns flickr-style variants: the last vowel dropped, each
+// single non-initial vowel dropped, and all non-initial vowels dropped
+func VowelDrops(base string) []string {
+	var positions []int
+	for i := 1; i < len(base); i++ {
+		if isVowel(base[i]) {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	// Dropping the last vowel ("flicker" -> "flickr") reads best, so it comes first
+	last := positions[len(positions)-1]
+	out := []string{base[:last] + base[last+1:]}
+	for _, i := range positions[:len(positions)-1] {
+		out = append(out, base[:i]+base[i+1:])
+	}
+
+	if len(positions) > 1 {
+		var b strings.Builder
+		b.WriteByte(base[0])
+		for i := 1; i < len(base); i++ {
+			if !isVowel(base[i]) {
+				b.WriteByte(base[i])
+			}
+		}
+		if b.Len() >= 2 {
+			out = append(out, b.String())
+		}
+	}
+	return out
+}
+
+// Leet returns restrained leetspeak variants with at most two substituted
+// characters (e.g. "elite" -> "3lite", "el1te", "3l1te")
+func Leet(base string) []string {
+	var positions []int
+	for i := 0; i < len(base); i++ {
+		if _, ok := leetMap[base[i]]; ok {
+			positions = append(positions, i)
+		}
+	}
+
+	var out []string
+	for a := 0; a < len(positions); a++ {
+		b := []byte(base)
+		b[positions[a]] = leetMap[b[positions[a]]]
+		out = append(out, string(b))
+		for c := a + 1; c < len(positions); c++ {
+			b2 := []byte(string(b))
+			b2[positions[c]] = leetMap[b2[positions[c]]]
+			out = append(out, string(b2))
+		}
+	}
+	return out
+}
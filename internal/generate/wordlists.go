@@ -0,0 +1,59 @@
+package generate
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// BuiltinPrefix marks a wordlist argument naming an embedded list rather
+// than a file (e.g. "builtin:nouns")
+const BuiltinPrefix = "builtin:"
+
+// wordlistFiles holds the embedded wordlists, one word per line
+//
+//go:embed wordlists/*.txt
+var wordlistFiles embed.FS
+
+// Wordlists returns the names of the embedded wordlists
+func Wordlists() []string {
+	entries, _ := wordlistFiles.ReadDir("wordlists")
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".txt"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadList reads a wordlist file, or the embedded list a "builtin:" name
+// refers to
+func LoadList(spec string) ([]string, error) {
+	name, ok := strings.CutPrefix(spec, BuiltinPrefix)
+	if !ok {
+		return LoadWords(spec)
+	}
+	data, err := wordlistFiles.ReadFile(path.Join("wordlists", name+".txt"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown wordlist %q (available: %s%s)", spec, BuiltinPrefix, strings.Join(Wordlists(), ", "+BuiltinPrefix))
+	}
+	return parseWords(string(data)), nil
+}
+
+// parseWords splits a wordlist into lowercased, deduplicated words, skipping
+// blank lines and # comments
+func parseWords(data string) []string {
+	seen := make(map[string]bool)
+	var words []string
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+		seen[line] = true
+		words = append(words, line)
+	}
+	return words
+}
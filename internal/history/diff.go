@@ -0,0 +1,132 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Kinds of change between two runs
+const (
+	NewlyAvailable = "newly available"
+	NewlyTaken     = "newly taken"
+	NewlyPremium   = "newly premium"
+	NewlyReserved  = "newly reserved"
+	ExpiryChanged  = "expiry changed"
+)
+
+// Change is a domain whose state differs between two runs
+type Change struct {
+	Domain string
+	Kind   string
+	// OldExpiry and NewExpiry are the expiry dates in the two runs
+	OldExpiry string
+	NewExpiry string
+}
+
+// Diff lists the domains checked in both old and new whose state (available,
+// taken, premium or reserved) or expiry date changed, by domain. Failed checks are not compared, and a
+// domain checked more than once in a run counts with its last result.
+func Diff(old, new []Record) []Change {
+	before := make(map[string]Record)
+	for _, r := range old {
+		if r.Error == "" {
+			before[r.Domain] = r
+		}
+	}
+	after := make(map[string]Record)
+	for _, r := range new {
+		if r.Error == "" {
+			after[r.Domain] = r
+		}
+	}
+
+	var changes []Change
+	for domain, cur := range after {
+		prev, ok := before[domain]
+		if !ok {
+			continue
+		}
+		c := Change{Domain: domain, OldExpiry: prev.ExpiryDate, NewExpiry: cur.ExpiryDate}
+		switch state := stateOf(cur); {
+		case state != stateOf(prev):
+			c.Kind = state
+		case state == NewlyTaken && prev.ExpiryDate != "" && cur.ExpiryDate != "" && prev.ExpiryDate != cur.ExpiryDate:
+			c.Kind = ExpiryChanged
+		default:
+			continue
+		}
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Domain < changes[j].Domain })
+	return changes
+}
+
+// stateOf returns the kind of change a domain is reported with on reaching
+// the state of r
+func stateOf(r Record) string {
+	switch {
+	case r.Available:
+		return NewlyAvailable
+	case r.State == "premium":
+		return NewlyPremium
+	case r.State == "reserved":
+		return NewlyReserved
+	}
+	return NewlyTaken
+}
+
+// exportedResult holds the fields of a --json result line that a diff needs
+type exportedResult struct {
+	Domain     string `json:"domain"`
+	Available  bool   `json:"available"`
+	Premium    bool   `json:"premium"`
+	Reserved   bool   `json:"reserved"`
+	Error      string `json:"error"`
+	ExpiryDate string `json:"expiry_date"`
+	Keyword    string `json:"keyword"`
+	Registrar  string `json:"registrar"`
+}
+
+// ReadJSON reads results exported as JSON lines, by --json on a check or on
+// history
+func ReadJSON(r io.Reader) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var res exportedResult
+		if err := json.Unmarshal([]byte(text), &res); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if res.Domain == "" {
+			return nil, fmt.Errorf("line %d: no domain", line)
+		}
+		rec := Record{
+			Domain:     res.Domain,
+			Keyword:    res.Keyword,
+			Available:  res.Available,
+			Error:      res.Error,
+			ExpiryDate: res.ExpiryDate,
+			Registrar:  res.Registrar,
+		}
+		switch {
+		case res.Reserved:
+			rec.State = "reserved"
+		case res.Premium:
+			rec.State = "premium"
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,39 @@
+package history
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := []Record{
+		{Domain: "a.com", Available: true},
+		{Domain: "b.com"},
+		{Domain: "c.com", Available: true},
+		{Domain: "d.com", State: "premium"},
+		{Domain: "e.com", ExpiryDate: "2026-01-01"},
+		{Domain: "f.com", State: "premium"},
+		{Domain: "g.com", Error: "timeout"},
+		{Domain: "h.com"},
+	}
+	cur := []Record{
+		{Domain: "a.com"},
+		{Domain: "b.com", Available: true},
+		{Domain: "c.com", State: "premium"},
+		{Domain: "d.com", State: "reserved"},
+		{Domain: "e.com", ExpiryDate: "2027-01-01"},
+		{Domain: "f.com", State: "premium"},
+		{Domain: "g.com", Available: true},
+		{Domain: "i.com", Available: true},
+	}
+	want := []Change{
+		{Domain: "a.com", Kind: NewlyTaken},
+		{Domain: "b.com", Kind: NewlyAvailable},
+		{Domain: "c.com", Kind: NewlyPremium},
+		{Domain: "d.com", Kind: NewlyReserved},
+		{Domain: "e.com", Kind: ExpiryChanged, OldExpiry: "2026-01-01", NewExpiry: "2027-01-01"},
+	}
+	if got := Diff(old, cur); !slices.Equal(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,301 @@
+// Package history keeps every run's check results in a local SQLite
+// database, so earlier results can be reviewed and compared.
+package history
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS runs (
+	id       INTEGER PRIMARY KEY,
+	started  TEXT NOT NULL,
+	finished TEXT,
+	command  TEXT NOT NULL,
+	keyword  TEXT NOT NULL,
+	backend  TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS results (
+	run_id       INTEGER NOT NULL REFERENCES runs(id) ON DELETE CASCADE,
+	checked      TEXT NOT NULL,
+	domain       TEXT NOT NULL,
+	keyword      TEXT NOT NULL,
+	available    INTEGER NOT NULL,
+	state        TEXT NOT NULL DEFAULT '',
+	error        TEXT NOT NULL,
+	expiry_date  TEXT NOT NULL,
+	created_date TEXT NOT NULL,
+	registrar    TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS results_checked ON results(checked);
+CREATE INDEX IF NOT EXISTS results_domain ON results(domain);
+CREATE INDEX IF NOT EXISTS results_run ON results(run_id);
+`
+
+// timeFormat is used for stored times; fixed-width UTC so they sort as text
+const timeFormat = "2006-01-02T15:04:05Z"
+
+// DB is the result history database
+type DB struct {
+	db *sql.DB
+}
+
+// Run is one recorded check run
+type Run struct {
+	ID       int64
+	Started  time.Time
+	Finished time.Time
+	// Command is the command line that started the run
+	Command string
+	Keyword string
+	Backend string
+	// Checked and Available count the run's results
+	Checked   int
+	Available int
+}
+
+// Record is one stored check result
+type Record struct {
+	RunID     int64
+	Checked   time.Time
+	Domain    string
+	Keyword   string
+	Available bool
+	// State is premium or reserved for a name the registry restricts, and
+	// empty otherwise
+	State       string
+	Error       string
+	ExpiryDate  string
+	CreatedDate string
+	Registrar   string
+}
+
+// Filter selects records; zero fields match everything
+type Filter struct {
+	// Keyword matches records checked for the keyword and domains whose
+	// label is the keyword
+	Keyword       string
+	Since         time.Time
+	AvailableOnly bool
+	RunID         int64
+	// Limit keeps only the newest records
+	Limit int
+}
+
+// Path returns where the history is kept (~/.config/gofindadomain/history.db)
+func Path() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.db"), nil
+}
+
+// Open opens the history database, creating it on first use
+func Open() (*DB, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open history %s: %w", path, err)
+	}
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open history %s: %w", path, err)
+	}
+	return &DB{db: db}, nil
+}
+
+// migrate brings a history created by an earlier version up to the schema
+func migrate(db *sql.DB) error {
+	// The state column came with premium and reserved results; earlier
+	// results keep it empty
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('results') WHERE name = 'state'`).Scan(&n); err != nil {
+		return err
+	}
+	if n == 0 {
+		if _, err := db.Exec(`ALTER TABLE results ADD COLUMN state TEXT NOT NULL DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the database
+func (h *DB) Close() error {
+	return h.db.Close()
+}
+
+// StartRun records the start of a run and returns its ID
+func (h *DB) StartRun(command, keyword, backend string) (int64, error) {
+	res, err := h.db.Exec(`INSERT INTO runs (started, command, keyword, backend) VALUES (?, ?, ?, ?)`,
+		time.Now().UTC().Format(timeFormat), command, keyword, backend)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record run: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// FinishRun records the end of a run
+func (h *DB) FinishRun(id int64) error {
+	if _, err := h.db.Exec(`UPDATE runs SET finished = ? WHERE id = ?`, time.Now().UTC().Format(timeFormat), id); err != nil {
+		return fmt.Errorf("failed to record run: %w", err)
+	}
+	return nil
+}
+
+// Add stores a check result of a run
+func (h *DB) Add(runID int64, keyword string, r checker.Result) error {
+	errText, state := "", r.Restriction()
+	if r.Error != nil {
+		errText, state = r.Error.Error(), ""
+	}
+	_, err := h.db.Exec(`INSERT INTO results (run_id, checked, domain, keyword, available, state, error, expiry_date, created_date, registrar)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		runID, time.Now().UTC().Format(timeFormat), r.Domain, keyword, r.Available && r.Error == nil, state, errText,
+		r.ExpiryDate, r.CreatedDate, r.Registrar)
+	if err != nil {
+		return fmt.Errorf("failed to record %s: %w", r.Domain, err)
+	}
+	return nil
+}
+
+// Records returns the records matching f, oldest first
+func (h *DB) Records(f Filter) ([]Record, error) {
+	var where []string
+	var args []any
+	if f.Keyword != "" {
+		where = append(where, `(keyword = ? OR domain LIKE ? ESCAPE '\')`)
+		label := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(f.Keyword))
+		args = append(args, f.Keyword, label+".%")
+	}
+	if !f.Since.IsZero() {
+		where = append(where, `checked >= ?`)
+		args = append(args, f.Since.UTC().Format(timeFormat))
+	}
+	if f.AvailableOnly {
+		where = append(where, `available = 1`)
+	}
+	if f.RunID != 0 {
+		where = append(where, `run_id = ?`)
+		args = append(args, f.RunID)
+	}
+
+	query := `SELECT run_id, checked, domain, keyword, available, state, error, expiry_date, created_date, registrar FROM results`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, ` AND `)
+	}
+	query += ` ORDER BY checked DESC, rowid DESC`
+	if f.Limit > 0 {
+		query += fmt.Sprintf(` LIMIT %d`, f.Limit)
+	}
+
+	rows, err := h.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var rec Record
+		var checked string
+		if err := rows.Scan(&rec.RunID, &checked, &rec.Domain, &rec.Keyword, &rec.Available, &rec.State, &rec.Error,
+			&rec.ExpiryDate, &rec.CreatedDate, &rec.Registrar); err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+		rec.Checked, _ = time.Parse(timeFormat, checked)
+		records = append(records, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	// Newest were selected for the limit; return them oldest first
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+	return records, nil
+}
+
+// Dropped reports whether earlier results show domain, now registered with
+// the creation date created (2006-01-02), as registered again since: it was
+// recorded available up to that day, or with an earlier creation date
+func (h *DB) Dropped(domain, created string) (bool, error) {
+	var n int
+	err := h.db.QueryRow(`SELECT COUNT(*) FROM results WHERE domain = ? AND error = '' AND (
+			(available = 1 AND checked <= ?) OR
+			(created_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*' AND substr(created_date, 1, 10) < ?))`,
+		domain, created+"T23:59:59Z", created).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("failed to read history: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Runs returns the recorded runs started since the given time, oldest first
+func (h *DB) Runs(since time.Time) ([]Run, error) {
+	return h.runs(`r.started >= ?`, since.UTC().Format(timeFormat))
+}
+
+// Run returns the recorded run with the given ID
+func (h *DB) Run(id int64) (Run, error) {
+	runs, err := h.runs(`r.id = ?`, id)
+	if err != nil {
+		return Run{}, err
+	}
+	if len(runs) == 0 {
+		return Run{}, fmt.Errorf("no run %d in the history", id)
+	}
+	return runs[0], nil
+}
+
+// runs returns the runs matching where with their result counts
+func (h *DB) runs(where string, args ...any) ([]Run, error) {
+	rows, err := h.db.Query(`SELECT r.id, r.started, COALESCE(r.finished, ''), r.command, r.keyword, r.backend,
+			COUNT(res.domain), COALESCE(SUM(res.available), 0)
+		FROM runs r LEFT JOIN results res ON res.run_id = r.id
+		WHERE `+where+`
+		GROUP BY r.id ORDER BY r.id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []Run
+	for rows.Next() {
+		var run Run
+		var started, finished string
+		if err := rows.Scan(&run.ID, &started, &finished, &run.Command, &run.Keyword, &run.Backend,
+			&run.Checked, &run.Available); err != nil {
+			return nil, fmt.Errorf("failed to read history: %w", err)
+		}
+		run.Started, _ = time.Parse(timeFormat, started)
+		run.Finished, _ = time.Parse(timeFormat, finished)
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return runs, nil
+}
@@ -0,0 +1,131 @@
+// Package hook runs a user-supplied Starlark script against each check result.
+//
+// The script must define on_result(result), where result is a dict with the
+// keys domain, available, expiry_date, error and score. The return value decides
+// what happens to the result:
+//
+//	None or True   keep the result unchanged
+//	False          drop the result
+//	dict           keep (bool), notify (bool), note (string), score (number)
+package hook
+
+import (
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+const entryPoint = "on_result"
+
+// Script is a loaded hook script
+type Script struct {
+	path string
+	fn   starlark.Callable
+}
+
+// Outcome is what the hook decided for a single result
+type Outcome struct {
+	Keep   bool
+	Notify bool
+	Note   string
+	Score  int
+}
+
+// Load parses and executes a hook script and looks up its on_result function
+func Load(path string) (*Script, error) {
+	thread := newThread(path)
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, path, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load hook %s: %w", path, err)
+	}
+
+	fn, ok := globals[entryPoint].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("hook %s does not define %s(result)", path, entryPoint)
+	}
+
+	globals.Freeze()
+	return &Script{path: path, fn: fn}, nil
+}
+
+// Run calls on_result for a single result with its quality score. The
+// returned Outcome carries the score, possibly changed by the hook.
+func (s *Script) Run(r checker.Result, score int) (Outcome, error) {
+	out := Outcome{Keep: true, Score: score}
+
+	errValue := starlark.Value(starlark.None)
+	if r.Error != nil {
+		errValue = starlark.String(r.Error.Error())
+	}
+
+	arg := starlark.NewDict(5)
+	_ = arg.SetKey(starlark.String("domain"), starlark.String(r.Domain))
+	_ = arg.SetKey(starlark.String("available"), starlark.Bool(r.Available))
+	_ = arg.SetKey(starlark.String("expiry_date"), starlark.String(r.ExpiryDate))
+	_ = arg.SetKey(starlark.String("error"), errValue)
+	_ = arg.SetKey(starlark.String("score"), starlark.MakeInt(score))
+
+	v, err := starlark.Call(newThread(s.path), s.fn, starlark.Tuple{arg}, nil)
+	if err != nil {
+		return out, fmt.Errorf("hook %s: %w", entryPoint, err)
+	}
+
+	switch v := v.(type) {
+	case starlark.NoneType:
+	case starlark.Bool:
+		out.Keep = bool(v)
+	case *starlark.Dict:
+		if err := decodeOutcome(v, &out); err != nil {
+			return Outcome{Keep: true, Score: score}, err
+		}
+	default:
+		return out, fmt.Errorf("hook %s returned %s, want None, bool or dict", entryPoint, v.Type())
+	}
+
+	return out, nil
+}
+
+func decodeOutcome(d *starlark.Dict, out *Outcome) error {
+	for _, item := range d.Items() {
+		key, ok := starlark.AsString(item[0])
+		if !ok {
+			return fmt.Errorf("hook %s returned a dict with non-string key %s", entryPoint, item[0])
+		}
+		v := item[1]
+
+		switch key {
+		case "keep":
+			out.Keep = bool(v.Truth())
+		case "notify":
+			out.Notify = bool(v.Truth())
+		case "note":
+			note, ok := starlark.AsString(v)
+			if !ok {
+				return fmt.Errorf("hook %s: note must be a string, got %s", entryPoint, v.Type())
+			}
+			out.Note = note
+		case "score":
+			score, ok := starlark.AsFloat(v)
+			if !ok {
+				return fmt.Errorf("hook %s: score must be a number, got %s", entryPoint, v.Type())
+			}
+			out.Score = int(math.Round(score))
+		default:
+			return fmt.Errorf("hook %s returned unknown key %q", entryPoint, key)
+		}
+	}
+	return nil
+}
+
+func newThread(name string) *starlark.Thread {
+	return &starlark.Thread{
+		Name: name,
+		Print: func(_ *starlark.Thread, msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+}
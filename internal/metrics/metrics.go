@@ -0,0 +1,250 @@
+// Package metrics collects check and query statistics and serves them in the
+// Prometheus text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Query protocols
+const (
+	Whois = "whois"
+	RDAP  = "rdap"
+)
+
+var (
+	// Checks counts checked domains by result: available, taken or error
+	Checks = newCounter("gofindadomain_checks_total", "Domains checked, by result.", "result")
+
+	// CheckErrors counts failed checks by reason: rate_limited, unavailable,
+	// transient or other
+	CheckErrors = newCounter("gofindadomain_check_errors_total", "Failed checks, by reason.", "reason")
+
+	// QueryDuration is the latency of whois and RDAP queries per server
+	QueryDuration = newHistogram("gofindadomain_query_duration_seconds", "Whois and RDAP query latency, by server.",
+		[]float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 15, 30}, "protocol", "server")
+
+	// QueryErrors counts failed whois and RDAP queries per server
+	QueryErrors = newCounter("gofindadomain_query_errors_total", "Failed whois and RDAP queries, by server.", "protocol", "server")
+
+	// RateLimitWaits counts queries delayed by the per-server rate limit
+	RateLimitWaits = newCounter("gofindadomain_rate_limit_waits_total", "Queries delayed by the per-server rate limit.", "server")
+
+	// RateLimited counts queries a server refused as over its quota
+	RateLimited = newCounter("gofindadomain_rate_limited_total", "Queries refused by a server as over its quota.", "server")
+
+	// BackendChecks counts checks per backend of a chain by outcome: ok or error
+	BackendChecks = newCounter("gofindadomain_backend_checks_total", "Checks answered or failed, by backend.", "backend", "outcome")
+
+	// ConcurrencyBackoffs counts the times adaptive concurrency was halved
+	ConcurrencyBackoffs = newCounter("gofindadomain_concurrency_backoffs_total", "Times adaptive concurrency was halved after rate limiting or refused connections.")
+
+	collectors = []collector{Checks, CheckErrors, QueryDuration, QueryErrors, RateLimitWaits, RateLimited, BackendChecks, ConcurrencyBackoffs}
+)
+
+// ObserveQuery records a whois or RDAP query to server that took d
+func ObserveQuery(protocol, server string, d time.Duration, failed, rateLimited bool) {
+	QueryDuration.Observe(d.Seconds(), protocol, server)
+	if failed {
+		QueryErrors.Inc(protocol, server)
+	}
+	if rateLimited {
+		RateLimited.Inc(server)
+	}
+}
+
+// Handler serves all metrics
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		Write(w)
+	})
+}
+
+// Write writes all metrics in the text exposition format
+func Write(w io.Writer) error {
+	for _, c := range collectors {
+		if err := c.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type collector interface {
+	write(w io.Writer) error
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// series is the name, help and label names of a metric; each of its time
+// series is keyed by its label values
+type series struct {
+	name   string
+	help   string
+	labels []string
+}
+
+func (s series) key(values []string) string {
+	if len(values) != len(s.labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", s.name, len(s.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// labelValues splits a key back into its label values
+func (s series) labelValues(key string) []string {
+	if len(s.labels) == 0 {
+		return nil
+	}
+	return strings.Split(key, "\xff")
+}
+
+// labelPairs formats `a="x",b="y"` for a key, with extra pairs appended
+func (s series) labelPairs(key string, extra ...string) string {
+	var pairs []string
+	if len(s.labels) > 0 {
+		for i, v := range strings.Split(key, "\xff") {
+			pairs = append(pairs, s.labels[i]+`="`+labelEscaper.Replace(v)+`"`)
+		}
+	}
+	pairs = append(pairs, extra...)
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Counter is a set of counters partitioned by labels
+type Counter struct {
+	series
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+func newCounter(name, help string, labels ...string) *Counter {
+	return &Counter{series: series{name, help, labels}, values: make(map[string]float64)}
+}
+
+// Inc adds one to the counter with the given label values
+func (c *Counter) Inc(values ...string) {
+	key := c.key(values)
+	c.mu.Lock()
+	c.values[key]++
+	c.mu.Unlock()
+}
+
+// Each calls fn with the label values and value of every series
+func (c *Counter) Each(fn func(values []string, v float64)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range sortedKeys(c.values) {
+		fn(c.labelValues(key), c.values[key])
+	}
+}
+
+func (c *Counter) write(w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name); err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(c.values) {
+		if _, err := fmt.Fprintf(w, "%s%s %s\n", c.name, c.labelPairs(key), formatFloat(c.values[key])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Histogram is a set of histograms partitioned by labels
+type Histogram struct {
+	series
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func newHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return &Histogram{series: series{name, help, labels}, buckets: buckets, values: make(map[string]*histogram)}
+}
+
+// Observe records v in the histogram with the given label values
+func (h *Histogram) Observe(v float64, values ...string) {
+	key := h.key(values)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hist, ok := h.values[key]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = hist
+	}
+	for i, upper := range h.buckets {
+		if v <= upper {
+			hist.counts[i]++
+		}
+	}
+	hist.count++
+	hist.sum += v
+}
+
+// Each calls fn with the label values, observation count and sum of every
+// series
+func (h *Histogram) Each(fn func(values []string, count uint64, sum float64)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range sortedKeys(h.values) {
+		fn(h.labelValues(key), h.values[key].count, h.values[key].sum)
+	}
+}
+
+func (h *Histogram) write(w io.Writer) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name); err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(h.values) {
+		hist := h.values[key]
+		for i, upper := range h.buckets {
+			le := `le="` + formatFloat(upper) + `"`
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.labelPairs(key, le), hist.counts[i]); err != nil {
+				return err
+			}
+		}
+		_, err := fmt.Fprintf(w, "%s_bucket%s %d\n%s_sum%s %s\n%s_count%s %d\n",
+			h.name, h.labelPairs(key, `le="+Inf"`), hist.count,
+			h.name, h.labelPairs(key), formatFloat(hist.sum),
+			h.name, h.labelPairs(key), hist.count)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,66 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// Availability is a domain's entry in a namecheap.domains.check response
+type Availability struct {
+	Domain      string `xml:"Domain,attr"`
+	Available   bool   `xml:"Available,attr"`
+	ErrorNo     string `xml:"ErrorNo,attr"`
+	Description string `xml:"Description,attr"`
+	Premium     bool   `xml:"IsPremiumName,attr"`
+	// PremiumPrice is the first-year price of a premium name
+	PremiumPrice string `xml:"PremiumRegistrationPrice,attr"`
+	// Fee is the ICANN fee charged on top of a premium price
+	Fee string `xml:"EapFee,attr"`
+}
+
+// Check asks Namecheap whether domain can be registered
+func (c *Client) Check(ctx context.Context, domain string) (Availability, error) {
+	var a Availability
+	err := c.call(ctx, "namecheap.domains.check", url.Values{"DomainList": {domain}}, &a)
+	if err != nil {
+		return a, err
+	}
+	if a.ErrorNo != "" && a.ErrorNo != "0" {
+		return a, fmt.Errorf("namecheap cannot check %s: %s", domain, a.Description)
+	}
+	return a, nil
+}
+
+// PremiumCost returns the first-year price of a premium name and the fee
+// charged on top of it
+func (a Availability) PremiumCost() (price, fee float64) {
+	price, _ = strconv.ParseFloat(a.PremiumPrice, 64)
+	fee, _ = strconv.ParseFloat(a.Fee, 64)
+	return price, fee
+}
+
+// Backend checks domains through the Namecheap API. Its answers come from
+// the registrar's own registry connections, so they are high confidence.
+type Backend struct {
+	Client *Client
+}
+
+// Check implements checker.Backend
+func (b Backend) Check(ctx context.Context, domain string) checker.Result {
+	a, err := b.Client.Check(ctx, domain)
+	if err != nil {
+		return checker.Result{Domain: domain, Error: err}
+	}
+	r := checker.Result{Domain: domain, Confidence: checker.ConfidenceHigh}
+	switch {
+	case a.Available && a.Premium:
+		r.Premium = true
+	case a.Available:
+		r.Available = true
+	}
+	return r
+}
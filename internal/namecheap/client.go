@@ -0,0 +1,131 @@
+package namecheap
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+const (
+	ProductionURL = "https://api.namecheap.com/xml.response"
+	SandboxURL    = "https://api.sandbox.namecheap.com/xml.response"
+)
+
+// RateLimit is the queries per second sent to the API unless the config
+// overrides it for the API host. Namecheap allows 50 calls a minute.
+const RateLimit = 0.8
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Client calls the Namecheap XML API
+type Client struct {
+	cfg      config.Namecheap
+	endpoint string
+}
+
+// New returns a client for the configured account. The
+// GOFINDADOMAIN_NAMECHEAP_API_KEY environment variable takes precedence
+// over the configured key.
+func New(cfg config.Namecheap) (*Client, error) {
+	if env := os.Getenv("GOFINDADOMAIN_NAMECHEAP_API_KEY"); env != "" {
+		cfg.APIKey = env
+	}
+	if cfg.APIUser == "" || cfg.APIKey == "" || cfg.ClientIP == "" {
+		return nil, fmt.Errorf("namecheap needs namecheap.api_user, api_key and client_ip in the config")
+	}
+	if cfg.Username == "" {
+		cfg.Username = cfg.APIUser
+	}
+	endpoint := ProductionURL
+	if cfg.Sandbox {
+		endpoint = SandboxURL
+	}
+	return &Client{cfg: cfg, endpoint: endpoint}, nil
+}
+
+// Host returns the API host name, which rate limits are keyed by
+func (c *Client) Host() string {
+	u, _ := url.Parse(c.endpoint)
+	return u.Host
+}
+
+// Sandbox reports whether calls go to the sandbox
+func (c *Client) Sandbox() bool {
+	return c.cfg.Sandbox
+}
+
+// envelope is the ApiResponse wrapped around every command's result
+type envelope struct {
+	Status string `xml:"Status,attr"`
+	Errors []struct {
+		Number  string `xml:"Number,attr"`
+		Message string `xml:",chardata"`
+	} `xml:"Errors>Error"`
+	Command struct {
+		Inner []byte `xml:",innerxml"`
+	} `xml:"CommandResponse"`
+}
+
+// call runs an API command and decodes the first element of its
+// CommandResponse into result
+func (c *Client) call(ctx context.Context, command string, params url.Values, result any) error {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+	q.Set("ApiUser", c.cfg.APIUser)
+	q.Set("ApiKey", c.cfg.APIKey)
+	q.Set("UserName", c.cfg.Username)
+	q.Set("ClientIp", c.cfg.ClientIP)
+	q.Set("Command", command)
+
+	if err := checker.WaitServer(ctx, c.Host()); err != nil {
+		return err
+	}
+	// POST keeps the key and contact details out of proxy and server logs
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(q.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", command, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to call %s: HTTP %d", command, resp.StatusCode)
+	}
+
+	var env envelope
+	if err := xml.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", command, err)
+	}
+	if !strings.EqualFold(env.Status, "OK") {
+		var msgs []string
+		for _, e := range env.Errors {
+			msgs = append(msgs, fmt.Sprintf("%s (%s)", strings.TrimSpace(e.Message), e.Number))
+		}
+		if len(msgs) == 0 {
+			msgs = append(msgs, "status "+env.Status)
+		}
+		return fmt.Errorf("namecheap %s: %s", command, strings.Join(msgs, "; "))
+	}
+	if result == nil {
+		return nil
+	}
+	if err := xml.NewDecoder(bytes.NewReader(env.Command.Inner)).Decode(result); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", command, err)
+	}
+	return nil
+}
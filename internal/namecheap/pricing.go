@@ -0,0 +1,85 @@
+package namecheap
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Price is the account's one-year price for a TLD
+type Price struct {
+	Register float64
+	Renew    float64
+	Currency string
+}
+
+// pricingResult is the subset of a namecheap.users.getPricing response used here
+type pricingResult struct {
+	Types []struct {
+		Categories []struct {
+			Name     string `xml:"Name,attr"`
+			Products []struct {
+				Name   string `xml:"Name,attr"`
+				Prices []struct {
+					Duration     int    `xml:"Duration,attr"`
+					DurationType string `xml:"DurationType,attr"`
+					Price        string `xml:"Price,attr"`
+					YourPrice    string `xml:"YourPrice,attr"`
+					Currency     string `xml:"Currency,attr"`
+				} `xml:"Price"`
+			} `xml:"Product"`
+		} `xml:"ProductCategory"`
+	} `xml:"ProductType"`
+}
+
+// Pricing fetches the account's domain prices keyed by TLD without the
+// leading dot ("com", "co.uk"), or only those of tld when it is not empty
+func (c *Client) Pricing(ctx context.Context, tld string) (map[string]Price, error) {
+	params := url.Values{"ProductType": {"DOMAIN"}}
+	if tld != "" {
+		params.Set("ProductName", strings.TrimPrefix(tld, "."))
+	}
+	var res pricingResult
+	if err := c.call(ctx, "namecheap.users.getPricing", params, &res); err != nil {
+		return nil, err
+	}
+
+	prices := make(map[string]Price)
+	for _, pt := range res.Types {
+		for _, cat := range pt.Categories {
+			category := strings.ToLower(cat.Name)
+			if category != "register" && category != "renew" {
+				continue
+			}
+			for _, p := range cat.Products {
+				for _, price := range p.Prices {
+					if price.Duration != 1 || !strings.EqualFold(price.DurationType, "YEAR") {
+						continue
+					}
+					// YourPrice includes account discounts, when given
+					amount, _ := strconv.ParseFloat(price.YourPrice, 64)
+					if amount == 0 {
+						amount, _ = strconv.ParseFloat(price.Price, 64)
+					}
+					name := strings.ToLower(p.Name)
+					entry := prices[name]
+					entry.Currency = price.Currency
+					if category == "register" {
+						entry.Register = amount
+					} else {
+						entry.Renew = amount
+					}
+					prices[name] = entry
+				}
+			}
+		}
+	}
+	// Renewal-only entries are not registrable
+	for name, p := range prices {
+		if p.Register == 0 {
+			delete(prices, name)
+		}
+	}
+	return prices, nil
+}
@@ -0,0 +1,94 @@
+package namecheap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// contactRoles are the contacts domains.create needs, all filled from one contact
+var contactRoles = []string{"Registrant", "Tech", "Admin", "AuxBilling"}
+
+// Registration is the outcome of namecheap.domains.create
+type Registration struct {
+	Domain        string  `xml:"Domain,attr"`
+	Registered    bool    `xml:"Registered,attr"`
+	ChargedAmount float64 `xml:"ChargedAmount,attr"`
+	OrderID       string  `xml:"OrderID,attr"`
+	TransactionID string  `xml:"TransactionID,attr"`
+}
+
+// Order is a domain to register
+type Order struct {
+	Domain string
+	Years  int
+	// PremiumPrice and EapFee must be the prices Check reported for
+	// premium names
+	PremiumPrice float64
+	EapFee       float64
+}
+
+// ValidateContact reports the first required contact field that is missing
+func ValidateContact(c config.Contact) error {
+	required := [][2]string{
+		{"first_name", c.FirstName}, {"last_name", c.LastName}, {"address1", c.Address1},
+		{"city", c.City}, {"state", c.State}, {"postal_code", c.PostalCode},
+		{"country", c.Country}, {"phone", c.Phone}, {"email", c.Email},
+	}
+	for _, f := range required {
+		if strings.TrimSpace(f[1]) == "" {
+			return fmt.Errorf("namecheap.contact.%s is missing from the config", f[0])
+		}
+	}
+	return nil
+}
+
+// Register buys a domain, charging the account's balance or payment method
+func (c *Client) Register(ctx context.Context, o Order) (Registration, error) {
+	contact := c.cfg.Contact
+	if err := ValidateContact(contact); err != nil {
+		return Registration{}, err
+	}
+
+	params := url.Values{
+		"DomainName": {o.Domain},
+		"Years":      {strconv.Itoa(o.Years)},
+	}
+	for _, role := range contactRoles {
+		params.Set(role+"FirstName", contact.FirstName)
+		params.Set(role+"LastName", contact.LastName)
+		params.Set(role+"Address1", contact.Address1)
+		params.Set(role+"City", contact.City)
+		params.Set(role+"StateProvince", contact.State)
+		params.Set(role+"PostalCode", contact.PostalCode)
+		params.Set(role+"Country", contact.Country)
+		params.Set(role+"Phone", contact.Phone)
+		params.Set(role+"EmailAddress", contact.Email)
+		if contact.Organization != "" {
+			params.Set(role+"OrganizationName", contact.Organization)
+		}
+		if contact.Address2 != "" {
+			params.Set(role+"Address2", contact.Address2)
+		}
+	}
+	if o.PremiumPrice > 0 {
+		params.Set("IsPremiumDomain", "true")
+		params.Set("PremiumPrice", strconv.FormatFloat(o.PremiumPrice, 'f', 2, 64))
+	}
+	if o.EapFee > 0 {
+		params.Set("EapFee", strconv.FormatFloat(o.EapFee, 'f', 2, 64))
+	}
+
+	var r Registration
+	if err := c.call(ctx, "namecheap.domains.create", params, &r); err != nil {
+		return r, err
+	}
+	if !r.Registered {
+		return r, fmt.Errorf("namecheap did not register %s", o.Domain)
+	}
+	return r, nil
+}
@@ -0,0 +1,121 @@
+// Package notify sends alerts about available domains to webhooks, chat
+// services and email.
+//
+// Sinks are written as "<kind>:<target>":
+//
+//	webhook:<url>                  POST the notification as JSON
+//	slack:<webhook url>            Slack incoming webhook
+//	discord:<webhook url>          Discord webhook
+//	telegram:<bot token>/<chat id> Telegram bot message
+//	email:<address>[,<address>]    mail through the configured SMTP server
+//	plugin:<name>                  the notifier plugin called name
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// Notification is a single alert about a domain
+type Notification struct {
+	Domain     string `json:"domain"`
+	Available  bool   `json:"available"`
+	ExpiryDate string `json:"expiry_date,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Sink delivers notifications to one destination
+type Sink interface {
+	// Name identifies the sink in error messages, without secrets
+	Name() string
+	Send(ctx context.Context, n Notification) error
+}
+
+var client = &http.Client{Timeout: 15 * time.Second}
+
+// Notifier sends each notification to every sink. A nil *Notifier has no
+// sinks.
+type Notifier struct {
+	Sinks []Sink
+}
+
+// New parses the sinks configured in cfg followed by extra sinks, usually
+// given with --notify. It returns nil when there are none. The Notifier
+// must be closed to stop its plugin sinks.
+func New(cfg config.Notify, extra []string) (*Notifier, error) {
+	n := &Notifier{}
+	for _, spec := range append(append([]string(nil), cfg.Sinks...), extra...) {
+		s, err := Parse(spec, cfg.SMTP)
+		if err != nil {
+			n.Close()
+			return nil, err
+		}
+		n.Sinks = append(n.Sinks, s)
+	}
+	if len(n.Sinks) == 0 {
+		return nil, nil
+	}
+	return n, nil
+}
+
+// Close stops the sinks that hold resources, such as plugin processes
+func (n *Notifier) Close() {
+	if n == nil {
+		return
+	}
+	for _, s := range n.Sinks {
+		if c, ok := s.(interface{ Close() }); ok {
+			c.Close()
+		}
+	}
+}
+
+// Parse builds the sink described by spec; smtp is used by email sinks.
+// Plugin sinks are started and must be closed.
+func Parse(spec string, smtp config.SMTP) (Sink, error) {
+	kind, target, ok := strings.Cut(spec, ":")
+	if !ok || target == "" {
+		return nil, fmt.Errorf("invalid notification sink %q (use <kind>:<target>, e.g. slack:<webhook url>)", spec)
+	}
+
+	switch kind {
+	case "webhook", "slack", "discord":
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid %s url %q", kind, target)
+		}
+		return webhookSink{kind: kind, url: target}, nil
+	case "telegram":
+		i := strings.LastIndex(target, "/")
+		if i <= 0 || i == len(target)-1 {
+			return nil, fmt.Errorf("invalid telegram sink (use telegram:<bot token>/<chat id>)")
+		}
+		return telegramSink{token: target[:i], chatID: target[i+1:]}, nil
+	case "email":
+		return newEmailSink(target, smtp)
+	case "plugin":
+		return newPluginSink(target)
+	}
+	return nil, fmt.Errorf("unknown notification sink %q (use webhook, slack, discord, telegram, email or plugin)", kind)
+}
+
+// Send delivers n to every sink, returning the failures joined
+func (n *Notifier) Send(ctx context.Context, msg Notification) error {
+	if n == nil {
+		return nil
+	}
+	var errs []error
+	for _, s := range n.Sinks {
+		if err := s.Send(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
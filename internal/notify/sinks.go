@@ -0,0 +1,234 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/plugin"
+)
+
+// webhookSink posts to a generic, Slack or Discord webhook
+type webhookSink struct {
+	kind string
+	url  string
+}
+
+func (s webhookSink) Name() string {
+	if u, err := url.Parse(s.url); err == nil {
+		return s.kind + " (" + u.Host + ")"
+	}
+	return s.kind
+}
+
+func (s webhookSink) Send(ctx context.Context, n Notification) error {
+	var payload any = n
+	switch s.kind {
+	case "slack":
+		payload = map[string]string{"text": n.Message}
+	case "discord":
+		payload = map[string]string{"content": n.Message}
+	}
+	return postJSON(ctx, s.url, payload)
+}
+
+// pluginSink hands notifications to a notifier plugin
+type pluginSink struct {
+	name string
+	p    *plugin.Plugin
+}
+
+func newPluginSink(name string) (pluginSink, error) {
+	info, err := plugin.Find(plugin.KindNotifier, name)
+	if err != nil {
+		return pluginSink{}, err
+	}
+	p, err := plugin.Start(info)
+	if err != nil {
+		return pluginSink{}, err
+	}
+	return pluginSink{name: name, p: p}, nil
+}
+
+func (s pluginSink) Name() string { return "plugin:" + s.name }
+
+func (s pluginSink) Send(ctx context.Context, n Notification) error {
+	return s.p.Notify(ctx, plugin.Notification(n))
+}
+
+func (s pluginSink) Close() { s.p.Close() }
+
+// telegramSink sends a message through the Telegram Bot API
+type telegramSink struct {
+	token  string
+	chatID string
+}
+
+func (s telegramSink) Name() string { return "telegram (" + s.chatID + ")" }
+
+func (s telegramSink) Send(ctx context.Context, n Notification) error {
+	return postJSON(ctx, "https://api.telegram.org/bot"+s.token+"/sendMessage",
+		map[string]string{"chat_id": s.chatID, "text": n.Message})
+}
+
+func postJSON(ctx context.Context, endpoint string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// The request error repeats the url, which may hold a token
+		var ue *url.Error
+		if errors.As(err, &ue) {
+			err = ue.Err
+		}
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		line, _, _ := strings.Cut(strings.TrimSpace(string(msg)), "\n")
+		if line == "" {
+			return fmt.Errorf("failed to send notification: %s", resp.Status)
+		}
+		return fmt.Errorf("failed to send notification: %s: %s", resp.Status, line)
+	}
+	return nil
+}
+
+// smtpTimeout bounds a whole SMTP exchange, from dialing to QUIT
+const smtpTimeout = 15 * time.Second
+
+// emailSink mails notifications through an SMTP server
+type emailSink struct {
+	to   []string
+	smtp config.SMTP
+}
+
+func newEmailSink(target string, cfg config.SMTP) (Sink, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("email notifications need notify.smtp.host in the config file")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	if cfg.From == "" {
+		cfg.From = cfg.Username
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("email notifications need notify.smtp.from or notify.smtp.username in the config file")
+	}
+	if env := os.Getenv("GOFINDADOMAIN_SMTP_PASSWORD"); env != "" {
+		cfg.Password = env
+	}
+
+	var to []string
+	for _, addr := range strings.Split(target, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	return emailSink{to: to, smtp: cfg}, nil
+}
+
+func (s emailSink) Name() string { return "email (" + strings.Join(s.to, ", ") + ")" }
+
+func (s emailSink) Send(ctx context.Context, n Notification) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.smtp.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", n.Message)
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(n.Message + "\r\n")
+	if n.ExpiryDate != "" {
+		fmt.Fprintf(&msg, "Expiry date: %s\r\n", n.ExpiryDate)
+	}
+
+	if err := s.sendMail(ctx, []byte(msg.String())); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// sendMail does what smtp.SendMail does, but within smtpTimeout and
+// abandoning the connection when ctx is done
+func (s emailSink) sendMail(ctx context.Context, msg []byte) error {
+	dialer := net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.smtp.Host, strconv.Itoa(s.smtp.Port)))
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, s.smtp.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.smtp.Host}); err != nil {
+			return err
+		}
+	}
+	if s.smtp.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(smtp.PlainAuth("", s.smtp.Username, s.smtp.Password, s.smtp.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.smtp.From); err != nil {
+		return err
+	}
+	for _, addr := range s.to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,105 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// checkResult is the result of the backend "check" method. Premium and
+// reserved pass on a registry's premium or reserved flag (such as the EPP
+// fee extension's premium class) for a name that is not available.
+type checkResult struct {
+	Available  bool   `json:"available"`
+	ExpiryDate string `json:"expiry_date"`
+	Premium    bool   `json:"premium"`
+	Reserved   bool   `json:"reserved"`
+}
+
+// Check implements checker.Backend for backend plugins
+func (p *Plugin) Check(ctx context.Context, domain string) checker.Result {
+	var res checkResult
+	if err := p.Call(ctx, "check", map[string]string{"domain": domain}, &res); err != nil {
+		return checker.Result{Domain: domain, Error: err}
+	}
+	return checker.Result{
+		Domain:     domain,
+		Available:  res.Available && !res.Premium && !res.Reserved,
+		ExpiryDate: res.ExpiryDate,
+		Premium:    res.Premium && !res.Reserved,
+		Reserved:   res.Reserved,
+		Confidence: checker.ConfidenceMedium,
+	}
+}
+
+// PriceQuote is the result of the pricing "price" method
+type PriceQuote struct {
+	Currency string  `json:"currency"`
+	Register float64 `json:"register"`
+	Renew    float64 `json:"renew"`
+	URL      string  `json:"url,omitempty"`
+}
+
+// Price asks a pricing plugin for the registration price of a domain
+func (p *Plugin) Price(ctx context.Context, domain string) (PriceQuote, error) {
+	var quote PriceQuote
+	err := p.Call(ctx, "price", map[string]string{"domain": domain}, &quote)
+	return quote, err
+}
+
+// AftermarketListing is the result of the aftermarket "listing" method.
+// Price 0 means the seller takes offers.
+type AftermarketListing struct {
+	Listed   bool    `json:"listed"`
+	Price    float64 `json:"price"`
+	Currency string  `json:"currency"`
+	URL      string  `json:"url,omitempty"`
+}
+
+// Listing asks an aftermarket plugin whether a domain is listed for sale
+func (p *Plugin) Listing(ctx context.Context, domain string) (AftermarketListing, error) {
+	var listing AftermarketListing
+	err := p.Call(ctx, "listing", map[string]string{"domain": domain}, &listing)
+	return listing, err
+}
+
+// TrademarkMark is a trademark in the result of the trademark "search" method
+type TrademarkMark struct {
+	Mark   string `json:"mark"`
+	Office string `json:"office"`
+	Number string `json:"number"`
+	Status string `json:"status"`
+	// Live is false for expired, withdrawn, refused or cancelled marks
+	Live bool `json:"live"`
+}
+
+// SearchMarks asks a trademark plugin for the marks containing a name
+func (p *Plugin) SearchMarks(ctx context.Context, name string) ([]TrademarkMark, error) {
+	var res struct {
+		Marks []TrademarkMark `json:"marks"`
+	}
+	err := p.Call(ctx, "search", map[string]string{"name": name}, &res)
+	return res.Marks, err
+}
+
+// Notification is the payload of the notifier "notify" method
+type Notification struct {
+	Domain     string `json:"domain"`
+	Available  bool   `json:"available"`
+	ExpiryDate string `json:"expiry_date,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Notify asks a notifier plugin to deliver a notification
+func (p *Plugin) Notify(ctx context.Context, n Notification) error {
+	return p.Call(ctx, "notify", n, nil)
+}
+
+// StartBackend finds and starts the backend plugin with the given name
+func StartBackend(name string) (*Plugin, error) {
+	info, err := Find(KindBackend, name)
+	if err != nil {
+		return nil, err
+	}
+	return Start(info)
+}
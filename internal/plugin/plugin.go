@@ -0,0 +1,339 @@
+// Package plugin runs third-party extensions as subprocesses that speak a
+// JSON-lines protocol over stdin/stdout.
+//
+// A plugin is an executable named gofindadomain-<kind>-<name> found in the
+// plugin directory or on PATH. The host writes one JSON request per line:
+//
+//	{"id": 1, "method": "check", "params": {"domain": "example.com"}}
+//
+// and the plugin answers each request with one JSON line carrying the same id:
+//
+//	{"id": 1, "result": {"available": false, "expiry_date": "2030-01-01"}}
+//	{"id": 2, "error": "rate limited"}
+//
+// Responses may arrive in any order. The first request is always "handshake",
+// which the plugin must answer with {"protocol": 1} and optionally its "version".
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// ProtocolVersion is the protocol version spoken by this host
+const ProtocolVersion = 1
+
+const executablePrefix = "gofindadomain-"
+
+const (
+	// handshakeTimeout bounds how long a starting plugin has to answer the
+	// handshake
+	handshakeTimeout = 10 * time.Second
+	// closeTimeout is how long a plugin has to exit once its stdin is
+	// closed before it is killed
+	closeTimeout = 3 * time.Second
+)
+
+// Kind is the extension point a plugin implements
+type Kind string
+
+const (
+	KindBackend     Kind = "backend"
+	KindPricing     Kind = "pricing"
+	KindNotifier    Kind = "notifier"
+	KindAftermarket Kind = "aftermarket"
+	KindTrademark   Kind = "trademark"
+)
+
+var kinds = []Kind{KindBackend, KindPricing, KindNotifier, KindAftermarket, KindTrademark}
+
+// ErrNotFound is returned when no plugin with the requested kind and name exists
+var ErrNotFound = errors.New("plugin not found")
+
+// Info describes a discovered plugin executable
+type Info struct {
+	Kind Kind
+	Name string
+	Path string
+}
+
+// Dir returns the user plugin directory (~/.config/gofindadomain/plugins)
+func Dir() (string, error) {
+	base, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "plugins"), nil
+}
+
+// Discover lists plugins in the plugin directory and on PATH. When the same
+// plugin exists in several places the plugin directory wins, then PATH order.
+func Discover() []Info {
+	var dirs []string
+	if dir, err := Dir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	dirs = append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+
+	seen := make(map[string]bool)
+	var found []Info
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			info, ok := parseName(e.Name())
+			if !ok || e.IsDir() {
+				continue
+			}
+			key := string(info.Kind) + "/" + info.Name
+			if seen[key] {
+				continue
+			}
+			info.Path = filepath.Join(dir, e.Name())
+			if !isExecutable(info.Path) {
+				continue
+			}
+			seen[key] = true
+			found = append(found, info)
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Kind != found[j].Kind {
+			return found[i].Kind < found[j].Kind
+		}
+		return found[i].Name < found[j].Name
+	})
+	return found
+}
+
+// Find returns the plugin of the given kind and name
+func Find(kind Kind, name string) (Info, error) {
+	for _, info := range Discover() {
+		if info.Kind == kind && info.Name == name {
+			return info, nil
+		}
+	}
+	return Info{}, fmt.Errorf("%w: %s%s-%s", ErrNotFound, executablePrefix, kind, name)
+}
+
+func parseName(filename string) (Info, bool) {
+	if runtime.GOOS == "windows" {
+		filename = strings.TrimSuffix(filename, ".exe")
+	}
+	rest, ok := strings.CutPrefix(filename, executablePrefix)
+	if !ok {
+		return Info{}, false
+	}
+	for _, k := range kinds {
+		if name, ok := strings.CutPrefix(rest, string(k)+"-"); ok && name != "" {
+			return Info{Kind: k, Name: name}, true
+		}
+	}
+	return Info{}, false
+}
+
+func isExecutable(path string) bool {
+	st, err := os.Stat(path)
+	if err != nil || st.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return st.Mode()&0o111 != 0
+}
+
+type request struct {
+	ID     int    `json:"id"`
+	Method string `json:"method"`
+	Params any    `json:"params,omitempty"`
+}
+
+type response struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  string          `json:"error"`
+}
+
+// Plugin is a running plugin process
+type Plugin struct {
+	Info Info
+	// Version is the plugin version reported during the handshake, if any
+	Version string
+
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// writeMu keeps requests from interleaving on stdin. It is separate from
+	// mu so that a plugin slow to read its stdin cannot block responses.
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int
+	pending map[int]chan response
+	closed  error
+}
+
+// Start launches a plugin and performs the protocol handshake
+func Start(info Info) (*Plugin, error) {
+	cmd := exec.Command(info.Path)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin %s: %w", info.Name, err)
+	}
+
+	p := &Plugin{
+		Info:    info,
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[int]chan response),
+	}
+	go p.readLoop(stdout)
+
+	var hs struct {
+		Protocol int    `json:"protocol"`
+		Version  string `json:"version"`
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	if err := p.Call(ctx, "handshake", map[string]any{"protocol": ProtocolVersion, "kind": info.Kind}, &hs); err != nil {
+		p.Close()
+		return nil, fmt.Errorf("plugin %s handshake failed: %w", info.Name, err)
+	}
+	if hs.Protocol != ProtocolVersion {
+		p.Close()
+		return nil, fmt.Errorf("plugin %s speaks protocol %d, want %d", info.Name, hs.Protocol, ProtocolVersion)
+	}
+	p.Version = hs.Version
+
+	return p, nil
+}
+
+// Call sends a request and decodes the plugin's result into out (which may be nil)
+func (p *Plugin) Call(ctx context.Context, method string, params, out any) error {
+	p.mu.Lock()
+	if p.closed != nil {
+		p.mu.Unlock()
+		return p.closed
+	}
+	p.nextID++
+	id := p.nextID
+	ch := make(chan response, 1)
+	p.pending[id] = ch
+	p.mu.Unlock()
+
+	line, err := json.Marshal(request{ID: id, Method: method, Params: params})
+	if err == nil {
+		p.writeMu.Lock()
+		_, err = p.stdin.Write(append(line, '\n'))
+		p.writeMu.Unlock()
+	}
+	if err != nil {
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		return fmt.Errorf("plugin %s: %w", p.Info.Name, err)
+	}
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return p.closedErr()
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("plugin %s: %s", p.Info.Name, resp.Error)
+		}
+		if out != nil && len(resp.Result) > 0 {
+			if err := json.Unmarshal(resp.Result, out); err != nil {
+				return fmt.Errorf("plugin %s: invalid %s result: %w", p.Info.Name, method, err)
+			}
+		}
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		delete(p.pending, id)
+		p.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (p *Plugin) readLoop(stdout io.Reader) {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var resp response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			continue
+		}
+		p.mu.Lock()
+		ch, ok := p.pending[resp.ID]
+		delete(p.pending, resp.ID)
+		p.mu.Unlock()
+		if ok {
+			ch <- resp
+		}
+	}
+
+	p.mu.Lock()
+	if p.closed == nil {
+		p.closed = fmt.Errorf("plugin %s exited", p.Info.Name)
+	}
+	for id, ch := range p.pending {
+		close(ch)
+		delete(p.pending, id)
+	}
+	p.mu.Unlock()
+}
+
+func (p *Plugin) closedErr() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+// Close stops the plugin process, closing its stdin and killing it if it
+// has not exited shortly after
+func (p *Plugin) Close() error {
+	p.mu.Lock()
+	if p.closed == nil {
+		p.closed = fmt.Errorf("plugin %s closed", p.Info.Name)
+	}
+	p.mu.Unlock()
+
+	p.stdin.Close()
+	done := make(chan error, 1)
+	go func() { done <- p.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(closeTimeout):
+		p.cmd.Process.Kill()
+		<-done
+		return fmt.Errorf("plugin %s did not exit within %s and was killed", p.Info.Name, closeTimeout)
+	}
+}
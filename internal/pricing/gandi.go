@@ -0,0 +1,71 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const GandiCheckURL = "https://api.gandi.net/v5/domain/check"
+
+// gandiSource asks the Gandi API for the price of each domain, which also
+// covers premium pricing. It needs a personal access token.
+type gandiSource struct {
+	token string
+}
+
+// Price implements Source
+func (s gandiSource) Price(ctx context.Context, domain string) (Quote, error) {
+	q := url.Values{"name": {domain}, "processes": {"create", "renew"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, GandiCheckURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return Quote{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Quote{}, fmt.Errorf("failed to fetch gandi price: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Quote{}, fmt.Errorf("failed to fetch gandi price: HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Currency string `json:"currency"`
+		Products []struct {
+			Process string `json:"process"`
+			Prices  []struct {
+				MinDuration  int     `json:"min_duration"`
+				DurationUnit string  `json:"duration_unit"`
+				Price        float64 `json:"price_before_taxes"`
+			} `json:"prices"`
+		} `json:"products"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Quote{}, fmt.Errorf("failed to parse gandi price: %w", err)
+	}
+
+	quote := Quote{Currency: body.Currency, URL: BuyURL("gandi", domain)}
+	for _, p := range body.Products {
+		for _, price := range p.Prices {
+			if price.MinDuration > 1 || price.DurationUnit != "y" {
+				continue
+			}
+			switch p.Process {
+			case "create":
+				quote.Register = price.Price
+			case "renew":
+				quote.Renew = price.Price
+			}
+			break
+		}
+	}
+	if quote.Register == 0 {
+		return Quote{}, ErrNoPrice
+	}
+	return quote, nil
+}
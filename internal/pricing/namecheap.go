@@ -0,0 +1,20 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/james-see/gofindadomain/internal/namecheap"
+)
+
+// fetchNamecheap fetches the account's domain prices from the Namecheap API
+func fetchNamecheap(ctx context.Context, c *namecheap.Client) (*Table, error) {
+	prices, err := c.Pricing(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	t := &Table{Currency: "USD", Prices: make(map[string]Quote, len(prices))}
+	for tld, p := range prices {
+		t.Prices[tld] = Quote{Register: p.Register, Renew: p.Renew, Currency: p.Currency}
+	}
+	return t, nil
+}
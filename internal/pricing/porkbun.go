@@ -0,0 +1,56 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const PorkbunPricingURL = "https://api.porkbun.com/api/json/v3/pricing/get"
+
+// fetchPorkbun fetches Porkbun's price list, which needs no API key
+func fetchPorkbun(ctx context.Context) (*Table, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, PorkbunPricingURL, strings.NewReader("{}"))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Pricing map[string]struct {
+			Registration string `json:"registration"`
+			Renewal      string `json:"renewal"`
+		} `json:"pricing"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if body.Status != "SUCCESS" {
+		return nil, fmt.Errorf("porkbun: %s", body.Message)
+	}
+
+	t := &Table{Currency: "USD", Prices: make(map[string]Quote, len(body.Pricing))}
+	for tld, p := range body.Pricing {
+		register, err := strconv.ParseFloat(p.Registration, 64)
+		if err != nil {
+			continue
+		}
+		renew, _ := strconv.ParseFloat(p.Renewal, 64)
+		t.Prices[strings.ToLower(tld)] = Quote{Register: register, Renew: renew}
+	}
+	return t, nil
+}
@@ -0,0 +1,228 @@
+package pricing
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/namecheap"
+)
+
+// tableMaxAge is how long a price table fetched from a registrar is reused
+const tableMaxAge = 24 * time.Hour
+
+//go:embed prices.json
+var embeddedPrices []byte
+
+// ErrNoPrice is returned for domains a source has no price for
+var ErrNoPrice = errors.New("no price for TLD")
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// Quote is the price of registering and renewing a domain for a year
+type Quote struct {
+	Register float64 `json:"register"`
+	Renew    float64 `json:"renew,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	// URL is the registrar's checkout page for the domain, when it has one
+	URL string `json:"-"`
+}
+
+// RegistrarURLs are registrars' search pages by name, the checkout pages of
+// the registrar sources and the TUI's registrar presets; {domain} is
+// replaced with the domain to buy
+var RegistrarURLs = map[string]string{
+	"namecheap":  "https://www.namecheap.com/domains/registration/results/?domain={domain}",
+	"porkbun":    "https://porkbun.com/checkout/search?q={domain}",
+	"cloudflare": "https://domains.cloudflare.com/?domain={domain}",
+	"dynadot":    "https://www.dynadot.com/domain/search?domain={domain}",
+	"godaddy":    "https://www.godaddy.com/domainsearch/find?domainToCheck={domain}",
+	"gandi":      "https://shop.gandi.net/domain/suggest?search={domain}",
+}
+
+// BuyURL returns the page of the registrar called name for domain, or ""
+// for names without one
+func BuyURL(name, domain string) string {
+	template, ok := RegistrarURLs[name]
+	if !ok {
+		return ""
+	}
+	return strings.ReplaceAll(template, "{domain}", url.QueryEscape(domain))
+}
+
+// Source looks up registration prices
+type Source interface {
+	Price(ctx context.Context, domain string) (Quote, error)
+}
+
+// Sources lists the built-in source names accepted by New
+var Sources = []string{"static", "porkbun", "namecheap", "gandi"}
+
+// New returns the built-in source called name: static (the bundled table,
+// with pricing.table laid over it), porkbun, namecheap or gandi
+func New(name string, cfg *config.Config) (Source, error) {
+	switch name {
+	case "static":
+		t, err := Static(cfg.Pricing.Table)
+		if err != nil {
+			return nil, err
+		}
+		return t, nil
+	case "porkbun":
+		return &tableSource{name: name, fetch: fetchPorkbun}, nil
+	case "namecheap":
+		c, err := namecheap.New(cfg.Namecheap)
+		if err != nil {
+			return nil, err
+		}
+		return &tableSource{name: name, fetch: func(ctx context.Context) (*Table, error) {
+			return fetchNamecheap(ctx, c)
+		}}, nil
+	case "gandi":
+		token := cfg.Pricing.Gandi.Token
+		if env := os.Getenv("GOFINDADOMAIN_GANDI_TOKEN"); env != "" {
+			token = env
+		}
+		if token == "" {
+			return nil, fmt.Errorf("gandi pricing needs pricing.gandi.token in the config or $GOFINDADOMAIN_GANDI_TOKEN")
+		}
+		return gandiSource{token: token}, nil
+	}
+	return nil, fmt.Errorf("unknown pricing source %q (use %s or plugin:<name>)", name, strings.Join(Sources, ", "))
+}
+
+// Table holds per-TLD prices keyed by TLD or suffix without the leading dot
+// ("com", "co.uk"). Currency applies to quotes that name none.
+type Table struct {
+	Source   string           `json:"source,omitempty"`
+	Updated  time.Time        `json:"updated,omitempty"`
+	Currency string           `json:"currency,omitempty"`
+	Prices   map[string]Quote `json:"prices"`
+}
+
+// Static returns the bundled price table, which holds approximate list
+// prices in USD. A JSON table at path, if given, is laid over it.
+func Static(path string) (*Table, error) {
+	var t Table
+	if err := json.Unmarshal(embeddedPrices, &t); err != nil {
+		panic(fmt.Sprintf("pricing: invalid embedded prices: %v", err))
+	}
+	if path == "" {
+		return &t, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price table: %w", err)
+	}
+	var overlay Table
+	if err := json.Unmarshal(data, &overlay); err != nil {
+		return nil, fmt.Errorf("failed to parse price table %s: %w", path, err)
+	}
+	for tld, q := range overlay.Prices {
+		if q.Currency == "" {
+			q.Currency = overlay.Currency
+		}
+		t.Prices[strings.TrimPrefix(strings.ToLower(tld), ".")] = q
+	}
+	return &t, nil
+}
+
+// Price implements Source, matching the longest suffix of domain in the table
+func (t *Table) Price(_ context.Context, domain string) (Quote, error) {
+	labels := strings.Split(strings.ToLower(domain), ".")
+	for i := 1; i < len(labels); i++ {
+		q, ok := t.Prices[strings.Join(labels[i:], ".")]
+		if !ok {
+			continue
+		}
+		if q.Currency == "" {
+			q.Currency = t.Currency
+		}
+		if q.Currency == "" {
+			q.Currency = "USD"
+		}
+		return q, nil
+	}
+	return Quote{}, ErrNoPrice
+}
+
+// tableSource is a registrar price list fetched once and cached on disk for
+// tableMaxAge
+type tableSource struct {
+	name  string
+	fetch func(ctx context.Context) (*Table, error)
+
+	once  sync.Once
+	table *Table
+	err   error
+}
+
+// Price implements Source
+func (s *tableSource) Price(ctx context.Context, domain string) (Quote, error) {
+	s.once.Do(func() { s.table, s.err = s.load(ctx) })
+	if s.err != nil {
+		return Quote{}, s.err
+	}
+	q, err := s.table.Price(ctx, domain)
+	if err != nil {
+		return q, err
+	}
+	q.URL = BuyURL(s.name, domain)
+	return q, nil
+}
+
+// load returns the cached table while it is fresh, else fetches and caches
+// a new one. A stale cache is used when fetching fails.
+func (s *tableSource) load(ctx context.Context) (*Table, error) {
+	path, err := cachePath(s.name)
+	if err != nil {
+		return nil, err
+	}
+	var cached *Table
+	if data, err := os.ReadFile(path); err == nil {
+		var t Table
+		if json.Unmarshal(data, &t) == nil {
+			cached = &t
+		}
+	}
+	if cached != nil && time.Since(cached.Updated) < tableMaxAge {
+		return cached, nil
+	}
+
+	t, err := s.fetch(ctx)
+	if err != nil {
+		if cached != nil {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("failed to fetch %s prices: %w", s.name, err)
+	}
+	t.Source = s.name
+	t.Updated = time.Now()
+	if data, err := json.Marshal(t); err == nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			_ = os.WriteFile(path, data, 0o644)
+		}
+	}
+	return t, nil
+}
+
+// cachePath returns where a fetched price table is kept
+// (~/.config/gofindadomain/prices/<source>.json)
+func cachePath(source string) (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "prices", source+".json"), nil
+}
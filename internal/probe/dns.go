@@ -0,0 +1,35 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// dns resolves the domain's address and mail records. Lookup failures,
+// including NXDOMAIN, leave the records empty.
+func (t *target) dns(ctx context.Context) *checker.DNSRecords {
+	d := &checker.DNSRecords{}
+
+	var resolver net.Resolver
+	if ips, err := resolver.LookupIP(ctx, "ip", t.domain); err == nil {
+		for _, ip := range ips {
+			if ip.To4() != nil {
+				d.A = append(d.A, ip.String())
+			} else {
+				d.AAAA = append(d.AAAA, ip.String())
+			}
+		}
+	}
+	if mxs, err := resolver.LookupMX(ctx, t.domain); err == nil {
+		for _, mx := range mxs {
+			// A null MX (".") explicitly declares the domain accepts no mail
+			if host := strings.TrimSuffix(mx.Host, "."); host != "" {
+				d.MX = append(d.MX, host)
+			}
+		}
+	}
+	return d
+}
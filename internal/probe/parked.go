@@ -0,0 +1,83 @@
+package probe
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// parkingNameservers are the nameserver domains of parking services
+var parkingNameservers = []string{
+	"above.com",
+	"bodis.com",
+	"cashparking.com",
+	"dan.com",
+	"domainmarket.com",
+	"hugedomains.com",
+	"namedrive.com",
+	"parkingcrew.net",
+	"parklogic.com",
+	"sedoparking.com",
+	"smartname.com",
+	"undeveloped.com",
+	"voodoo.com",
+}
+
+// parkingMarkers are landing page fragments left by parking services and
+// registrar parking templates
+var parkingMarkers = []string{
+	"sedoparking",
+	"sedo.com/services/parking",
+	"parkingcrew",
+	"bodis.com",
+	"parklogic",
+	// GoDaddy's parked page template
+	"parking-lander",
+	"wsimg.com/parking",
+	// Dan's parked and lander pages
+	"dan.com/lander",
+	"dan-lander",
+	// Phrases of parking pages in general
+	"domain name is parked",
+	"this domain is parked",
+	"domain is parked",
+	"parked free",
+	"parked domain",
+	"related searches",
+	"window.park",
+}
+
+// parked reports whether the domain points at a parking service, judged by
+// its nameservers first and its landing page otherwise
+func (t *target) parked(ctx context.Context) bool {
+	if t.isParked == nil {
+		v := t.detectParking(ctx)
+		t.isParked = &v
+	}
+	return *t.isParked
+}
+
+func (t *target) detectParking(ctx context.Context) bool {
+	var resolver net.Resolver
+	if nss, err := resolver.LookupNS(ctx, t.domain); err == nil {
+		for _, ns := range nss {
+			host := strings.ToLower(strings.TrimSuffix(ns.Host, "."))
+			for _, p := range parkingNameservers {
+				if host == p || strings.HasSuffix(host, "."+p) {
+					return true
+				}
+			}
+		}
+	}
+
+	p := t.landingPage(ctx)
+	if p == nil {
+		return false
+	}
+	for _, m := range parkingMarkers {
+		if strings.Contains(p.lower, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Package probe enriches taken domains with lightweight network checks
+// (nameservers and landing pages) that hint whether they can be acquired.
+package probe
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// maxPageSize limits how much of a landing page is read
+const maxPageSize = 256 << 10
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Options selects the probes run on taken domains
+type Options struct {
+	Parked  bool
+	ForSale bool
+	Usage   bool
+	// DNS resolves the domain's A, AAAA and MX records
+	DNS bool
+}
+
+// Enabled reports whether any probe is selected
+func (o Options) Enabled() bool {
+	return o.Parked || o.ForSale || o.Usage || o.DNS
+}
+
+// Backend wraps a checker.Backend and probes the domains it finds taken
+type Backend struct {
+	checker.Backend
+	Options Options
+}
+
+// Check implements checker.Backend
+func (b Backend) Check(ctx context.Context, domain string) checker.Result {
+	r := b.Backend.Check(ctx, domain)
+	if r.Available || r.Error != nil {
+		return r
+	}
+
+	p := &target{domain: domain}
+	if b.Options.Parked {
+		r.Parked = p.parked(ctx)
+	}
+	if b.Options.ForSale {
+		r.ForSale, r.SaleURL, r.SaleContact = p.forSale(ctx)
+	}
+	if b.Options.Usage {
+		r.Usage = p.usage(ctx)
+	}
+	if b.Options.DNS {
+		r.DNS = p.dns(ctx)
+	}
+	return r
+}
+
+// target caches what has been fetched about a domain so probes can share it
+type target struct {
+	domain  string
+	fetched bool
+	page    *page
+	// isParked caches the parking probe
+	isParked *bool
+}
+
+// page is a fetched landing page
+type page struct {
+	URL        string
+	StatusCode int
+	Body       string
+	// lower is Body in lower case, for matching markers
+	lower string
+}
+
+// landingPage fetches the domain's web page once, following redirects, over
+// HTTP or, when the domain does not answer it, HTTPS. It returns nil when the
+// domain serves no page.
+func (t *target) landingPage(ctx context.Context) *page {
+	if t.fetched {
+		return t.page
+	}
+	t.fetched = true
+
+	for _, scheme := range []string{"http", "https"} {
+		if t.page = fetchPage(ctx, scheme+"://"+t.domain+"/"); t.page != nil {
+			break
+		}
+	}
+	return t.page
+}
+
+// fetchPage fetches a page, or returns nil when it cannot be fetched
+func fetchPage(ctx context.Context, url string) *page {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", "gofindadomain")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil
+	}
+
+	return &page{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		lower:      strings.ToLower(string(body)),
+	}
+}
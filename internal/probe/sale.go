@@ -0,0 +1,109 @@
+package probe
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// marketplaces are the hosts of domain marketplaces that sale pages link or redirect to
+var marketplaces = []string{
+	"afternic.com",
+	"atom.com",
+	"brandbucket.com",
+	"buydomains.com",
+	"dan.com",
+	"efty.com",
+	"flippa.com",
+	"godaddy.com",
+	"hugedomains.com",
+	"sedo.com",
+	"squadhelp.com",
+	"undeveloped.com",
+}
+
+// saleMarkers are landing page phrases announcing a domain is for sale
+var saleMarkers = []string{
+	"domain is for sale",
+	"domain may be for sale",
+	"domain name is for sale",
+	"is available for purchase",
+	"buy this domain",
+	"make an offer",
+	"inquire about this domain",
+}
+
+var (
+	hrefPattern  = regexp.MustCompile(`(?i)href\s*=\s*["']([^"']+)["']`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// forSale reports whether the domain's landing page offers it for sale, with
+// the marketplace or landing page URL to follow up on and a contact email
+// when the page shows one
+func (t *target) forSale(ctx context.Context) (bool, string, string) {
+	p := t.landingPage(ctx)
+	if p == nil {
+		return false, "", ""
+	}
+
+	// Redirected straight to a marketplace listing
+	if isMarketplace(p.URL) {
+		return true, p.URL, contact(p.Body)
+	}
+
+	marked := false
+	for _, m := range saleMarkers {
+		if strings.Contains(p.lower, m) {
+			marked = true
+			break
+		}
+	}
+
+	for _, m := range hrefPattern.FindAllStringSubmatch(p.Body, -1) {
+		link := m[1]
+		if isMarketplace(link) && (marked || strings.Contains(strings.ToLower(link), t.domain)) {
+			return true, link, contact(p.Body)
+		}
+	}
+
+	if marked {
+		return true, p.URL, contact(p.Body)
+	}
+	return false, "", ""
+}
+
+// isMarketplace reports whether link points at a known domain marketplace
+func isMarketplace(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, m := range marketplaces {
+		if host == m || strings.HasSuffix(host, "."+m) {
+			return true
+		}
+	}
+	return false
+}
+
+// contact returns the first email address on the page, preferring mailto links
+func contact(body string) string {
+	for _, m := range hrefPattern.FindAllStringSubmatch(body, -1) {
+		if addr, ok := strings.CutPrefix(strings.ToLower(m[1]), "mailto:"); ok {
+			addr, _, _ = strings.Cut(addr, "?")
+			return addr
+		}
+	}
+	for _, addr := range emailPattern.FindAllString(body, -1) {
+		// Retina image names such as logo@2x.png look like addresses
+		switch strings.ToLower(addr[strings.LastIndex(addr, ".")+1:]) {
+		case "png", "jpg", "jpeg", "gif", "svg", "webp":
+			continue
+		}
+		return addr
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// minSiteSize is the smallest page body counted as a real website; placeholder
+// and default server pages are usually smaller
+const minSiteSize = 2 << 10
+
+// usage gathers active-usage signals: mail exchangers, a real website and a
+// valid certificate
+func (t *target) usage(ctx context.Context) *checker.Usage {
+	u := &checker.Usage{}
+
+	var resolver net.Resolver
+	if mxs, err := resolver.LookupMX(ctx, t.domain); err == nil {
+		for _, mx := range mxs {
+			// A null MX (".") explicitly declares the domain accepts no mail
+			if mx.Host != "." && mx.Host != "" {
+				u.MX = true
+				break
+			}
+		}
+	}
+
+	if p := t.landingPage(ctx); p != nil && p.StatusCode == http.StatusOK && len(p.Body) >= minSiteSize {
+		u.Web = !t.parked(ctx) && !isMarketplace(p.URL)
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		Config:    &tls.Config{ServerName: t.domain},
+	}
+	if conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(t.domain, "443")); err == nil {
+		conn.Close()
+		u.HTTPS = true
+	}
+
+	return u
+}
@@ -0,0 +1,222 @@
+package rank
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// Quote is what a price source knows about registering a domain
+type Quote struct {
+	// Price is the first-year registration price and Renew the yearly
+	// renewal price, 0 when unknown
+	Price    float64
+	Renew    float64
+	Currency string
+	// BuyURL adds the domain to the source registrar's cart, when it has one
+	BuyURL string
+}
+
+// PriceFunc returns the quote for a domain, or false when its price is unknown
+type PriceFunc func(ctx context.Context, domain string) (Quote, bool)
+
+// Entry is a result annotated with its quality score, price and composite rank
+type Entry struct {
+	checker.Result
+	Score     int
+	Price     float64
+	Renew     float64
+	Currency  string
+	HasPrice  bool
+	BuyURL    string
+	Composite float64
+}
+
+// Ranker combines quality scores and prices into a composite rank:
+//
+//	composite = ScoreWeight*score - PriceWeight*price
+//
+// Domains without a known price are not penalized for price.
+type Ranker struct {
+	ScoreWeight float64
+	PriceWeight float64
+	Price       PriceFunc
+
+	mu     sync.Mutex
+	prices map[string]priceEntry
+}
+
+type priceEntry struct {
+	quote Quote
+	ok    bool
+}
+
+// New returns a Ranker with the given weights. price may be nil.
+func New(scoreWeight, priceWeight float64, price PriceFunc) *Ranker {
+	return &Ranker{
+		ScoreWeight: scoreWeight,
+		PriceWeight: priceWeight,
+		Price:       price,
+		prices:      make(map[string]priceEntry),
+	}
+}
+
+// Rank annotates a result with its score, price and composite rank. Prices are
+// only looked up for available domains and are cached per domain.
+func (r *Ranker) Rank(ctx context.Context, result checker.Result, score int) Entry {
+	e := Entry{Result: result, Score: score}
+	if result.Available && result.Error == nil {
+		var q Quote
+		q, e.HasPrice = r.lookupPrice(ctx, result.Domain)
+		if e.HasPrice {
+			e.Price, e.Renew, e.Currency, e.BuyURL = q.Price, q.Renew, q.Currency, q.BuyURL
+		}
+	}
+
+	e.Composite = r.ScoreWeight * float64(score)
+	if e.HasPrice {
+		e.Composite -= r.PriceWeight * e.Price
+	}
+	return e
+}
+
+func (r *Ranker) lookupPrice(ctx context.Context, domain string) (Quote, bool) {
+	if r.Price == nil {
+		return Quote{}, false
+	}
+
+	r.mu.Lock()
+	cached, ok := r.prices[domain]
+	r.mu.Unlock()
+	if ok {
+		return cached.quote, cached.ok
+	}
+
+	quote, found := r.Price(ctx, domain)
+	r.mu.Lock()
+	r.prices[domain] = priceEntry{quote: quote, ok: found}
+	r.mu.Unlock()
+	return quote, found
+}
+
+// FormatPrice renders an entry's price with its currency, or "" when unknown
+func (e Entry) FormatPrice() string {
+	if !e.HasPrice {
+		return ""
+	}
+	return e.formatAmount(e.Price)
+}
+
+// FormatRenew renders an entry's renewal price, or "" when unknown
+func (e Entry) FormatRenew() string {
+	if !e.HasPrice || e.Renew == 0 {
+		return ""
+	}
+	return e.formatAmount(e.Renew)
+}
+
+func (e Entry) formatAmount(amount float64) string {
+	if e.Currency == "" || e.Currency == "USD" {
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	return fmt.Sprintf("%.2f %s", amount, e.Currency)
+}
+
+// Sort orders entries best first: available domains by descending composite
+// rank, then premium, dropping, parked or for-sale domains, then other taken and
+// reserved domains and errors by domain name
+func Sort(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		aAvail := a.Available && a.Error == nil
+		bAvail := b.Available && b.Error == nil
+		if aAvail != bAvail {
+			return aAvail
+		}
+		if aAcq, bAcq := a.Premium || a.Dropping() || a.Parked || a.ForSale, b.Premium || b.Dropping() || b.Parked || b.ForSale; aAcq != bAcq {
+			return aAcq
+		}
+		if aAvail && a.Composite != b.Composite {
+			return a.Composite > b.Composite
+		}
+		return a.Domain < b.Domain
+	})
+}
+
+// SortByAvailability orders available domains first, then premium,
+// reserved and dropping, parked or for-sale domains, then taken domains and
+// failed checks, each by domain name
+func SortByAvailability(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := availabilityOrder(entries[i]), availabilityOrder(entries[j])
+		if a != b {
+			return a < b
+		}
+		return entries[i].Domain < entries[j].Domain
+	})
+}
+
+func availabilityOrder(e Entry) int {
+	switch {
+	case e.Error != nil:
+		return 5
+	case e.Available:
+		return 0
+	case e.Premium:
+		return 1
+	case e.Reserved:
+		return 2
+	case e.Dropping() || e.Parked || e.ForSale:
+		return 3
+	}
+	return 4
+}
+
+// SortByDomain orders entries by domain name
+func SortByDomain(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Domain < entries[j].Domain
+	})
+}
+
+// SortByExpiry orders registered domains soonest to expire first, followed
+// by the domains without a known expiry date by domain name
+func SortByExpiry(entries []Entry) {
+	expiry := make(map[string]time.Time, len(entries))
+	for _, e := range entries {
+		if t, ok := checker.ParseDate(e.ExpiryDate); ok && !e.Available && e.Error == nil {
+			expiry[e.Domain] = t
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, aOK := expiry[entries[i].Domain]
+		b, bOK := expiry[entries[j].Domain]
+		if aOK != bOK {
+			return aOK
+		}
+		if aOK && !a.Equal(b) {
+			return a.Before(b)
+		}
+		return entries[i].Domain < entries[j].Domain
+	})
+}
+
+// SortByPrice orders entries cheapest first: available domains by ascending
+// price, then available domains without a known price by composite rank,
+// then the rest as Sort orders them
+func SortByPrice(entries []Entry) {
+	Sort(entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		aPriced := a.Available && a.Error == nil && a.HasPrice
+		bPriced := b.Available && b.Error == nil && b.HasPrice
+		if aPriced != bPriced {
+			return aPriced
+		}
+		return aPriced && a.Price < b.Price
+	})
+}
@@ -0,0 +1,67 @@
+// Package report summarizes check results.
+package report
+
+import (
+	"sort"
+	"strings"
+)
+
+// lengthTiers are the label length tiers domain investors hunt for short names in
+var lengthTiers = []string{"<=3", "4", "5", "6+"}
+
+// LengthTier is the available domains whose labels fall in one length tier
+type LengthTier struct {
+	Name    string
+	Domains []string
+	// PerTLD counts the domains by TLD
+	PerTLD map[string]int
+}
+
+// TLDs returns the tier's TLDs, most domains first
+func (t LengthTier) TLDs() []string {
+	tlds := make([]string, 0, len(t.PerTLD))
+	for tld := range t.PerTLD {
+		tlds = append(tlds, tld)
+	}
+	sort.Slice(tlds, func(i, j int) bool {
+		if t.PerTLD[tlds[i]] != t.PerTLD[tlds[j]] {
+			return t.PerTLD[tlds[i]] > t.PerTLD[tlds[j]]
+		}
+		return tlds[i] < tlds[j]
+	})
+	return tlds
+}
+
+// LengthReport groups domains by label length: 3 or fewer, 4, 5 and 6+ characters
+type LengthReport struct {
+	tiers []LengthTier
+}
+
+// NewLengthReport returns an empty report
+func NewLengthReport() *LengthReport {
+	r := &LengthReport{}
+	for _, name := range lengthTiers {
+		r.tiers = append(r.tiers, LengthTier{Name: name, PerTLD: make(map[string]int)})
+	}
+	return r
+}
+
+// Add files a domain under its label length tier
+func (r *LengthReport) Add(domain string) {
+	label, tld := domain, ""
+	if i := strings.Index(domain, "."); i >= 0 {
+		label, tld = domain[:i], domain[i:]
+	}
+
+	i := min(max(len(label)-3, 0), len(r.tiers)-1)
+	r.tiers[i].Domains = append(r.tiers[i].Domains, domain)
+	r.tiers[i].PerTLD[tld]++
+}
+
+// Tiers returns the tiers from shortest to longest, with their domains sorted
+func (r *LengthReport) Tiers() []LengthTier {
+	for _, t := range r.tiers {
+		sort.Strings(t.Domains)
+	}
+	return r.tiers
+}
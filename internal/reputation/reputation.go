@@ -0,0 +1,173 @@
+// Package reputation checks domains against DNS blocklists and Google Safe
+// Browsing, which reveal available domains abused by a previous owner.
+package reputation
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// errRefused is returned when a blocklist declines to answer, as Spamhaus
+// does for queries through public resolvers, or answers with an unknown code
+var errRefused = errors.New("query refused")
+
+// blocklist is a DNS blocklist of domain names, queried as <domain>.<zone>
+type blocklist struct {
+	name string
+	zone string
+	// reason decodes a listing's return address, returning errRefused for
+	// the codes that signal a refused query
+	reason func(ip net.IP) (string, error)
+}
+
+var blocklists = []blocklist{
+	{"Spamhaus DBL", "dbl.spamhaus.org", spamhausReason},
+	{"SURBL", "multi.surbl.org", bitsReason(surblBits)},
+	{"URIBL", "multi.uribl.com", bitsReason(uriblBits)},
+}
+
+// spamhausCodes are the Spamhaus DBL return codes (127.0.1.x)
+var spamhausCodes = map[byte]string{
+	2: "spam", 4: "phishing", 5: "malware", 6: "botnet",
+	102: "abused legit spam", 103: "abused redirector", 104: "abused legit phishing",
+	105: "abused legit malware", 106: "abused legit botnet",
+}
+
+func spamhausReason(ip net.IP) (string, error) {
+	ip = ip.To4()
+	if ip[1] != 0 || ip[2] != 1 {
+		return "", errRefused
+	}
+	if reason, ok := spamhausCodes[ip[3]]; ok {
+		return reason, nil
+	}
+	return "listed", nil
+}
+
+// listBit is a sublist of a blocklist that flags listings with a bit of
+// the last octet of its 127.0.0.x return address
+type listBit struct {
+	bit    byte
+	reason string
+}
+
+var (
+	surblBits = []listBit{{8, "phishing"}, {16, "malware"}, {64, "abuse"}, {128, "cracked"}}
+	uriblBits = []listBit{{2, "black"}, {4, "grey"}, {8, "red"}}
+)
+
+// bitsReason decodes the return address of a blocklist whose sublists are
+// bits; 127.0.0.1 signals a refused query on both SURBL and URIBL
+func bitsReason(bits []listBit) func(ip net.IP) (string, error) {
+	return func(ip net.IP) (string, error) {
+		ip = ip.To4()
+		code := ip[3]
+		if ip[1] != 0 || ip[2] != 0 || code == 1 {
+			return "", errRefused
+		}
+		var reasons []string
+		for _, b := range bits {
+			if code&b.bit != 0 {
+				reasons = append(reasons, b.reason)
+			}
+		}
+		if len(reasons) == 0 {
+			return "listed", nil
+		}
+		return strings.Join(reasons, ", "), nil
+	}
+}
+
+// lookup asks a blocklist about domain, returning the listing reason or ""
+// when the domain is not listed
+func (b blocklist) lookup(ctx context.Context, domain string) (string, error) {
+	var resolver net.Resolver
+	addrs, err := resolver.LookupIP(ctx, "ip4", domain+"."+b.zone)
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, ip := range addrs {
+		if ip4 := ip.To4(); ip4 != nil && ip4[0] == 127 {
+			return b.reason(ip4)
+		}
+	}
+	return "", nil
+}
+
+// SafeBrowsingKey returns the configured Google Safe Browsing API key,
+// preferring $GOFINDADOMAIN_SAFE_BROWSING_KEY
+func SafeBrowsingKey(cfg config.Reputation) string {
+	if env := os.Getenv("GOFINDADOMAIN_SAFE_BROWSING_KEY"); env != "" {
+		return env
+	}
+	return cfg.SafeBrowsingKey
+}
+
+// Check asks the DNS blocklists and, given an API key, Google Safe Browsing
+// about domain. Sources that fail to answer are left out of the count.
+func Check(ctx context.Context, domain, safeBrowsingKey string) checker.Reputation {
+	type answer struct {
+		name    string
+		reason  string
+		checked bool
+	}
+	answers := make([]answer, len(blocklists)+1)
+
+	var wg sync.WaitGroup
+	for i, b := range blocklists {
+		wg.Go(func() {
+			reason, err := b.lookup(ctx, domain)
+			answers[i] = answer{name: b.name, reason: reason, checked: err == nil}
+		})
+	}
+	if safeBrowsingKey != "" {
+		wg.Go(func() {
+			reason, err := safeBrowsing(ctx, domain, safeBrowsingKey)
+			answers[len(blocklists)] = answer{name: "Google Safe Browsing", reason: reason, checked: err == nil}
+		})
+	}
+	wg.Wait()
+
+	var rep checker.Reputation
+	for _, a := range answers {
+		if !a.checked {
+			continue
+		}
+		rep.Checked++
+		if a.reason != "" {
+			rep.Listings = append(rep.Listings, a.name+": "+a.reason)
+		}
+	}
+	return rep
+}
+
+// Backend wraps a checker.Backend and checks the reputation of the domains
+// it finds available. When no source answers, the reputation is left unset.
+type Backend struct {
+	checker.Backend
+	// SafeBrowsingKey enables Google Safe Browsing
+	SafeBrowsingKey string
+}
+
+// Check implements checker.Backend
+func (b Backend) Check(ctx context.Context, domain string) checker.Result {
+	r := b.Backend.Check(ctx, domain)
+	if !r.Available || r.Error != nil {
+		return r
+	}
+	if rep := Check(ctx, domain, b.SafeBrowsingKey); rep.Checked > 0 {
+		r.Reputation = &rep
+	}
+	return r
+}
@@ -0,0 +1,69 @@
+package reputation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const SafeBrowsingURL = "https://safebrowsing.googleapis.com/v4/threatMatches:find"
+
+var client = &http.Client{Timeout: 15 * time.Second}
+
+// safeBrowsing asks the Safe Browsing Lookup API about the domain's web
+// pages, returning the threat types found or "" when none are
+func safeBrowsing(ctx context.Context, domain, key string) (string, error) {
+	type entry struct {
+		URL string `json:"url"`
+	}
+	body := map[string]any{
+		"client": map[string]string{"clientId": "gofindadomain", "clientVersion": "1"},
+		"threatInfo": map[string]any{
+			"threatTypes":      []string{"MALWARE", "SOCIAL_ENGINEERING", "UNWANTED_SOFTWARE", "POTENTIALLY_HARMFUL_APPLICATION"},
+			"platformTypes":    []string{"ANY_PLATFORM"},
+			"threatEntryTypes": []string{"URL"},
+			"threatEntries":    []entry{{"http://" + domain + "/"}, {"https://" + domain + "/"}},
+		},
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, SafeBrowsingURL+"?key="+url.QueryEscape(key), bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query safe browsing: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query safe browsing: HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Matches []struct {
+			ThreatType string `json:"threatType"`
+		} `json:"matches"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse safe browsing response: %w", err)
+	}
+	var threats []string
+	for _, m := range result.Matches {
+		threat := strings.ToLower(strings.ReplaceAll(m.ThreatType, "_", " "))
+		if !slices.Contains(threats, threat) {
+			threats = append(threats, threat)
+		}
+	}
+	return strings.Join(threats, ", "), nil
+}
@@ -0,0 +1,289 @@
+package route53
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials are AWS access keys, temporary when SessionToken is set
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// Expires is zero for long-term keys
+	Expires time.Time
+}
+
+// expired reports whether temporary credentials are about to expire
+func (c Credentials) expired() bool {
+	return !c.Expires.IsZero() && time.Until(c.Expires) < time.Minute
+}
+
+// metadataClient queries the container and instance metadata endpoints,
+// which answer quickly when they exist at all
+var metadataClient = &http.Client{Timeout: 2 * time.Second}
+
+// errNoCredentials is returned by a provider that found nothing to use
+var errNoCredentials = errors.New("no credentials")
+
+// credentialProvider is one link of the default credential chain
+type credentialProvider struct {
+	name string
+	load func(ctx context.Context) (Credentials, error)
+}
+
+// defaultChain mirrors the order of the AWS SDKs' default chain for the
+// sources gofindadomain supports
+var defaultChain = []credentialProvider{
+	{"environment", envCredentials},
+	{"shared files", sharedCredentials},
+	{"container", containerCredentials},
+	{"instance metadata", instanceCredentials},
+}
+
+// LoadCredentials returns the first credentials found in the environment
+// (AWS_ACCESS_KEY_ID), the shared credentials and config files (AWS_PROFILE),
+// the ECS container endpoint or the EC2 instance metadata service
+func LoadCredentials(ctx context.Context) (Credentials, error) {
+	for _, p := range defaultChain {
+		c, err := p.load(ctx)
+		if errors.Is(err, errNoCredentials) {
+			continue
+		}
+		if err != nil {
+			return Credentials{}, fmt.Errorf("failed to load AWS credentials from %s: %w", p.name, err)
+		}
+		return c, nil
+	}
+	return Credentials{}, fmt.Errorf("no AWS credentials found: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY, or configure a profile in ~/.aws/credentials")
+}
+
+// credentialCache loads credentials once and again shortly before they expire
+type credentialCache struct {
+	mu    sync.Mutex
+	creds Credentials
+	ok    bool
+}
+
+func (c *credentialCache) get(ctx context.Context) (Credentials, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ok && !c.creds.expired() {
+		return c.creds, nil
+	}
+	creds, err := LoadCredentials(ctx)
+	if err != nil {
+		return Credentials{}, err
+	}
+	c.creds, c.ok = creds, true
+	return creds, nil
+}
+
+func envCredentials(context.Context) (Credentials, error) {
+	id := os.Getenv("AWS_ACCESS_KEY_ID")
+	secret := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if id == "" || secret == "" {
+		return Credentials{}, errNoCredentials
+	}
+	return Credentials{AccessKeyID: id, SecretAccessKey: secret, SessionToken: os.Getenv("AWS_SESSION_TOKEN")}, nil
+}
+
+// sharedCredentials reads the profile's static keys from the shared
+// credentials file, then the shared config file
+func sharedCredentials(context.Context) (Credentials, error) {
+	profile := os.Getenv("AWS_PROFILE")
+	if profile == "" {
+		profile = "default"
+	}
+	home, _ := os.UserHomeDir()
+
+	credsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credsFile == "" {
+		credsFile = filepath.Join(home, ".aws", "credentials")
+	}
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = filepath.Join(home, ".aws", "config")
+	}
+
+	// The config file names profiles other than default "profile <name>"
+	sections := []struct{ path, section string }{{credsFile, profile}, {configFile, "profile " + profile}}
+	if profile == "default" {
+		sections = append(sections, struct{ path, section string }{configFile, "default"})
+	}
+	for _, s := range sections {
+		values, err := readINISection(s.path, s.section)
+		if err != nil {
+			return Credentials{}, err
+		}
+		if values["aws_access_key_id"] != "" && values["aws_secret_access_key"] != "" {
+			return Credentials{
+				AccessKeyID:     values["aws_access_key_id"],
+				SecretAccessKey: values["aws_secret_access_key"],
+				SessionToken:    values["aws_session_token"],
+			}, nil
+		}
+	}
+	return Credentials{}, errNoCredentials
+}
+
+// readINISection returns the keys of one [section] of an INI file, or nil
+// when the file does not exist
+func readINISection(path, section string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	in := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			in = strings.TrimSpace(line[1:len(line)-1]) == section
+			continue
+		}
+		if k, v, ok := strings.Cut(line, "="); in && ok {
+			values[strings.ToLower(strings.TrimSpace(k))] = strings.TrimSpace(v)
+		}
+	}
+	return values, scanner.Err()
+}
+
+// metadataCredentials is the JSON served by the container and instance
+// credential endpoints
+type metadataCredentials struct {
+	AccessKeyID     string    `json:"AccessKeyId"`
+	SecretAccessKey string    `json:"SecretAccessKey"`
+	Token           string    `json:"Token"`
+	Expiration      time.Time `json:"Expiration"`
+}
+
+func (m metadataCredentials) credentials() Credentials {
+	return Credentials{AccessKeyID: m.AccessKeyID, SecretAccessKey: m.SecretAccessKey, SessionToken: m.Token, Expires: m.Expiration}
+}
+
+// containerCredentials asks the ECS (or EKS pod identity) credential endpoint
+func containerCredentials(ctx context.Context) (Credentials, error) {
+	endpoint := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+	if rel := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); rel != "" {
+		endpoint = "http://169.254.170.2" + rel
+	}
+	if endpoint == "" {
+		return Credentials{}, errNoCredentials
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	token := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN")
+	if file := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE"); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return Credentials{}, err
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	var m metadataCredentials
+	if err := getJSON(req, &m); err != nil {
+		return Credentials{}, err
+	}
+	return m.credentials(), nil
+}
+
+// instanceCredentials asks the EC2 instance metadata service (IMDSv2) for
+// the credentials of the instance role
+func instanceCredentials(ctx context.Context) (Credentials, error) {
+	if strings.EqualFold(os.Getenv("AWS_EC2_METADATA_DISABLED"), "true") {
+		return Credentials{}, errNoCredentials
+	}
+	const base = "http://169.254.169.254/latest"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, base+"/api/token", nil)
+	if err != nil {
+		return Credentials{}, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "21600")
+	resp, err := metadataClient.Do(req)
+	if err != nil {
+		// Not on EC2
+		return Credentials{}, errNoCredentials
+	}
+	token, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return Credentials{}, errNoCredentials
+	}
+
+	get := func(path string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+path, nil)
+		if err == nil {
+			req.Header.Set("X-aws-ec2-metadata-token", string(token))
+		}
+		return req, err
+	}
+
+	req, err = get("/meta-data/iam/security-credentials/")
+	if err != nil {
+		return Credentials{}, err
+	}
+	resp, err = metadataClient.Do(req)
+	if err != nil {
+		return Credentials{}, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return Credentials{}, err
+	}
+	role, _, _ := strings.Cut(strings.TrimSpace(string(body)), "\n")
+	if resp.StatusCode != http.StatusOK || role == "" {
+		// An instance without a role
+		return Credentials{}, errNoCredentials
+	}
+
+	req, err = get("/meta-data/iam/security-credentials/" + role)
+	if err != nil {
+		return Credentials{}, err
+	}
+	var m metadataCredentials
+	if err := getJSON(req, &m); err != nil {
+		return Credentials{}, err
+	}
+	return m.credentials(), nil
+}
+
+// getJSON runs a metadata request and decodes its JSON answer
+func getJSON(req *http.Request, v any) error {
+	resp, err := metadataClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d from %s", resp.StatusCode, req.URL.Host)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
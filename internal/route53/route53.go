@@ -0,0 +1,124 @@
+package route53
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// Route 53 Domains is only served from us-east-1
+const (
+	Endpoint = "https://route53domains.us-east-1.amazonaws.com/"
+	region   = "us-east-1"
+	service  = "route53domains"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Backend checks domains with the Route 53 Domains CheckDomainAvailability
+// API, using AWS credentials from the default chain
+type Backend struct {
+	creds *credentialCache
+}
+
+// New returns a Backend, failing early when no AWS credentials are found
+func New(ctx context.Context) (Backend, error) {
+	b := Backend{creds: &credentialCache{}}
+	if _, err := b.creds.get(ctx); err != nil {
+		return Backend{}, err
+	}
+	return b, nil
+}
+
+// Check implements checker.Backend
+func (b Backend) Check(ctx context.Context, domain string) checker.Result {
+	availability, err := b.availability(ctx, domain)
+	if err != nil {
+		return checker.Result{Domain: domain, Error: err}
+	}
+
+	r := checker.Result{Domain: domain, Confidence: checker.ConfidenceHigh}
+	switch availability {
+	case "AVAILABLE", "AVAILABLE_PREORDER":
+		r.Available = true
+	case "UNAVAILABLE_PREMIUM":
+		r.Premium = true
+	case "AVAILABLE_RESERVED", "UNAVAILABLE_RESTRICTED", "RESERVED":
+		r.Reserved = true
+	case "UNAVAILABLE":
+	default:
+		// DONT_KNOW, PENDING and INVALID_NAME_FOR_TLD leave the domain to
+		// the next backend of a chain
+		return checker.Result{Domain: domain, Error: fmt.Errorf("route 53 cannot tell whether %s is available: %s", domain, availability)}
+	}
+	return r
+}
+
+// availability calls CheckDomainAvailability and returns its Availability
+func (b Backend) availability(ctx context.Context, domain string) (string, error) {
+	creds, err := b.creds.get(ctx)
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(map[string]string{"DomainName": domain})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "Route53Domains_v20140515.CheckDomainAvailability")
+	if err := checker.WaitServer(ctx, req.URL.Host); err != nil {
+		return "", err
+	}
+	sign(req, body, creds, region, service, time.Now())
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to query route 53: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var out struct {
+		Availability string `json:"Availability"`
+		// Errors carry __type and message (or Message)
+		Type     string `json:"__type"`
+		Message  string `json:"message"`
+		MessageU string `json:"Message"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&out)
+	if err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("failed to parse route 53 response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		kind := out.Type
+		if i := strings.LastIndex(kind, "#"); i >= 0 {
+			kind = kind[i+1:]
+		}
+		msg := out.Message
+		if msg == "" {
+			msg = out.MessageU
+		}
+		if msg == "" {
+			msg = fmt.Sprintf("HTTP %d", resp.StatusCode)
+		}
+		// Throttling and server errors are retried by --retries
+		switch {
+		case kind == "ThrottlingException" || resp.StatusCode == http.StatusTooManyRequests:
+			return "", fmt.Errorf("route 53: %s: %w", msg, checker.ErrRateLimited)
+		case resp.StatusCode >= 500:
+			return "", fmt.Errorf("route 53: %s: %w", msg, checker.ErrServerUnavailable)
+		}
+		return "", fmt.Errorf("route 53: %s: %s", kind, msg)
+	}
+	return out.Availability, nil
+}
@@ -0,0 +1,84 @@
+package route53
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The credentials and requests below are from AWS's Signature Version 4
+// test suite and the IAM ListUsers example of its documentation
+var exampleCreds = Credentials{
+	AccessKeyID:     "AKIDEXAMPLE",
+	SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+}
+
+var exampleTime = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		headers  map[string]string
+		region   string
+		service  string
+		wantAuth string
+	}{
+		{
+			name:    "get-vanilla",
+			method:  http.MethodGet,
+			url:     "https://example.amazonaws.com/",
+			region:  "us-east-1",
+			service: "service",
+			wantAuth: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+				"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:    "iam-list-users",
+			method:  http.MethodGet,
+			url:     "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08",
+			headers: map[string]string{"Content-Type": "application/x-www-form-urlencoded; charset=utf-8"},
+			region:  "us-east-1",
+			service: "iam",
+			wantAuth: "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+				"SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, tt.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, value := range tt.headers {
+			req.Header.Set(name, value)
+		}
+		sign(req, nil, exampleCreds, tt.region, tt.service, exampleTime)
+
+		if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+			t.Errorf("%s: X-Amz-Date = %q, want 20150830T123600Z", tt.name, got)
+		}
+		if got := req.Header.Get("Authorization"); got != tt.wantAuth {
+			t.Errorf("%s: Authorization =\n%s\nwant\n%s", tt.name, got, tt.wantAuth)
+		}
+	}
+}
+
+func TestSignSessionToken(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := exampleCreds
+	creds.SessionToken = "token"
+	sign(req, nil, creds, "us-east-1", "service", exampleTime)
+
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "token" {
+		t.Errorf("X-Amz-Security-Token = %q, want token", got)
+	}
+	const want = "SignedHeaders=host;x-amz-date;x-amz-security-token,"
+	if got := req.Header.Get("Authorization"); !strings.Contains(got, want) {
+		t.Errorf("Authorization = %q, want it to sign the session token (%s)", got, want)
+	}
+}
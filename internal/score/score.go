@@ -0,0 +1,184 @@
+package score
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed words.txt
+var embeddedWords string
+
+var dictionary = loadWords(embeddedWords)
+
+// Component weights; together they add up to 100
+const (
+	lengthWeight     = 35
+	pronounceWeight  = 25
+	dictionaryWeight = 20
+	tldWeight        = 20
+
+	hyphenPenalty = 10
+	digitPenalty  = 5
+)
+
+// tldTiers rates how desirable a TLD is on a 0-1 scale
+var tldTiers = map[string]float64{
+	".com": 1.0,
+	".io":  0.75, ".ai": 0.75, ".co": 0.75, ".app": 0.75, ".dev": 0.75,
+	".net": 0.6, ".org": 0.6,
+}
+
+// Score rates a domain from 0 to 100 on label length, pronounceability,
+// dictionary-word content and TLD desirability, minus hyphen and digit penalties
+func Score(domain string) int {
+	label, tld := split(domain)
+	if label == "" {
+		return 0
+	}
+
+	s := lengthWeight * lengthScore(label)
+	s += pronounceWeight * Pronounceability(label)
+	s += dictionaryWeight * DictionaryCoverage(label)
+	s += tldWeight * TLDDesirability(tld)
+
+	for _, c := range label {
+		switch {
+		case c == '-':
+			s -= hyphenPenalty
+		case c >= '0' && c <= '9':
+			s -= digitPenalty
+		}
+	}
+
+	return int(min(100, max(0, s)) + 0.5)
+}
+
+// split separates a domain into its first label and the remaining suffix
+// (".com", ".co.uk")
+func split(domain string) (label, tld string) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if i := strings.Index(domain, "."); i >= 0 {
+		return domain[:i], domain[i:]
+	}
+	return domain, ""
+}
+
+// lengthScore favors short labels: 1.0 up to 3 characters, 0 from 15 up
+func lengthScore(label string) float64 {
+	n := len(label)
+	if n <= 3 {
+		return 1
+	}
+	return max(0, 1-float64(n-3)/12)
+}
+
+// TLDDesirability rates a TLD from 0 to 1. Unlisted two-letter ccTLDs rate
+// above other unlisted TLDs.
+func TLDDesirability(tld string) float64 {
+	tld = strings.ToLower(tld)
+	if !strings.HasPrefix(tld, ".") {
+		tld = "." + tld
+	}
+	if v, ok := tldTiers[tld]; ok {
+		return v
+	}
+	if len(tld) == 3 {
+		return 0.4
+	}
+	return 0.3
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiouy", c) >= 0
+}
+
+// leetLetters maps leetspeak digits to the letters they are read as
+var leetLetters = map[byte]byte{'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't'}
+
+// Pronounceability rates from 0 to 1 how easy a label is to say, based on
+// its vowel ratio and runs of consecutive consonants or vowels. Leetspeak
+// digits are read as the letters they stand for.
+func Pronounceability(label string) float64 {
+	var letters []byte
+	for i := 0; i < len(label); i++ {
+		c := label[i] | 0x20
+		if l, ok := leetLetters[label[i]]; ok {
+			c = l
+		}
+		if c >= 'a' && c <= 'z' {
+			letters = append(letters, c)
+		}
+	}
+	if len(letters) == 0 {
+		return 0
+	}
+
+	vowels := 0
+	penalty := 0.0
+	run := 0
+	prevVowel := false
+	for i, c := range letters {
+		v := isVowel(c)
+		if v {
+			vowels++
+		}
+		if i > 0 && v == prevVowel {
+			run++
+		} else {
+			run = 1
+		}
+		prevVowel = v
+		// Three consonants or three vowels in a row get hard to say
+		if run > 2 {
+			penalty += 0.2
+		}
+	}
+
+	ratio := float64(vowels) / float64(len(letters))
+	score := 1.0
+	switch {
+	case vowels == 0:
+		return 0
+	case ratio < 0.25:
+		score -= (0.25 - ratio) * 2
+	case ratio > 0.6:
+		score -= (ratio - 0.6) * 2
+	}
+
+	return max(0, score-penalty)
+}
+
+// DictionaryCoverage returns the fraction of a label's letters that can be
+// covered by non-overlapping dictionary words of three or more letters
+func DictionaryCoverage(label string) float64 {
+	label = strings.ToLower(label)
+	n := len(label)
+	if n == 0 {
+		return 0
+	}
+
+	// best[i] is the most letters coverable in label[:i]
+	best := make([]int, n+1)
+	for i := 1; i <= n; i++ {
+		best[i] = best[i-1]
+		for j := max(0, i-12); j <= i-3; j++ {
+			if dictionary[label[j:i]] && best[j]+(i-j) > best[i] {
+				best[i] = best[j] + (i - j)
+			}
+		}
+	}
+	return float64(best[n]) / float64(n)
+}
+
+// IsWord reports whether w is in the embedded dictionary
+func IsWord(w string) bool {
+	return dictionary[strings.ToLower(w)]
+}
+
+func loadWords(data string) map[string]bool {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(data) {
+		words[w] = true
+	}
+	return words
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+// jobRetention is how long finished jobs stay available
+const jobRetention = 24 * time.Hour
+
+// job is a bulk check running in the background
+type job struct {
+	id      string
+	total   int
+	created time.Time
+
+	// cancel stops the job's checks
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	results   []any
+	finished  time.Time
+	cancelled bool
+	// changed is closed and replaced whenever a result is added or the job
+	// finishes, waking event streams
+	changed chan struct{}
+}
+
+type jobRequest struct {
+	Keywords []string `json:"keywords"`
+	TLDs     []string `json:"tlds"`
+	Domains  []string `json:"domains"`
+}
+
+// jobStatus is the JSON form of a job
+type jobStatus struct {
+	ID       string     `json:"id"`
+	Status   string     `json:"status"`
+	Total    int        `json:"total"`
+	Checked  int        `json:"checked"`
+	Created  time.Time  `json:"created"`
+	Finished *time.Time `json:"finished,omitempty"`
+	Results  []any      `json:"results,omitempty"`
+}
+
+// domains expands the request into the keyword x TLD matrix followed by the
+// full domains, without duplicates. Pairs whose keyword is shorter than the
+// TLD's registry registers are left out.
+func (req jobRequest) domains() []string {
+	var out []string
+	seen := make(map[string]bool)
+	add := func(d string) {
+		if !seen[d] {
+			seen[d] = true
+			out = append(out, d)
+		}
+	}
+	for _, k := range req.Keywords {
+		if k = normalizeDomain(k); k == "" {
+			continue
+		}
+		for _, t := range req.TLDs {
+			if t = normalizeDomain(t); t != "" && tld.CheckMinLength(k+"."+t) == nil {
+				add(k + "." + t)
+			}
+		}
+	}
+	for _, d := range req.Domains {
+		if d = normalizeDomain(d); d != "" {
+			add(d)
+		}
+	}
+	return out
+}
+
+func (s *Server) handleCreateJob(w http.ResponseWriter, r *http.Request) {
+	var req jobRequest
+	if !decodeBody(w, r, "request", &req) {
+		return
+	}
+	if len(req.Keywords) > 0 && len(req.TLDs) == 0 {
+		writeError(w, http.StatusBadRequest, "keywords need tlds")
+		return
+	}
+	domains := req.domains()
+	if len(domains) == 0 {
+		writeError(w, http.StatusBadRequest, "nothing to check: pass keywords and tlds, or domains")
+		return
+	}
+	if len(domains) > maxJobDomains {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("too many domains (%d, at most %d per job)", len(domains), maxJobDomains))
+		return
+	}
+	for _, d := range domains {
+		if !strings.Contains(d, ".") {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%q is not a full domain name", d))
+			return
+		}
+		if err := validateDomain(d); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	j := s.startJob(domains)
+	if j == nil {
+		writeError(w, http.StatusTooManyRequests, fmt.Sprintf("%d jobs are running already; try again once one finishes or cancel one", s.maxJobs()))
+		return
+	}
+	w.Header().Set("Location", "/jobs/"+j.id)
+	writeJSON(w, http.StatusAccepted, j.status(false))
+}
+
+// maxJobs returns how many jobs may run at once
+func (s *Server) maxJobs() int {
+	if s.MaxJobs > 0 {
+		return s.MaxJobs
+	}
+	return DefaultMaxJobs
+}
+
+// startJob registers a job and checks its domains in the background, or
+// returns nil when MaxJobs jobs are running already
+func (s *Server) startJob(domains []string) *job {
+	now := time.Now().UTC()
+	// Jobs outlive the request that started them
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{total: len(domains), created: now, cancel: cancel, changed: make(chan struct{})}
+
+	s.mu.Lock()
+	if s.running >= s.maxJobs() {
+		s.mu.Unlock()
+		cancel()
+		return nil
+	}
+	s.running++
+	if s.jobs == nil {
+		s.jobs = make(map[string]*job)
+	}
+	for id, old := range s.jobs {
+		if f := old.finishedAt(); !f.IsZero() && now.Sub(f) > jobRetention {
+			delete(s.jobs, id)
+		}
+	}
+	s.nextID++
+	j.id = strconv.Itoa(s.nextID)
+	s.jobs[j.id] = j
+	s.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		checker.CheckDomainsWithCallback(ctx, s.Backend, domains, s.Concurrency, func(r checker.Result) {
+			j.add(s.Format(ctx, r))
+		})
+		j.finish(ctx.Err() != nil)
+
+		s.mu.Lock()
+		s.running--
+		s.mu.Unlock()
+	}()
+	return j
+}
+
+func (s *Server) job(id string) *job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobs[id]
+}
+
+func (j *job) add(result any) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.results = append(j.results, result)
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// finish marks the job done, or cancelled when its checks were stopped
+func (j *job) finish(cancelled bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.finished = time.Now().UTC()
+	j.cancelled = cancelled
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+func (j *job) finishedAt() time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.finished
+}
+
+// since returns the results after the first n, whether the job has
+// finished, and a channel closed on the next change
+func (j *job) since(n int) ([]any, bool, <-chan struct{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.results[n:], !j.finished.IsZero(), j.changed
+}
+
+func (j *job) status(withResults bool) jobStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	st := jobStatus{ID: j.id, Status: "running", Total: j.total, Checked: len(j.results), Created: j.created}
+	if !j.finished.IsZero() {
+		st.Status = "done"
+		if j.cancelled {
+			st.Status = "cancelled"
+		}
+		finished := j.finished
+		st.Finished = &finished
+	}
+	if withResults {
+		st.Results = append([]any{}, j.results...)
+	}
+	return st
+}
+
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	j := s.job(r.PathValue("id"))
+	if j == nil {
+		writeError(w, http.StatusNotFound, "no such job")
+		return
+	}
+	writeJSON(w, http.StatusOK, j.status(true))
+}
+
+// handleDeleteJob cancels a job, if it is still running, and forgets it
+func (s *Server) handleDeleteJob(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	j := s.jobs[id]
+	delete(s.jobs, id)
+	s.mu.Unlock()
+	if j == nil {
+		writeError(w, http.StatusNotFound, "no such job")
+		return
+	}
+	j.cancel()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleJobEvents streams a job's results as Server-Sent Events, starting
+// with those already checked, and ends with a "done" event carrying the
+// job's final status
+func (s *Server) handleJobEvents(w http.ResponseWriter, r *http.Request) {
+	j := s.job(r.PathValue("id"))
+	if j == nil {
+		writeError(w, http.StatusNotFound, "no such job")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	sent := 0
+	for {
+		results, done, changed := j.since(sent)
+		for _, res := range results {
+			sent++
+			if err := writeEvent(w, "result", sent, res); err != nil {
+				return
+			}
+		}
+		if done {
+			writeEvent(w, "done", sent, j.status(false))
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event string, id int, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\nid: %d\ndata: %s\n\n", event, id, data)
+	return err
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/james-see/gofindadomain/internal/store"
+)
+
+// listName restricts list names to safe file names
+var listName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// etag identifies the state of a list by a hash of its entries
+func etag(entries []*store.Entry) string {
+	data, _ := json.Marshal(entries)
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// handleGetList serves a list with its ETag; a list never saved is 404
+func (s *Server) handleGetList(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if !listName.MatchString(name) {
+		writeError(w, http.StatusBadRequest, "invalid list name")
+		return
+	}
+
+	s.listMu.Lock()
+	entries, _, err := s.Lists.Load(name)
+	s.listMu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if entries == nil {
+		writeError(w, http.StatusNotFound, "no such list")
+		return
+	}
+	w.Header().Set("ETag", etag(entries))
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// handlePutList replaces a list. If-Match must carry the current ETag and
+// If-None-Match: * requires the list not to exist; otherwise it is 412.
+func (s *Server) handlePutList(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if !listName.MatchString(name) {
+		writeError(w, http.StatusBadRequest, "invalid list name")
+		return
+	}
+	var entries []*store.Entry
+	if !decodeBody(w, r, "list", &entries) {
+		return
+	}
+	if entries == nil {
+		entries = []*store.Entry{}
+	}
+
+	s.listMu.Lock()
+	defer s.listMu.Unlock()
+
+	current, _, err := s.Lists.Load(name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	exists := current != nil
+	if match := r.Header.Get("If-Match"); match != "" && (!exists || match != etag(current)) {
+		writeError(w, http.StatusPreconditionFailed, store.ErrConflict.Error())
+		return
+	}
+	if r.Header.Get("If-None-Match") == "*" && exists {
+		writeError(w, http.StatusPreconditionFailed, store.ErrConflict.Error())
+		return
+	}
+
+	if _, err := s.Lists.Save(name, entries, ""); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("ETag", etag(entries))
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,166 @@
+// Package server is the HTTP API of gofindadomain serve, which runs checks
+// for a team and keeps their shared shortlist and watchlist:
+//
+//	POST /check                {"domain": "example.com"} checks one domain
+//	POST /jobs                 {"keywords": [...], "tlds": [...], "domains": [...]} starts a bulk job
+//	GET  /jobs/{id}            the job's progress and results so far
+//	DELETE /jobs/{id}          cancels a job and forgets it
+//	GET  /jobs/{id}/events     Server-Sent Events: a "result" event per result, then "done"
+//	GET  /api/lists/{name}     a saved list, with its ETag
+//	PUT  /api/lists/{name}     replaces a saved list (If-Match or If-None-Match: *)
+//	GET  /metrics              Prometheus metrics
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/metrics"
+	"github.com/james-see/gofindadomain/internal/store"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+// maxJobDomains caps the domains of a single job
+const maxJobDomains = 100000
+
+// maxBodyBytes caps request bodies, leaving room for a job of maxJobDomains
+// full domains
+const maxBodyBytes = 8 << 20
+
+// DefaultMaxJobs is how many jobs run at once unless MaxJobs is set
+const DefaultMaxJobs = 4
+
+// Server serves the API. Its fields must be set before Handler is called.
+type Server struct {
+	Backend     checker.Backend
+	Concurrency int
+	// MaxJobs caps the jobs running at once; further jobs are refused with
+	// 429 until one finishes. 0 means DefaultMaxJobs.
+	MaxJobs int
+	// Format converts a result to the JSON value returned for it
+	Format func(ctx context.Context, r checker.Result) any
+	// Token, when set, must be sent as "Authorization: Bearer <token>"
+	Token string
+	// Lists keeps the saved lists served under /api/lists
+	Lists store.Backend
+
+	mu      sync.Mutex
+	jobs    map[string]*job
+	nextID  int
+	running int
+
+	// listMu serializes list writes so If-Match checks are atomic
+	listMu sync.Mutex
+}
+
+// Handler returns the API's routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /check", s.handleCheck)
+	mux.HandleFunc("POST /jobs", s.handleCreateJob)
+	mux.HandleFunc("GET /jobs/{id}", s.handleJob)
+	mux.HandleFunc("GET /jobs/{id}/events", s.handleJobEvents)
+	mux.HandleFunc("DELETE /jobs/{id}", s.handleDeleteJob)
+	mux.HandleFunc("GET /api/lists/{name}", s.handleGetList)
+	mux.HandleFunc("PUT /api/lists/{name}", s.handlePutList)
+	mux.Handle("GET /metrics", metrics.Handler())
+	return s.authenticate(limitBody(mux))
+}
+
+// limitBody caps the size of request bodies at maxBodyBytes
+func limitBody(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate rejects requests without the bearer token, if one is set
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.Token != "" {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(s.Token)) != 1 {
+				writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type checkRequest struct {
+	Domain string `json:"domain"`
+}
+
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	var req checkRequest
+	if !decodeBody(w, r, "request", &req) {
+		return
+	}
+	domain := normalizeDomain(req.Domain)
+	if !strings.Contains(domain, ".") {
+		writeError(w, http.StatusBadRequest, "domain must be a full domain name such as example.com")
+		return
+	}
+	if err := validateDomain(domain); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result := s.Backend.Check(r.Context(), domain)
+	checker.ObserveCheck(result)
+	writeJSON(w, http.StatusOK, s.Format(r.Context(), result))
+}
+
+// normalizeDomain lower-cases a domain, strips surrounding space and dots
+// and converts Unicode labels to punycode
+func normalizeDomain(d string) string {
+	d = strings.Trim(strings.ToLower(strings.TrimSpace(d)), ".")
+	if ascii, err := tld.ToASCII(d); err == nil {
+		return ascii
+	}
+	return d
+}
+
+// validateDomain rejects a domain breaking the DNS label rules or shorter
+// than its registry registers
+func validateDomain(domain string) error {
+	if err := tld.ValidateDomain(domain); err != nil {
+		return err
+	}
+	return tld.CheckMinLength(domain)
+}
+
+// decodeBody decodes a JSON request body into v, or writes a 400, or a 413
+// for a body over maxBodyBytes, and returns false. what names the body in
+// the error.
+func decodeBody(w http.ResponseWriter, r *http.Request, what string, v any) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return true
+	}
+	if tooLarge := (*http.MaxBytesError)(nil); errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("%s is larger than %d bytes", what, tooLarge.Limit))
+		return false
+	}
+	writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s: %v", what, err))
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
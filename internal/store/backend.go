@@ -0,0 +1,192 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// ErrConflict is returned by Save when someone else changed the list since it
+// was loaded
+var ErrConflict = errors.New("list was changed by someone else")
+
+// Backend loads and saves lists. version is an opaque token identifying the
+// loaded state; backends that detect concurrent changes reject a Save whose
+// version is stale with ErrConflict.
+type Backend interface {
+	Load(name string) (entries []*Entry, version string, err error)
+	Save(name string, entries []*Entry, version string) (newVersion string, err error)
+}
+
+// New returns the backend selected by the store config: a shared serve
+// instance when a URL is set, local files otherwise
+func New(cfg config.Store) (Backend, error) {
+	if cfg.URL == "" {
+		dir, err := config.Dir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate config directory: %w", err)
+		}
+		return FileBackend{Dir: dir}, nil
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("invalid store url %q", cfg.URL)
+	}
+	token := cfg.Token
+	if env := os.Getenv("GOFINDADOMAIN_STORE_TOKEN"); env != "" {
+		token = env
+	}
+	return &HTTPBackend{URL: strings.TrimSuffix(cfg.URL, "/"), Token: token}, nil
+}
+
+// FileBackend keeps each list in <Dir>/<name>.json
+type FileBackend struct {
+	Dir string
+}
+
+// Load implements Backend. A missing file is an empty list.
+func (b FileBackend) Load(name string) ([]*Entry, string, error) {
+	data, err := os.ReadFile(b.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	var entries []*Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", b.path(name), err)
+	}
+	return entries, "", nil
+}
+
+// Save implements Backend, replacing the file atomically
+func (b FileBackend) Save(name string, entries []*Entry, _ string) (string, error) {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(b.Dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", name, err)
+	}
+
+	path := b.path(name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return "", fmt.Errorf("failed to save %s: %w", name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to save %s: %w", name, err)
+	}
+	return "", nil
+}
+
+func (b FileBackend) path(name string) string {
+	return filepath.Join(b.Dir, name+".json")
+}
+
+// HTTPBackend keeps lists on a shared gofindadomain serve instance:
+//
+//	GET {URL}/api/lists/{name}   200 with the entries and an ETag, or 404 for an empty list
+//	PUT {URL}/api/lists/{name}   replaces the entries; If-Match carries the loaded ETag
+//	                             (If-None-Match: * for a new list) and 412 signals a conflict
+type HTTPBackend struct {
+	URL   string
+	Token string
+}
+
+var storeClient = &http.Client{Timeout: 15 * time.Second}
+
+// Load implements Backend
+func (b *HTTPBackend) Load(name string) ([]*Entry, string, error) {
+	resp, err := b.do(http.MethodGet, name, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, "", nil
+	default:
+		return nil, "", fmt.Errorf("failed to load %s: %s", name, httpError(resp))
+	}
+
+	var entries []*Entry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return entries, resp.Header.Get("ETag"), nil
+}
+
+// Save implements Backend
+func (b *HTTPBackend) Save(name string, entries []*Entry, version string) (string, error) {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return "", err
+	}
+
+	header := http.Header{"Content-Type": {"application/json"}}
+	if version != "" {
+		header.Set("If-Match", version)
+	} else {
+		header.Set("If-None-Match", "*")
+	}
+
+	resp, err := b.do(http.MethodPut, name, bytes.NewReader(data), header)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return resp.Header.Get("ETag"), nil
+	case http.StatusPreconditionFailed:
+		return "", ErrConflict
+	default:
+		return "", fmt.Errorf("failed to save %s: %s", name, httpError(resp))
+	}
+}
+
+func (b *HTTPBackend) do(method, name string, body io.Reader, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(method, b.URL+"/api/lists/"+url.PathEscape(name), body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	if b.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+b.Token)
+	}
+
+	resp, err := storeClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach store %s: %w", b.URL, err)
+	}
+	return resp, nil
+}
+
+// httpError describes a failed response with the first line of its body
+func httpError(resp *http.Response) string {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	line, _, _ := strings.Cut(strings.TrimSpace(string(msg)), "\n")
+	if line == "" {
+		return resp.Status
+	}
+	return resp.Status + ": " + line
+}
@@ -0,0 +1,154 @@
+// Package store persists the user's saved domains (shortlist, watchlist) with
+// their notes and tags, in local files or on a shared serve instance.
+package store
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// List names
+const (
+	Shortlist = "shortlist"
+	Watchlist = "watchlist"
+)
+
+// Entry is a saved domain with the user's notes and tags
+type Entry struct {
+	Domain string    `json:"domain"`
+	Note   string    `json:"note,omitempty"`
+	Tags   []string  `json:"tags,omitempty"`
+	Added  time.Time `json:"added"`
+}
+
+// HasTag reports whether the entry carries tag (case-insensitive)
+func (e *Entry) HasTag(tag string) bool {
+	return slices.ContainsFunc(e.Tags, func(t string) bool { return strings.EqualFold(t, tag) })
+}
+
+// AddTags adds tags the entry does not carry yet
+func (e *Entry) AddTags(tags ...string) {
+	for _, t := range tags {
+		if t = strings.TrimSpace(t); t != "" && !e.HasTag(t) {
+			e.Tags = append(e.Tags, t)
+		}
+	}
+}
+
+// RemoveTags removes tags from the entry
+func (e *Entry) RemoveTags(tags ...string) {
+	e.Tags = slices.DeleteFunc(e.Tags, func(t string) bool {
+		return slices.ContainsFunc(tags, func(r string) bool { return strings.EqualFold(t, r) })
+	})
+}
+
+// List is a named list of saved domains
+type List struct {
+	Name    string
+	backend Backend
+	version string
+	entries []*Entry
+}
+
+// Open loads the named list from the backend
+func Open(b Backend, name string) (*List, error) {
+	entries, version, err := b.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	return &List{Name: name, backend: b, version: version, entries: entries}, nil
+}
+
+// Update loads the named list, applies fn and saves it. When someone else
+// changed the list in the meantime it reloads and applies fn again.
+func Update(b Backend, name string, fn func(*List) error) error {
+	const attempts = 3
+	for i := 0; ; i++ {
+		l, err := Open(b, name)
+		if err != nil {
+			return err
+		}
+		if err := fn(l); err != nil {
+			return err
+		}
+		err = l.Save()
+		if !errors.Is(err, ErrConflict) || i == attempts-1 {
+			return err
+		}
+	}
+}
+
+// Get returns the entry for domain
+func (l *List) Get(domain string) (*Entry, bool) {
+	domain = normalize(domain)
+	for _, e := range l.entries {
+		if e.Domain == domain {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// Add returns the entry for domain, adding it if the list does not have it
+func (l *List) Add(domain string) *Entry {
+	if e, ok := l.Get(domain); ok {
+		return e
+	}
+	e := &Entry{Domain: normalize(domain), Added: time.Now().UTC()}
+	l.entries = append(l.entries, e)
+	return e
+}
+
+// Remove deletes domain from the list and reports whether it was there
+func (l *List) Remove(domain string) bool {
+	domain = normalize(domain)
+	n := len(l.entries)
+	l.entries = slices.DeleteFunc(l.entries, func(e *Entry) bool { return e.Domain == domain })
+	return len(l.entries) != n
+}
+
+// Entries returns the entries sorted by domain, only those tagged with tag
+// when it is not empty
+func (l *List) Entries(tag string) []*Entry {
+	var out []*Entry
+	for _, e := range l.entries {
+		if tag == "" || e.HasTag(tag) {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Domain < out[j].Domain })
+	return out
+}
+
+// Tags returns every tag used in the list, sorted
+func (l *List) Tags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, e := range l.entries {
+		for _, t := range e.Tags {
+			if key := strings.ToLower(t); !seen[key] {
+				seen[key] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Save writes the list back to its backend
+func (l *List) Save() error {
+	version, err := l.backend.Save(l.Name, l.Entries(""), l.version)
+	if err != nil {
+		return err
+	}
+	l.version = version
+	return nil
+}
+
+func normalize(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
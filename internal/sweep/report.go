@@ -0,0 +1,173 @@
+package sweep
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// month is the look-back for the "since last month" comparison
+const month = 30 * 24 * time.Hour
+
+// Chart dimensions in SVG user units
+const (
+	chartWidth   = 720
+	chartHeight  = 240
+	chartPadding = 40
+)
+
+type reportData struct {
+	Def         Definition
+	Runs        []Snapshot
+	Latest      Snapshot
+	Chart       chart
+	SinceMonth  *changeSet
+	SincePrev   *changeSet
+	GeneratedAt time.Time
+}
+
+type changeSet struct {
+	Since time.Time
+	Changes
+}
+
+type chart struct {
+	Width, Height int
+	Points        []chartPoint
+	Polyline      string
+	Left, Right   int
+	Top, Bottom   int
+	Mid           int
+}
+
+type chartPoint struct {
+	X, Y  float64
+	Label string
+}
+
+// WriteHTML writes a trend report for a sweep's snapshots: availability over
+// time as a chart, the domains taken or freed since last month and since the
+// previous run, and a table of runs
+func WriteHTML(w io.Writer, def Definition, snaps []Snapshot) error {
+	if len(snaps) == 0 {
+		return fmt.Errorf("sweep %s has not been run yet", def.Name)
+	}
+
+	data := reportData{
+		Def:         def,
+		Latest:      snaps[len(snaps)-1],
+		Chart:       buildChart(snaps),
+		GeneratedAt: time.Now(),
+	}
+	for i := len(snaps) - 1; i >= 0; i-- {
+		data.Runs = append(data.Runs, snaps[i])
+	}
+	if base, ok := Baseline(snaps, month); ok {
+		data.SinceMonth = &changeSet{Since: base.Time, Changes: Compare(base, data.Latest)}
+		prev := snaps[len(snaps)-2]
+		data.SincePrev = &changeSet{Since: prev.Time, Changes: Compare(prev, data.Latest)}
+	}
+
+	return reportTemplate.Execute(w, data)
+}
+
+func buildChart(snaps []Snapshot) chart {
+	c := chart{
+		Width:  chartWidth,
+		Height: chartHeight,
+		Left:   chartPadding,
+		Right:  chartWidth - chartPadding/2,
+		Top:    chartPadding / 2,
+		Bottom: chartHeight - chartPadding,
+	}
+	c.Mid = (c.Top + c.Bottom) / 2
+
+	span := snaps[len(snaps)-1].Time.Sub(snaps[0].Time)
+	var points []string
+	for i, s := range snaps {
+		// Spread points by time; a single run or identical times fall back to even spacing
+		frac := 0.5
+		switch {
+		case span > 0:
+			frac = float64(s.Time.Sub(snaps[0].Time)) / float64(span)
+		case len(snaps) > 1:
+			frac = float64(i) / float64(len(snaps)-1)
+		}
+		x := float64(c.Left) + frac*float64(c.Right-c.Left)
+		y := float64(c.Bottom) - s.AvailabilityPct()/100*float64(c.Bottom-c.Top)
+		c.Points = append(c.Points, chartPoint{
+			X:     x,
+			Y:     y,
+			Label: fmt.Sprintf("%s: %.1f%% available", s.Time.Format("2006-01-02"), s.AvailabilityPct()),
+		})
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	c.Polyline = strings.Join(points, " ")
+	return c
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("2006-01-02 15:04") },
+	"pct":  func(s Snapshot) string { return fmt.Sprintf("%.1f%%", s.AvailabilityPct()) },
+	// Runs are listed newest first, so the oldest is last
+	"oldest": func(runs []Snapshot) Snapshot { return runs[len(runs)-1] },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Sweep {{.Def.Name}} - gofindadomain</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 60rem; color: #222; }
+h1 { margin-bottom: 0; }
+.meta { color: #666; margin-top: .25rem; }
+.taken { color: #c0392b; }
+.avail { color: #1e8449; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: .25rem .75rem; border-bottom: 1px solid #eee; }
+svg { background: #fafafa; border: 1px solid #eee; }
+svg .axis { stroke: #bbb; }
+svg .line { fill: none; stroke: #00a383; stroke-width: 2; }
+svg .dot { fill: #00a383; }
+svg text { font-size: 11px; fill: #666; }
+</style>
+</head>
+<body>
+<h1>Sweep {{.Def.Name}}</h1>
+<p class="meta">Keyword <strong>{{.Def.Keyword}}</strong> across {{len .Def.TLDs}} TLDs &middot; {{len .Runs}} runs &middot; generated {{date .GeneratedAt}}</p>
+
+<h2>Availability over time</h2>
+<svg width="{{.Chart.Width}}" height="{{.Chart.Height}}" viewBox="0 0 {{.Chart.Width}} {{.Chart.Height}}" role="img" aria-label="Availability percentage per run">
+<line class="axis" x1="{{.Chart.Left}}" y1="{{.Chart.Top}}" x2="{{.Chart.Left}}" y2="{{.Chart.Bottom}}"/>
+<line class="axis" x1="{{.Chart.Left}}" y1="{{.Chart.Bottom}}" x2="{{.Chart.Right}}" y2="{{.Chart.Bottom}}"/>
+<text x="4" y="{{.Chart.Top}}">100%</text>
+<text x="10" y="{{.Chart.Mid}}">50%</text>
+<text x="16" y="{{.Chart.Bottom}}">0%</text>
+<text x="{{.Chart.Left}}" y="{{.Chart.Height}}" dy="-12">{{date (oldest .Runs).Time}}</text>
+<text x="{{.Chart.Right}}" y="{{.Chart.Height}}" dy="-12" text-anchor="end">{{date .Latest.Time}}</text>
+<polyline class="line" points="{{.Chart.Polyline}}"/>
+{{range .Chart.Points}}<circle class="dot" cx="{{printf "%.1f" .X}}" cy="{{printf "%.1f" .Y}}" r="3"><title>{{.Label}}</title></circle>
+{{end}}</svg>
+
+<h2>Latest run</h2>
+<p>{{date .Latest.Time}}: <span class="avail">{{len .Latest.Available}} available</span>, <span class="taken">{{len .Latest.Taken}} taken</span>{{if .Latest.Errors}}, {{len .Latest.Errors}} errors{{end}} ({{pct .Latest}} available)</p>
+
+{{define "changes"}}
+{{if or .NewlyTaken .NewlyAvailable}}
+{{if .NewlyTaken}}<p class="taken">Newly taken ({{len .NewlyTaken}}):</p><ul>{{range .NewlyTaken}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{if .NewlyAvailable}}<p class="avail">Newly available ({{len .NewlyAvailable}}):</p><ul>{{range .NewlyAvailable}}<li>{{.}}</li>{{end}}</ul>{{end}}
+{{else}}<p>No changes.</p>{{end}}
+{{end}}
+
+{{with .SinceMonth}}<h2>Since {{date .Since}} (about a month ago)</h2>{{template "changes" .Changes}}{{end}}
+{{with .SincePrev}}<h2>Since the previous run ({{date .Since}})</h2>{{template "changes" .Changes}}{{end}}
+
+<h2>Runs</h2>
+<table>
+<tr><th>Date</th><th>Available</th><th>Taken</th><th>Errors</th><th>Availability</th></tr>
+{{range .Runs}}<tr><td>{{date .Time}}</td><td>{{len .Available}}</td><td>{{len .Taken}}</td><td>{{len .Errors}}</td><td>{{pct .}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
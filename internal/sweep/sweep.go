@@ -0,0 +1,249 @@
+// Package sweep stores saved sweep definitions (a keyword checked across a
+// fixed TLD list) and the snapshots of each run, for trend reports.
+package sweep
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// Definition is a saved sweep
+type Definition struct {
+	Name    string    `json:"name"`
+	Keyword string    `json:"keyword"`
+	TLDs    []string  `json:"tlds"`
+	Created time.Time `json:"created"`
+}
+
+// Domains returns the domains the sweep checks
+func (d Definition) Domains() []string {
+	domains := make([]string, len(d.TLDs))
+	for i, t := range d.TLDs {
+		domains[i] = d.Keyword + t
+	}
+	return domains
+}
+
+// Snapshot is the outcome of one run of a sweep
+type Snapshot struct {
+	Time      time.Time `json:"time"`
+	Available []string  `json:"available"`
+	Taken     []string  `json:"taken"`
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+// AvailabilityPct is the share of successfully checked domains that were
+// available, from 0 to 100
+func (s Snapshot) AvailabilityPct() float64 {
+	checked := len(s.Available) + len(s.Taken)
+	if checked == 0 {
+		return 0
+	}
+	return 100 * float64(len(s.Available)) / float64(checked)
+}
+
+// Changes are the domains whose state differs between two snapshots
+type Changes struct {
+	NewlyTaken     []string
+	NewlyAvailable []string
+}
+
+// Compare lists the domains that were taken or freed between prev and cur.
+// Domains that errored in either snapshot are not compared.
+func Compare(prev, cur Snapshot) Changes {
+	prevAvail := toSet(prev.Available)
+	prevTaken := toSet(prev.Taken)
+
+	var c Changes
+	for _, d := range cur.Taken {
+		if prevAvail[d] {
+			c.NewlyTaken = append(c.NewlyTaken, d)
+		}
+	}
+	for _, d := range cur.Available {
+		if prevTaken[d] {
+			c.NewlyAvailable = append(c.NewlyAvailable, d)
+		}
+	}
+	sort.Strings(c.NewlyTaken)
+	sort.Strings(c.NewlyAvailable)
+	return c
+}
+
+// Baseline returns the latest snapshot taken at least age before the last
+// one (the earliest snapshot if none is that old), for "since last month"
+// comparisons. ok is false when there are fewer than two snapshots.
+func Baseline(snaps []Snapshot, age time.Duration) (Snapshot, bool) {
+	if len(snaps) < 2 {
+		return Snapshot{}, false
+	}
+	cutoff := snaps[len(snaps)-1].Time.Add(-age)
+	base := snaps[0]
+	for _, s := range snaps[:len(snaps)-1] {
+		if s.Time.After(cutoff) {
+			break
+		}
+		base = s
+	}
+	return base, true
+}
+
+// Dir returns the directory sweeps are kept in (~/.config/gofindadomain/sweeps)
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sweeps"), nil
+}
+
+// Save stores a sweep definition, replacing one with the same name
+func Save(d Definition) error {
+	if d.Name == "" || strings.ContainsAny(d.Name, `/\`) {
+		return fmt.Errorf("invalid sweep name %q", d.Name)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to save sweep: %w", err)
+	}
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, d.Name+".json"), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to save sweep: %w", err)
+	}
+	return nil
+}
+
+// Load reads a sweep definition
+func Load(name string) (Definition, error) {
+	dir, err := Dir()
+	if err != nil {
+		return Definition{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Definition{}, fmt.Errorf("no sweep named %q (create it with sweep save)", name)
+	}
+	if err != nil {
+		return Definition{}, fmt.Errorf("failed to read sweep: %w", err)
+	}
+
+	var d Definition
+	if err := json.Unmarshal(data, &d); err != nil {
+		return Definition{}, fmt.Errorf("failed to parse sweep %s: %w", name, err)
+	}
+	return d, nil
+}
+
+// List returns the saved sweep definitions sorted by name
+func List() ([]Definition, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var defs []Definition
+	for _, f := range files {
+		d, err := Load(strings.TrimSuffix(filepath.Base(f), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, d)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs, nil
+}
+
+// Record appends a snapshot to the sweep's history
+// (~/.config/gofindadomain/sweeps/<name>.history.jsonl)
+func Record(name string, s Snapshot) error {
+	path, err := historyPath(name)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to record sweep: %w", err)
+	}
+	defer f.Close()
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to record sweep: %w", err)
+	}
+	return nil
+}
+
+// History returns the sweep's snapshots, oldest first
+func History(name string) ([]Snapshot, error) {
+	path, err := historyPath(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sweep history: %w", err)
+	}
+	defer f.Close()
+
+	var snaps []Snapshot
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), 16<<20)
+	for scanner.Scan() {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var s Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			return nil, fmt.Errorf("failed to parse sweep history %s: %w", path, err)
+		}
+		snaps = append(snaps, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sweep history: %w", err)
+	}
+
+	sort.SliceStable(snaps, func(i, j int) bool { return snaps[i].Time.Before(snaps[j].Time) })
+	return snaps, nil
+}
+
+func historyPath(name string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".history.jsonl"), nil
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, i := range items {
+		set[i] = true
+	}
+	return set
+}
@@ -0,0 +1,76 @@
+package sweep
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	prev := Snapshot{
+		Available: []string{"a.com", "b.net", "e.io"},
+		Taken:     []string{"c.org", "d.dev", "f.app"},
+		Errors:    []string{"g.xyz"},
+	}
+	cur := Snapshot{
+		Available: []string{"d.dev", "c.org", "g.xyz"},
+		Taken:     []string{"b.net", "a.com", "f.app"},
+		// e.io failed this time, so whether it changed is unknown
+		Errors: []string{"e.io"},
+	}
+	c := Compare(prev, cur)
+	if want := []string{"a.com", "b.net"}; !slices.Equal(c.NewlyTaken, want) {
+		t.Errorf("NewlyTaken = %v, want %v", c.NewlyTaken, want)
+	}
+	if want := []string{"c.org", "d.dev"}; !slices.Equal(c.NewlyAvailable, want) {
+		t.Errorf("NewlyAvailable = %v, want %v", c.NewlyAvailable, want)
+	}
+
+	if c := Compare(prev, prev); len(c.NewlyTaken) != 0 || len(c.NewlyAvailable) != 0 {
+		t.Errorf("Compare of a snapshot with itself = %+v, want no changes", c)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n) }
+	snaps := func(days ...int) []Snapshot {
+		var s []Snapshot
+		for _, d := range days {
+			s = append(s, Snapshot{Time: day(d)})
+		}
+		return s
+	}
+	month := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name   string
+		snaps  []Snapshot
+		age    time.Duration
+		want   time.Time
+		wantOK bool
+	}{
+		{"no snapshots", nil, month, time.Time{}, false},
+		{"one snapshot", snaps(0), month, time.Time{}, false},
+		{"two snapshots", snaps(0, 1), 0, day(0), true},
+		{"latest old enough", snaps(0, 10, 20, 50), month, day(20), true},
+		{"exactly age before", snaps(0, 20, 50), month, day(20), true},
+		{"none old enough", snaps(40, 45, 50), month, day(40), true},
+		{"zero age takes the one before last", snaps(0, 10, 20), 0, day(10), true},
+	}
+	for _, tt := range tests {
+		got, ok := Baseline(tt.snaps, tt.age)
+		if ok != tt.wantOK || !got.Time.Equal(tt.want) {
+			t.Errorf("%s: Baseline = %s, %v, want %s, %v", tt.name, got.Time, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestAvailabilityPct(t *testing.T) {
+	s := Snapshot{Available: []string{"a.com"}, Taken: []string{"b.com", "c.com", "d.com"}, Errors: []string{"e.com"}}
+	if got := s.AvailabilityPct(); got != 25 {
+		t.Errorf("AvailabilityPct() = %v, want 25", got)
+	}
+	if got := (Snapshot{Errors: []string{"e.com"}}).AvailabilityPct(); got != 0 {
+		t.Errorf("AvailabilityPct() of a snapshot of errors = %v, want 0", got)
+	}
+}
@@ -0,0 +1,86 @@
+package tld
+
+import "strings"
+
+// Category is a named group of TLDs, used for preset selections
+type Category struct {
+	Name        string
+	Description string
+	// tlds lists the members of fixed categories, without the dot
+	tlds []string
+	// match decides membership of rule-based categories
+	match func(name string) bool
+}
+
+// legacyGTLDs are the generic TLDs delegated before the 2012 new gTLD
+// program, including the sponsored ones
+var legacyGTLDs = []string{
+	"aero", "arpa", "asia", "biz", "cat", "com", "coop", "edu", "gov", "info", "int",
+	"jobs", "mil", "mobi", "museum", "name", "net", "org", "post", "pro", "tel", "travel", "xxx",
+}
+
+// Categories are the TLD presets, in the order they are offered
+var Categories = []Category{
+	{
+		Name:        "popular",
+		Description: "the most commonly registered TLDs",
+		tlds:        []string{"com", "net", "org", "io", "dev", "co", "app", "ai"},
+	},
+	{
+		Name:        "country-code",
+		Description: "two-letter country and territory TLDs",
+		match:       isCountryCode,
+	},
+	{
+		Name:        "new gTLDs",
+		Description: "generic TLDs from the 2012 program onwards (IDN TLDs excluded)",
+		match: func(name string) bool {
+			return !isCountryCode(name) && !strings.HasPrefix(name, "xn--") && !contains(legacyGTLDs, name) && !strings.Contains(name, ".")
+		},
+	},
+	{
+		Name:        "tech",
+		Description: "TLDs popular with software and startups",
+		tlds:        []string{"io", "dev", "app", "ai", "tech", "sh", "so", "gg", "cloud", "codes", "software", "digital", "systems", "page"},
+	},
+	{
+		Name:        "business",
+		Description: "TLDs for companies and commerce",
+		tlds:        []string{"com", "co", "biz", "inc", "llc", "ltd", "company", "business", "enterprises", "ventures", "group", "agency", "shop", "store"},
+	},
+	{
+		Name:        "cheap",
+		Description: "TLDs that usually cost little to register in the first year",
+		tlds:        []string{"xyz", "online", "site", "store", "fun", "space", "website", "club", "icu", "top", "shop", "pw", "buzz", "monster", "cyou"},
+	},
+	{
+		Name:        "second-level",
+		Description: "country suffixes such as .co.uk, .com.au and .co.jp",
+		match: func(name string) bool {
+			return strings.Contains(name, ".")
+		},
+	},
+}
+
+// Contains reports whether tld, with or without its leading dot, is in the
+// category
+func (c Category) Contains(tld string) bool {
+	name := strings.TrimPrefix(strings.ToLower(tld), ".")
+	if c.match != nil {
+		return c.match(name)
+	}
+	return contains(c.tlds, name)
+}
+
+func isCountryCode(name string) bool {
+	return len(name) == 2 && name[0] >= 'a' && name[0] <= 'z' && name[1] >= 'a' && name[1] <= 'z'
+}
+
+func contains(list []string, name string) bool {
+	for _, s := range list {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
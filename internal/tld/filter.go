@@ -0,0 +1,58 @@
+package tld
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Filter keeps the TLDs matching any include pattern (all when there are
+// none) and none of the exclude patterns. Patterns are globs such as .c* or
+// exact TLDs such as .xxx; the leading dot is optional. IDN TLDs match
+// patterns in either their Unicode or punycode form.
+func Filter(tlds, include, exclude []string) ([]string, error) {
+	normalize := func(patterns []string) ([]string, error) {
+		var out []string
+		for _, p := range patterns {
+			p = strings.ToLower(strings.TrimSpace(p))
+			if p == "" {
+				continue
+			}
+			if !strings.HasPrefix(p, ".") {
+				p = "." + p
+			}
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, fmt.Errorf("invalid TLD pattern %q: %w", p, err)
+			}
+			out = append(out, p)
+		}
+		return out, nil
+	}
+	include, err := normalize(include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err = normalize(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := func(patterns []string, t string) bool {
+		for _, p := range patterns {
+			if ok, _ := path.Match(p, t); ok {
+				return true
+			}
+		}
+		return false
+	}
+	var kept []string
+	for _, t := range tlds {
+		name := strings.ToLower(t)
+		unicode := ToUnicode(name)
+		included := len(include) == 0 || matches(include, name) || matches(include, unicode)
+		if included && !matches(exclude, name) && !matches(exclude, unicode) {
+			kept = append(kept, t)
+		}
+	}
+	return kept, nil
+}
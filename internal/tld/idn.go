@@ -0,0 +1,63 @@
+package tld
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// acePrefix marks a label in punycode (its A-label form)
+const acePrefix = "xn--"
+
+// ToASCII returns the A-label form of a domain or TLD (.中国 becomes
+// .xn--fiqs8s), the form whois and RDAP servers are queried with. Unicode
+// names are mapped per UTS #46 first, which normalizes them to NFC and folds
+// case and width (ＥＸＡＭＰＬＥ.com becomes example.com). ASCII names are
+// only lowercased, leaving them to ValidateLabel.
+func ToASCII(name string) (string, error) {
+	if isASCII(name) {
+		return strings.ToLower(name), nil
+	}
+	if !utf8.ValidString(name) {
+		return "", fmt.Errorf("invalid domain %q: not UTF-8", name)
+	}
+	ascii, err := idna.Lookup.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain %q: %w", name, err)
+	}
+	return ascii, nil
+}
+
+// ToUnicode returns the U-label form of a domain or TLD (.xn--p1ai becomes
+// .рф) for display. Labels that are not valid punycode are kept as they are.
+func ToUnicode(name string) string {
+	if !strings.Contains(strings.ToLower(name), acePrefix) {
+		return name
+	}
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if !strings.HasPrefix(strings.ToLower(label), acePrefix) {
+			continue
+		}
+		if u, err := idna.Lookup.ToUnicode(label); err == nil && u != "" {
+			labels[i] = u
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// IsIDN reports whether a TLD is internationalized
+func IsIDN(tld string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(strings.ToLower(tld), "."), acePrefix)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package tld
+
+import "testing"
+
+// rfc3492 are sample strings of RFC 3492 section 7.1 whose U-labels are
+// valid IDNA2008 labels, with their A-labels lowercased
+var rfc3492 = []struct {
+	name, unicode, ascii string
+}{
+	{"chinese simplified", "他们为什么不说中文", "xn--ihqwcrb4cv8a8dqg056pqjye"},
+	{"chinese traditional", "他們爲什麽不說中文", "xn--ihqwctvzc91f659drss3x8bo0yb"},
+	{"hebrew", "למההםפשוטלאמדבריםעברית", "xn--4dbcagdahymbxekheh6e0a7fei0b"},
+	{"japanese", "なぜみんな日本語を話してくれないのか", "xn--n8jok5ay5dzabd5bym9f0cm5685rrjetr6pdxa"},
+	{"korean", "세계의모든사람들이한국어를이해한다면얼마나좋을까", "xn--989aomsvi5e83db1d2a355cv1e0vak1dwrv93d5xbh15a0dt30a5jpsd879ccm6fea98c"},
+	{"russian", "почемужеонинеговорятпорусски", "xn--b1abfaaepdrnnbgefbadotcwatmq2g4l"},
+	{"japanese mixed", "3年b組金八先生", "xn--3b-ww4c5e180e575a65lsy2b"},
+	{"japanese hyphen", "安室奈美恵-with-super-monkeys", "xn---with-super-monkeys-pc58ag80a8qai00g7n9n"},
+}
+
+func TestToASCIIRFC3492(t *testing.T) {
+	for _, tt := range rfc3492 {
+		got, err := ToASCII(tt.unicode)
+		if err != nil {
+			t.Errorf("%s: ToASCII(%q) error: %v", tt.name, tt.unicode, err)
+			continue
+		}
+		if got != tt.ascii {
+			t.Errorf("%s: ToASCII(%q) = %q, want %q", tt.name, tt.unicode, got, tt.ascii)
+		}
+	}
+}
+
+func TestToUnicodeRFC3492(t *testing.T) {
+	for _, tt := range rfc3492 {
+		if got := ToUnicode(tt.ascii); got != tt.unicode {
+			t.Errorf("%s: ToUnicode(%q) = %q, want %q", tt.name, tt.ascii, got, tt.unicode)
+		}
+	}
+}
+
+func TestToASCII(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"Example.COM", "example.com"},
+		{".中国", ".xn--fiqs8s"},
+		{"bücher.de", "xn--bcher-kva.de"},
+		{"m\u00fcnchen.de", "xn--mnchen-3ya.de"},
+		// NFD input (u and a combining diaeresis) is normalized to NFC
+		{"mu\u0308nchen.de", "xn--mnchen-3ya.de"},
+		// Fullwidth letters fold to ASCII
+		{"ＥＸＡＭＰＬＥ.com", "example.com"},
+		{"Bücher.de", "xn--bcher-kva.de"},
+	}
+	for _, tt := range tests {
+		got, err := ToASCII(tt.in)
+		if err != nil {
+			t.Errorf("ToASCII(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ToASCII(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestToASCIIInvalid(t *testing.T) {
+	for _, in := range []string{"exa\xffmple.com", "a\u200db.com"} {
+		if got, err := ToASCII(in); err == nil {
+			t.Errorf("ToASCII(%q) = %q, want error", in, got)
+		}
+	}
+}
+
+func TestToUnicodeKeepsInvalid(t *testing.T) {
+	for _, in := range []string{"xn--.com", "xn--zz!.com", "example.com"} {
+		if got := ToUnicode(in); got != in {
+			t.Errorf("ToUnicode(%q) = %q, want it unchanged", in, got)
+		}
+	}
+	if got := ToUnicode(".XN--P1AI"); got != ".рф" {
+		t.Errorf("ToUnicode(%q) = %q, want %q", ".XN--P1AI", got, ".рф")
+	}
+}
@@ -0,0 +1,93 @@
+package tld
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// maxLabelLength is the longest DNS label, in punycode characters
+const maxLabelLength = 63
+
+// MinLengths are the shortest names some registries register under their
+// TLD or suffix, where that is more than one character. Registries relax
+// these now and then; a name that is too short is skipped rather than
+// queried.
+var MinLengths = map[string]int{
+	".be":     2,
+	".ca":     2,
+	".com.au": 2,
+	".com.br": 2,
+	".es":     3,
+	".eu":     2,
+	".jp":     3,
+	".kr":     2,
+	".net.au": 2,
+	".nl":     2,
+	".org.au": 2,
+}
+
+// ValidateLabel checks a keyword or other domain label, in punycode, against
+// the DNS label rules: 1 to 63 letters, digits and hyphens, no hyphen at
+// either end, and -- in positions 3 and 4 only for valid punycode (xn--).
+func ValidateLabel(label string) error {
+	name := ToUnicode(label)
+	switch {
+	case label == "":
+		return fmt.Errorf("empty label")
+	case len(label) > maxLabelLength:
+		return fmt.Errorf("%q is longer than %d characters", name, maxLabelLength)
+	case strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-"):
+		return fmt.Errorf("%q starts or ends with a hyphen", name)
+	}
+	for _, c := range label {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return fmt.Errorf("%q contains %q; only letters, digits and hyphens are allowed", name, c)
+		}
+	}
+	if len(label) >= 4 && label[2:4] == "--" {
+		if !strings.HasPrefix(label, acePrefix) {
+			return fmt.Errorf("%q has -- in positions 3 and 4, which is reserved for punycode", name)
+		}
+		// A valid A-label decodes to a non-ASCII U-label that encodes back
+		// to the same A-label
+		u, err := idna.Lookup.ToUnicode(label)
+		if err != nil || isASCII(u) {
+			return fmt.Errorf("%q is not valid punycode", label)
+		}
+		if a, err := idna.Lookup.ToASCII(u); err != nil || a != label {
+			return fmt.Errorf("%q is not valid punycode", label)
+		}
+	}
+	return nil
+}
+
+// ValidateDomain checks every label of a domain in punycode
+func ValidateDomain(domain string) error {
+	for _, label := range strings.Split(domain, ".") {
+		if err := ValidateLabel(label); err != nil {
+			return fmt.Errorf("invalid domain %q: %w", ToUnicode(domain), err)
+		}
+	}
+	return nil
+}
+
+// CheckMinLength reports a domain whose name is shorter than its TLD or
+// suffix allows, per MinLengths
+func CheckMinLength(domain string) error {
+	labels := strings.Split(domain, ".")
+	for i := 1; i < len(labels); i++ {
+		suffix := "." + strings.Join(labels[i:], ".")
+		least, ok := MinLengths[suffix]
+		if !ok {
+			continue
+		}
+		if n := utf8.RuneCountInString(ToUnicode(labels[i-1])); n < least {
+			return fmt.Errorf("%s needs at least %d characters before %s", ToUnicode(domain), least, ToUnicode(suffix))
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package tld
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateLabel(t *testing.T) {
+	tests := []struct {
+		label   string
+		wantErr bool
+	}{
+		{"example", false},
+		{"a", false},
+		{"my-domain2", false},
+		{strings.Repeat("a", 63), false},
+		{"xn--mnchen-3ya", false},
+		{"", true},
+		{strings.Repeat("a", 64), true},
+		{"-example", true},
+		{"example-", true},
+		{"Example", true},
+		{"exa_mple", true},
+		{"exa mple", true},
+		{"ab--cd", true},
+		{"xn--abc", true},
+		{"xn--example", true},
+		{"xn--", true},
+	}
+	for _, tt := range tests {
+		err := ValidateLabel(tt.label)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateLabel(%q) error = %v, want error %v", tt.label, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckMinLength(t *testing.T) {
+	tests := []struct {
+		domain  string
+		wantErr bool
+	}{
+		{"x.com", false},
+		{"ab.es", true},
+		{"abc.es", false},
+		{"ab.jp", true},
+		{"a.be", true},
+		{"ab.be", false},
+		{"a.com.au", true},
+		{"ab.com.au", false},
+		{"a.au", false},
+		// Counted in characters of the U-label, not of its punycode
+		{"xn--4ca.nl", true},
+		{"xn--ab-via.es", false},
+	}
+	for _, tt := range tests {
+		err := CheckMinLength(tt.domain)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckMinLength(%q) error = %v, want error %v", tt.domain, err, tt.wantErr)
+		}
+	}
+}
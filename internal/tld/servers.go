@@ -0,0 +1,107 @@
+package tld
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// serverLookups limits concurrent queries to IANA while refreshing servers
+const serverLookups = 8
+
+// ServerRegistry maps TLDs (without the dot) to their authoritative whois
+// server. An empty server marks a TLD that has none.
+type ServerRegistry struct {
+	Updated time.Time         `json:"updated"`
+	Servers map[string]string `json:"servers"`
+}
+
+// ServersPath returns where the whois server registry is kept
+// (~/.config/gofindadomain/whois-servers.json)
+func ServersPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "whois-servers.json"), nil
+}
+
+// LoadServers reads the whois server registry. It returns an empty registry
+// when none has been saved yet.
+func LoadServers() (*ServerRegistry, error) {
+	path, err := ServersPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &ServerRegistry{Servers: make(map[string]string)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read whois servers: %w", err)
+	}
+
+	var r ServerRegistry
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if r.Servers == nil {
+		r.Servers = make(map[string]string)
+	}
+	return &r, nil
+}
+
+// UpdateServers looks up the whois server of every TLD with lookup and
+// saves the registry. TLDs whose lookup fails keep their previous server.
+// It returns the number of TLDs that failed.
+func UpdateServers(ctx context.Context, tlds []string, lookup func(context.Context, string) (string, error)) (failed int, err error) {
+	r, err := LoadServers()
+	if err != nil {
+		return 0, err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, serverLookups)
+	for _, t := range tlds {
+		t = strings.TrimPrefix(strings.ToLower(t), ".")
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			server, err := lookup(ctx, t)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failed++
+				return
+			}
+			r.Servers[t] = server
+		}()
+	}
+	wg.Wait()
+
+	r.Updated = time.Now().UTC()
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return failed, err
+	}
+
+	path, err := ServersPath()
+	if err != nil {
+		return failed, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return failed, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	return failed, writeFileAtomic(path, data)
+}
@@ -0,0 +1,54 @@
+package tld
+
+import (
+	"bufio"
+	"strings"
+)
+
+// PublicSuffixURL is the Public Suffix List, the registry of suffixes under
+// which names can be registered
+const PublicSuffixURL = "https://publicsuffix.org/list/public_suffix_list.dat"
+
+// commercialLabels are the second-level labels countries open to companies
+// and the public (co.uk, com.au, ne.jp); the many geographic and
+// institutional suffixes of the Public Suffix List are left out
+var commercialLabels = []string{
+	"biz", "co", "com", "firm", "gen", "info", "ltd", "me", "ne", "net", "or", "org", "plc", "web",
+}
+
+// UpdateSuffixFile fetches the Public Suffix List and saves its commercial
+// second-level suffixes (.co.uk, .com.br, .co.jp, ...) to filepath, like
+// UpdateTLDFile
+func UpdateSuffixFile(filepath string) (updated bool, err error) {
+	return updateListFile(PublicSuffixURL, filepath, "public suffix list", parsePublicSuffixes)
+}
+
+// parsePublicSuffixes reads the ICANN section of the Public Suffix List,
+// keeping the plain two-label rules of a commercial label under a
+// country-code TLD
+func parsePublicSuffixes(body string) []string {
+	var suffixes []string
+	icann := false
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.Contains(line, "===BEGIN ICANN DOMAINS==="):
+			icann = true
+			continue
+		case strings.Contains(line, "===END ICANN DOMAINS==="):
+			icann = false
+			continue
+		}
+		if !icann || line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		label, tld, ok := strings.Cut(strings.ToLower(line), ".")
+		if !ok || strings.Contains(tld, ".") || !isCountryCode(tld) || !contains(commercialLabels, label) {
+			continue
+		}
+		suffixes = append(suffixes, "."+label+"."+tld)
+	}
+	return suffixes
+}
@@ -2,35 +2,106 @@ package tld
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 )
 
 const IANAURL = "https://data.iana.org/TLD/tlds-alpha-by-domain.txt"
 
-// UpdateTLDFile fetches the latest TLD list from IANA and saves it to the specified file
-func UpdateTLDFile(filepath string) error {
-	resp, err := http.Get(IANAURL)
+// updateMeta records the validators of the last successful download so
+// later updates can be made conditional
+type updateMeta struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// UpdateTLDFile fetches the latest TLD list from IANA and saves it to the specified file.
+// The request is conditional on the previous download, so updated is false when IANA
+// reports the list unchanged. The new list is written atomically and the previous
+// file is kept as a timestamped backup.
+func UpdateTLDFile(filepath string) (updated bool, err error) {
+	return updateListFile(IANAURL, filepath, "TLD list", parseIANATLDs)
+}
+
+// updateListFile downloads the list at url, conditional on the previous
+// download, and saves the suffixes parse finds in it to filepath
+func updateListFile(url, filepath, what string, parse func(body string) []string) (updated bool, err error) {
+	metaPath := filepath + ".meta"
+	meta := readUpdateMeta(metaPath)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch %s: %w", what, err)
+	}
+	if meta.ETag != "" {
+		req.Header.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		req.Header.Set("If-Modified-Since", meta.LastModified)
+	} else if info, err := os.Stat(filepath); err == nil && meta.ETag == "" {
+		req.Header.Set("If-Modified-Since", info.ModTime().UTC().Format(http.TimeFormat))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to fetch TLD list: %w", err)
+		return false, fmt.Errorf("failed to fetch %s: %w", what, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		if _, err := os.Stat(filepath); err == nil {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to fetch %s: HTTP 304 but %s does not exist", what, filepath)
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to fetch TLD list: HTTP %d", resp.StatusCode)
+		return false, fmt.Errorf("failed to fetch %s: HTTP %d", what, resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	tlds := parse(string(body))
+	if len(tlds) == 0 {
+		return false, fmt.Errorf("failed to fetch %s: response contained no TLDs", what)
+	}
+
+	var buf strings.Builder
+	for _, tld := range tlds {
+		fmt.Fprintln(&buf, tld)
+	}
+
+	if err := backupFile(filepath); err != nil {
+		return false, err
+	}
+	if err := writeFileAtomic(filepath, []byte(buf.String())); err != nil {
+		return false, err
 	}
 
-	// Process the TLD list
+	meta = updateMeta{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	if err := writeUpdateMeta(metaPath, meta); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+// parseIANATLDs reads the IANA TLD list, one TLD per line after # comments
+func parseIANATLDs(body string) []string {
 	var tlds []string
-	scanner := bufio.NewScanner(strings.NewReader(string(body)))
+	scanner := bufio.NewScanner(strings.NewReader(body))
 	for scanner.Scan() {
 		line := scanner.Text()
 		// Skip comments
@@ -45,22 +116,80 @@ func UpdateTLDFile(filepath string) error {
 		tld := "." + strings.ToLower(line)
 		tlds = append(tlds, tld)
 	}
+	return tlds
+}
 
-	// Write to file
-	file, err := os.Create(filepath)
+// writeFileAtomic writes data to a temp file in the target directory and
+// renames it into place, so readers never see a partial file
+func writeFileAtomic(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".tmp-*")
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
-	defer file.Close()
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
 
-	for _, tld := range tlds {
-		fmt.Fprintln(file, tld)
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmpName, 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(tmpName, name); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", name, err)
+	}
+	return nil
+}
+
+// backupFile copies an existing file to a timestamped .bak next to it
+func backupFile(name string) error {
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up %s: %w", name, err)
+	}
+
+	backup := fmt.Sprintf("%s.%s.bak", name, time.Now().Format("20060102-150405"))
+	if err := os.WriteFile(backup, data, 0o644); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", name, err)
 	}
+	return nil
+}
+
+func readUpdateMeta(metaPath string) updateMeta {
+	var meta updateMeta
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return meta
+	}
+	_ = json.Unmarshal(data, &meta)
+	return meta
+}
 
+func writeUpdateMeta(metaPath string, meta updateMeta) error {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(metaPath, data); err != nil {
+		return fmt.Errorf("failed to save update metadata: %w", err)
+	}
 	return nil
 }
 
-// LoadTLDsFromFile loads TLDs from a file
+// LoadTLDsFromFile loads TLDs from a file. IDN TLDs may be written in
+// either form and are returned in punycode.
 func LoadTLDsFromFile(filepath string) ([]string, error) {
 	file, err := os.Open(filepath)
 	if err != nil {
@@ -72,9 +201,14 @@ func LoadTLDsFromFile(filepath string) ([]string, error) {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			tlds = append(tlds, line)
+		if line == "" {
+			continue
+		}
+		t, err := ToASCII(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLD file: %w", err)
 		}
+		tlds = append(tlds, t)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -90,10 +224,9 @@ func LoadTLDsFromString(data string) []string {
 	scanner := bufio.NewScanner(strings.NewReader(data))
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
-		if line != "" {
-			tlds = append(tlds, line)
+		if t, err := ToASCII(line); err == nil && t != "" {
+			tlds = append(tlds, t)
 		}
 	}
 	return tlds
 }
-
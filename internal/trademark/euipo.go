@@ -0,0 +1,147 @@
+package trademark
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+const (
+	EUIPOTokenURL  = "https://euipo.europa.eu/cas-server-webapp/oidc/accessToken"
+	EUIPOSearchURL = "https://api.euipo.europa.eu/trademark-search/trademarks"
+)
+
+// euipoDeadStatuses are the statuses of EU trade marks that no longer
+// protect anything
+var euipoDeadStatuses = []string{"EXPIRED", "WITHDRAWN", "REFUSED", "CANCELLED", "SURRENDERED", "REMOVED"}
+
+// euipoSource searches the EUIPO Trademark Search API, which covers EU trade
+// marks. It needs the client ID and secret of an EUIPO API portal application.
+type euipoSource struct {
+	clientID     string
+	clientSecret string
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func newEUIPO(cfg config.EUIPO) (*euipoSource, error) {
+	s := &euipoSource{clientID: cfg.ClientID, clientSecret: cfg.ClientSecret}
+	if env := os.Getenv("GOFINDADOMAIN_EUIPO_CLIENT_ID"); env != "" {
+		s.clientID = env
+	}
+	if env := os.Getenv("GOFINDADOMAIN_EUIPO_CLIENT_SECRET"); env != "" {
+		s.clientSecret = env
+	}
+	if s.clientID == "" || s.clientSecret == "" {
+		return nil, fmt.Errorf("euipo needs trademark.euipo.client_id and client_secret in the config or $GOFINDADOMAIN_EUIPO_CLIENT_ID and $GOFINDADOMAIN_EUIPO_CLIENT_SECRET")
+	}
+	return s, nil
+}
+
+// accessToken returns the current OAuth token, fetching a new one with the
+// client credentials when it is missing or about to expire
+func (s *euipoSource) accessToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && time.Until(s.expires) > time.Minute {
+		return s.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+		"scope":         {"uid"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, EUIPOTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to authenticate with euipo: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to authenticate with euipo: HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to parse euipo token: %w", err)
+	}
+	s.token = body.AccessToken
+	s.expires = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// Search implements Source
+func (s *euipoSource) Search(ctx context.Context, name string) ([]Mark, error) {
+	token, err := s.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{
+		"query": {"wordMarkSpecification.verbalElement==*" + name + "*"},
+		"size":  {"100"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, EUIPOSearchURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-IBM-Client-Id", s.clientID)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search euipo: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to search euipo: HTTP %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Trademarks []struct {
+			ApplicationNumber     string `json:"applicationNumber"`
+			Status                string `json:"status"`
+			WordMarkSpecification struct {
+				VerbalElement string `json:"verbalElement"`
+			} `json:"wordMarkSpecification"`
+		} `json:"trademarks"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse euipo results: %w", err)
+	}
+	var marks []Mark
+	for _, tm := range body.Trademarks {
+		if tm.WordMarkSpecification.VerbalElement == "" {
+			continue
+		}
+		marks = append(marks, Mark{
+			Text:   tm.WordMarkSpecification.VerbalElement,
+			Office: "EUIPO",
+			Number: tm.ApplicationNumber,
+			Status: tm.Status,
+			Live:   !slices.Contains(euipoDeadStatuses, strings.ToUpper(tm.Status)),
+		})
+	}
+	return marks, nil
+}
@@ -0,0 +1,227 @@
+// Package trademark screens the names of available domains against
+// trademark registers and flags exact or close marks.
+package trademark
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/brand"
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/plugin"
+)
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// Mark is a trademark found in a register
+type Mark struct {
+	Text   string
+	Office string
+	Number string
+	Status string
+	// Live is false for expired, withdrawn, refused or cancelled marks
+	Live bool
+}
+
+// Source is a trademark register
+type Source interface {
+	// Search returns the word marks containing name
+	Search(ctx context.Context, name string) ([]Mark, error)
+}
+
+// Open returns a Screener searching the sources configured in cfg, starting
+// plugin sources. The returned func stops them. Without configured sources it
+// searches the USPTO, and EUIPO too when its credentials are set.
+func Open(cfg config.Trademark) (*Screener, func(), error) {
+	names := cfg.Sources
+	if len(names) == 0 {
+		names = []string{"uspto"}
+		if _, err := newEUIPO(cfg.EUIPO); err == nil {
+			names = append(names, "euipo")
+		}
+	}
+
+	s := &Screener{screened: make(map[string]*screening)}
+	var plugins []*plugin.Plugin
+	stop := func() {
+		for _, p := range plugins {
+			p.Close()
+		}
+	}
+	for _, name := range names {
+		if pluginName, ok := strings.CutPrefix(name, "plugin:"); ok {
+			info, err := plugin.Find(plugin.KindTrademark, pluginName)
+			if err != nil {
+				stop()
+				return nil, nil, err
+			}
+			p, err := plugin.Start(info)
+			if err != nil {
+				stop()
+				return nil, nil, err
+			}
+			plugins = append(plugins, p)
+			s.sources = append(s.sources, pluginSource{p})
+			continue
+		}
+
+		switch name {
+		case "uspto":
+			s.sources = append(s.sources, usptoSource{})
+		case "euipo":
+			src, err := newEUIPO(cfg.EUIPO)
+			if err != nil {
+				stop()
+				return nil, nil, err
+			}
+			s.sources = append(s.sources, src)
+		default:
+			stop()
+			return nil, nil, fmt.Errorf("unknown trademark source %q (use uspto, euipo or plugin:<name>)", name)
+		}
+	}
+	return s, stop, nil
+}
+
+// Screener searches the registers for names. A name every register answered
+// for is not searched again.
+type Screener struct {
+	sources []Source
+
+	mu       sync.Mutex
+	screened map[string]*screening
+}
+
+type screening struct {
+	// sem is held while the name is searched, so concurrent checks of the
+	// same name under other TLDs wait for one search instead of repeating it
+	sem  chan struct{}
+	done bool
+	hits []checker.TrademarkHit
+}
+
+// Screen returns the live marks matching name. When a register fails, the
+// hits of the others are returned along with the error, and the name is
+// searched again on the next call.
+func (s *Screener) Screen(ctx context.Context, name string) ([]checker.TrademarkHit, error) {
+	name = normalize(name)
+	s.mu.Lock()
+	sc, found := s.screened[name]
+	if !found {
+		sc = &screening{sem: make(chan struct{}, 1)}
+		s.screened[name] = sc
+	}
+	s.mu.Unlock()
+
+	select {
+	case sc.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-sc.sem }()
+	if sc.done {
+		return sc.hits, nil
+	}
+
+	var hits []checker.TrademarkHit
+	var failures []string
+	for _, src := range s.sources {
+		marks, err := src.Search(ctx, name)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		hits = append(hits, Match(name, marks)...)
+	}
+	if len(failures) > 0 {
+		return hits, errors.New(strings.Join(failures, "; "))
+	}
+	sc.hits, sc.done = hits, true
+	return hits, nil
+}
+
+// Match returns the live marks that equal name or are close to it, exact
+// hits first. A mark is close when it, or one of its words, is confusable
+// with name by spelling or sound.
+func Match(name string, marks []Mark) []checker.TrademarkHit {
+	name = normalize(name)
+	var exact, near []checker.TrademarkHit
+	for _, m := range marks {
+		if !m.Live {
+			continue
+		}
+		hit := checker.TrademarkHit{Mark: m.Text, Office: m.Office, Number: m.Number, Status: m.Status}
+		switch {
+		case normalize(m.Text) == name:
+			hit.Exact = true
+			exact = append(exact, hit)
+		case closeTo(name, m.Text):
+			near = append(near, hit)
+		}
+	}
+	return append(exact, near...)
+}
+
+func closeTo(name, mark string) bool {
+	if brand.Confusable(name, normalize(mark)) {
+		return true
+	}
+	for _, word := range strings.FieldsFunc(mark, notAlnum) {
+		if brand.Confusable(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize lowercases s and drops everything but letters and digits, so
+// "Swift-Panda" and "SWIFT PANDA" both become "swiftpanda"
+func normalize(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), notAlnum), "")
+}
+
+func notAlnum(r rune) bool {
+	return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+}
+
+// Backend wraps a checker.Backend and screens the names of the domains it
+// finds available
+type Backend struct {
+	checker.Backend
+	Screener *Screener
+}
+
+// Check implements checker.Backend
+func (b Backend) Check(ctx context.Context, domain string) checker.Result {
+	r := b.Backend.Check(ctx, domain)
+	if !r.Available || r.Error != nil {
+		return r
+	}
+	name, _, _ := strings.Cut(domain, ".")
+	r.Trademarks, r.TrademarkError = b.Screener.Screen(ctx, name)
+	return r
+}
+
+// pluginSource searches with a trademark plugin
+type pluginSource struct {
+	plugin *plugin.Plugin
+}
+
+// Search implements Source
+func (s pluginSource) Search(ctx context.Context, name string) ([]Mark, error) {
+	found, err := s.plugin.SearchMarks(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	marks := make([]Mark, 0, len(found))
+	for _, m := range found {
+		marks = append(marks, Mark{Text: m.Mark, Office: m.Office, Number: m.Number, Status: m.Status, Live: m.Live})
+	}
+	return marks, nil
+}
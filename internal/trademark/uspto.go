@@ -0,0 +1,92 @@
+package trademark
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// USPTOSearchURL is the search endpoint behind the USPTO's Trademark Search
+// (tmsearch.uspto.gov), which replaced TESS and needs no credentials
+const USPTOSearchURL = "https://tmsearch.uspto.gov/api-v1-0-0/tmsearch"
+
+// usptoSource searches US trademarks filed with the USPTO
+type usptoSource struct{}
+
+// Search implements Source
+func (usptoSource) Search(ctx context.Context, name string) ([]Mark, error) {
+	query := map[string]any{
+		"query": map[string]any{
+			"query_string": map[string]any{
+				"query":  "*" + name + "*",
+				"fields": []string{"wordmark", "wordmarkPseudoText"},
+			},
+		},
+		"size":    100,
+		"_source": []string{"alive", "wordmark", "registrationId"},
+	}
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, USPTOSearchURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gofindadomain")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search uspto: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to search uspto: HTTP %d", resp.StatusCode)
+	}
+
+	return parseUSPTO(resp.Body)
+}
+
+// parseUSPTO decodes the marks in a tmsearch response, which has the shape
+// of an Elasticsearch search response
+func parseUSPTO(r io.Reader) ([]Mark, error) {
+	var body struct {
+		Hits struct {
+			Hits []struct {
+				// ID is the serial number of the application
+				ID     string `json:"_id"`
+				Source struct {
+					Alive          bool   `json:"alive"`
+					Wordmark       string `json:"wordmark"`
+					RegistrationID string `json:"registrationId"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(r).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse uspto results: %w", err)
+	}
+	var marks []Mark
+	for _, hit := range body.Hits.Hits {
+		tm := hit.Source
+		if tm.Wordmark == "" {
+			continue
+		}
+		// Registered marks are cited by registration number, pending
+		// applications by serial number
+		mark := Mark{Text: tm.Wordmark, Office: "USPTO", Number: hit.ID, Status: "PENDING", Live: tm.Alive}
+		if tm.RegistrationID != "" {
+			mark.Number = tm.RegistrationID
+			mark.Status = "REGISTERED"
+		}
+		if !tm.Alive {
+			mark.Status = "DEAD"
+		}
+		marks = append(marks, mark)
+	}
+	return marks, nil
+}
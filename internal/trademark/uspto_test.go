@@ -0,0 +1,28 @@
+package trademark
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseUSPTO(t *testing.T) {
+	f, err := os.Open("testdata/uspto_search.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	marks, err := parseUSPTO(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Mark{
+		{Text: "ACME", Office: "USPTO", Number: "2987654", Status: "REGISTERED", Live: true},
+		{Text: "ACME ROCKETS", Office: "USPTO", Number: "98123456", Status: "PENDING", Live: true},
+		{Text: "ACMEWARE", Office: "USPTO", Number: "2100345", Status: "DEAD", Live: false},
+	}
+	if !reflect.DeepEqual(marks, want) {
+		t.Errorf("parseUSPTO() =\n%+v\nwant\n%+v", marks, want)
+	}
+}
@@ -0,0 +1,13 @@
+package tui
+
+import (
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// ApplyBranding replaces the TUI banner and palette. An empty bannerText hides
+// the banner; empty colors keep the theme's.
+func ApplyBranding(bannerText string, colors config.BrandColors) {
+	banner = bannerText
+	brandColors = colors
+	applyTheme(activeTheme)
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+)
+
+// copyDomains puts domains on the system clipboard, one per line, and
+// reports the outcome in the status line
+func (m *Model) copyDomains(domains []string) {
+	if len(domains) == 0 {
+		m.status = helpStyle.Render("No available domains to copy")
+		return
+	}
+	if err := clipboard.WriteAll(strings.Join(domains, "\n")); err != nil {
+		m.status = takenStyle.Render(fmt.Sprintf("Failed to copy to the clipboard: %v", err))
+		return
+	}
+	if len(domains) == 1 {
+		m.status = helpStyle.Render("Copied " + domains[0])
+	} else {
+		m.status = helpStyle.Render(fmt.Sprintf("Copied %d available domains", len(domains)))
+	}
+}
+
+// availableDomains returns the listed domains that are available
+func (m Model) availableDomains() []string {
+	var domains []string
+	for _, e := range m.visibleEntries() {
+		if e.Available && e.Error == nil {
+			domains = append(domains, e.Domain)
+		}
+	}
+	return domains
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/rank"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+// visibleEntries returns the results as listed on the results screen, after
+// sorting and filtering
+func (m Model) visibleEntries() []rank.Entry {
+	entries := make([]rank.Entry, 0, len(m.results))
+	for _, r := range m.results {
+		e := m.entry(r)
+		if !shown(r, m.showOnlyAvail) {
+			continue
+		}
+		if m.tagFilter != "" && !m.tagged(e.Domain, m.tagFilter) {
+			continue
+		}
+		if m.starredOnly && !m.starred(e.Domain) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sortEntries(entries, m.sortBy)
+	return entries
+}
+
+// shown reports whether a result is listed; with the available-only filter
+// premium, dropping, parked and for-sale domains stay visible since they are
+// often acquirable
+func shown(r checker.Result, showOnlyAvail bool) bool {
+	return !showOnlyAvail || r.Error != nil || r.Available || r.Premium || r.Dropping() || r.Parked || r.ForSale
+}
+
+// formatDetail renders the full parsed record of a result
+func (m Model) formatDetail(e rank.Entry) string {
+	r := e.Result
+	var s strings.Builder
+	field := func(label, value string) {
+		if value != "" {
+			s.WriteString(fmt.Sprintf("%-13s %s\n", label, value))
+		}
+	}
+
+	s.WriteString(titleStyle.Render(tld.ToUnicode(r.Domain)))
+	s.WriteString("\n\n")
+	if name := tld.ToUnicode(r.Domain); name != r.Domain {
+		field("Punycode:", r.Domain)
+	}
+	field("Backend:", r.Backend)
+
+	switch {
+	case r.Error != nil:
+		field("Error:", r.Error.Error())
+		return s.String()
+	case r.Available:
+		field("Status:", availableStyle.Render("available"))
+		field("Confidence:", r.Confidence.String())
+		field("Score:", fmt.Sprint(e.Score))
+		if e.HasPrice {
+			field("Price:", e.FormatPrice())
+		}
+		if renew := e.FormatRenew(); renew != "" {
+			field("Renewal:", renew)
+		}
+		if e.BuyURL != "" {
+			field("Buy:", e.BuyURL)
+		}
+		if r.Certs != nil {
+			field("Certificates:", r.Certs.String())
+		}
+		if r.Archive != nil {
+			field("Wayback:", r.Archive.String())
+		}
+		if r.Reputation != nil {
+			field("Reputation:", r.Reputation.String())
+		}
+		for i, t := range r.Trademarks {
+			label := ""
+			if i == 0 {
+				label = "Trademarks:"
+			}
+			s.WriteString(fmt.Sprintf("%-13s %s\n", label, t.String()))
+		}
+		return s.String()
+	case r.Restriction() != "":
+		field("Status:", expiryStyle.Render(r.Restriction()))
+		field("Score:", fmt.Sprint(e.Score))
+		if r.Premium {
+			field("Note:", "unregistered, but sold above the base price")
+		} else {
+			field("Note:", "unregistered, but withheld by the registry")
+		}
+		return s.String()
+	}
+
+	status := takenStyle.Render("taken")
+	if r.Dropping() {
+		status += " " + availableStyle.Render("(dropping soon)")
+	}
+	if r.Parked {
+		status += " (parked — possibly for sale)"
+	}
+	field("Status:", status)
+	field("Registrar:", r.Registrar)
+	field("Abuse:", r.AbuseEmail)
+	registrant := r.RegistrantOrg + r.RegistrantCountry
+	if r.RegistrantOrg != "" && r.RegistrantCountry != "" {
+		registrant = r.RegistrantOrg + " (" + r.RegistrantCountry + ")"
+	}
+	switch {
+	case r.RegistrantPrivacy && registrant == "":
+		registrant = "redacted for privacy"
+	case r.RegistrantPrivacy:
+		registrant += ", contact redacted"
+	}
+	field("Registrant:", registrant)
+	created := r.CreatedDate
+	if age := checker.Age(created, time.Now()); age != "" {
+		created += " (" + age + ")"
+	}
+	field("Created:", created)
+	field("Re-reg'd:", r.Reregistered)
+	field("Updated:", r.UpdatedDate)
+	if r.ExpiryDate != "" {
+		expiry := expiryStyle.Render(r.ExpiryDate)
+		if note := m.expiringNote(r); note != "" {
+			expiry += " " + takenStyle.Render("("+note+")")
+		}
+		field("Expiry:", expiry)
+	}
+	field("EPP Status:", strings.Join(r.Status, ", "))
+	if r.Registrar != "" || len(r.Nameservers) > 0 {
+		dnssec := "unsigned"
+		if r.DNSSEC {
+			dnssec = "signed"
+		}
+		field("DNSSEC:", dnssec)
+	}
+	for i, ns := range r.Nameservers {
+		label := ""
+		if i == 0 {
+			label = "Nameservers:"
+		}
+		s.WriteString(fmt.Sprintf("%-13s %s\n", label, ns))
+	}
+	if r.ForSale {
+		sale := r.SaleURL
+		if r.SaleContact != "" {
+			sale += " <" + r.SaleContact + ">"
+		}
+		field("For sale:", sale)
+	}
+	if r.Listing != nil {
+		field("Listed:", r.Listing.String())
+	}
+	if r.Usage != nil {
+		field("Usage:", r.Usage.String())
+	}
+	return s.String()
+}
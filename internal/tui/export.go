@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/rank"
+)
+
+// exportedResult is a result as written by the results screen export
+type exportedResult struct {
+	Domain      string   `json:"domain"`
+	Available   bool     `json:"available"`
+	Error       string   `json:"error,omitempty"`
+	Score       int      `json:"score"`
+	Price       *float64 `json:"price,omitempty"`
+	Currency    string   `json:"currency,omitempty"`
+	ExpiryDate  string   `json:"expiry_date,omitempty"`
+	CreatedDate string   `json:"created_date,omitempty"`
+	Registrar   string   `json:"registrar,omitempty"`
+	Parked      bool     `json:"parked,omitempty"`
+	ForSale     bool     `json:"for_sale,omitempty"`
+	SaleURL     string   `json:"sale_url,omitempty"`
+	Premium     bool     `json:"premium,omitempty"`
+	Reserved    bool     `json:"reserved,omitempty"`
+	Confidence  string   `json:"confidence,omitempty"`
+	RenewPrice  *float64 `json:"renew_price,omitempty"`
+	BuyURL      string   `json:"buy_url,omitempty"`
+	Backend     string   `json:"backend,omitempty"`
+}
+
+var exportColumns = []string{"domain", "available", "error", "score", "price", "currency", "expiry_date", "created_date", "registrar", "parked", "for_sale", "sale_url", "premium", "reserved", "confidence", "renew_price", "buy_url", "backend"}
+
+func newExportedResult(e rank.Entry) exportedResult {
+	r := e.Result
+	out := exportedResult{
+		Domain:      r.Domain,
+		Available:   r.Available && r.Error == nil,
+		Score:       e.Score,
+		ExpiryDate:  r.ExpiryDate,
+		CreatedDate: r.CreatedDate,
+		Registrar:   r.Registrar,
+		Parked:      r.Parked,
+		ForSale:     r.ForSale,
+		SaleURL:     r.SaleURL,
+		Premium:     r.Premium,
+		Reserved:    r.Reserved,
+		Confidence:  r.Confidence.String(),
+		Backend:     r.Backend,
+	}
+	if r.Error != nil {
+		out.Error = r.Error.Error()
+	}
+	if e.HasPrice {
+		out.Price = &e.Price
+		out.Currency = e.Currency
+		out.BuyURL = e.BuyURL
+		if e.Renew > 0 {
+			out.RenewPrice = &e.Renew
+		}
+	}
+	return out
+}
+
+// exportResults writes entries to path as CSV, or as a JSON array when the
+// path ends in .json
+func exportResults(path string, entries []rank.Entry) error {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to export results: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		out := make([]exportedResult, len(entries))
+		for i, e := range entries {
+			out[i] = newExportedResult(e)
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(out)
+	} else {
+		err = writeCSV(f, entries)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export results: %w", err)
+	}
+	return nil
+}
+
+func writeCSV(f *os.File, entries []rank.Entry) error {
+	w := csv.NewWriter(f)
+	w.Write(exportColumns)
+	for _, e := range entries {
+		r := newExportedResult(e)
+		price, renew := "", ""
+		if r.Price != nil {
+			price = strconv.FormatFloat(*r.Price, 'f', 2, 64)
+		}
+		if r.RenewPrice != nil {
+			renew = strconv.FormatFloat(*r.RenewPrice, 'f', 2, 64)
+		}
+		w.Write([]string{
+			r.Domain, strconv.FormatBool(r.Available), r.Error, strconv.Itoa(r.Score), price, r.Currency,
+			r.ExpiryDate, r.CreatedDate, r.Registrar, strconv.FormatBool(r.Parked), strconv.FormatBool(r.ForSale), r.SaleURL,
+			strconv.FormatBool(r.Premium), strconv.FormatBool(r.Reserved), r.Confidence, renew, r.BuyURL, r.Backend,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+// fuzzyScore reports whether the letters of query appear in order in name,
+// and how closely it matches; lower scores are better. Exact, prefix and
+// substring matches rank ahead of scattered ones.
+func fuzzyScore(query, name string) (int, bool) {
+	query = strings.TrimPrefix(strings.ToLower(query), ".")
+	name = strings.TrimPrefix(strings.ToLower(name), ".")
+	switch {
+	case query == "":
+		return 0, true
+	case name == query:
+		return 0, true
+	case strings.HasPrefix(name, query):
+		return 1, true
+	case strings.Contains(name, query):
+		return 2, true
+	}
+
+	// Subsequence match, penalized by the letters skipped between matches
+	letters, want := []rune(name), []rune(query)
+	gaps, j := 0, 0
+	for i := 0; i < len(letters) && j < len(want); i++ {
+		if letters[i] == want[j] {
+			j++
+		} else if j > 0 {
+			gaps++
+		}
+	}
+	if j < len(want) {
+		return 0, false
+	}
+	return 3 + gaps, true
+}
+
+// matchTLDs returns the indexes of the TLDs matching query, best matches
+// first and in list order otherwise. IDN TLDs match in either form.
+func matchTLDs(tlds []string, query string) []int {
+	type match struct{ index, score int }
+	var matches []match
+	for i, t := range tlds {
+		score, ok := fuzzyScore(query, t)
+		if name := tld.ToUnicode(t); name != t {
+			if s, found := fuzzyScore(query, name); found && (!ok || s < score) {
+				score, ok = s, true
+			}
+		}
+		if ok {
+			matches = append(matches, match{i, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+
+	indexes := make([]int, len(matches))
+	for i, m := range matches {
+		indexes[i] = m.index
+	}
+	return indexes
+}
+
+// visibleTLDs returns the indexes of the TLDs listed on the selection
+// screen: the filter matches, or every TLD without a filter
+func (m Model) visibleTLDs() []int {
+	if m.tldMatches != nil {
+		return m.tldMatches
+	}
+	indexes := make([]int, len(m.tlds))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+// clearTLDFilter drops the filter, keeping the cursor on the same TLD
+func (m *Model) clearTLDFilter() {
+	visible := m.visibleTLDs()
+	cursor := 0
+	if m.tldCursor < len(visible) {
+		cursor = visible[m.tldCursor]
+	}
+	m.filtering = false
+	m.tldFilter.Blur()
+	m.tldFilter.SetValue("")
+	m.tldMatches = nil
+	m.tldCursor = cursor
+}
+
+// updateTLDFilter handles keys while the TLD filter input is open. Up, down
+// and space still move and select so matches can be picked while typing.
+func (m Model) updateTLDFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		if m.cancel != nil {
+			m.cancel()
+		}
+		return m, tea.Quit
+	case "esc":
+		m.clearTLDFilter()
+		return m, nil
+	case "enter":
+		m.filtering = false
+		m.tldFilter.Blur()
+		if m.tldFilter.Value() == "" {
+			m.tldMatches = nil
+		}
+		return m, nil
+	case "up", "down", " ":
+		return m.updateTLDList(msg)
+	}
+
+	var cmd tea.Cmd
+	m.tldFilter, cmd = m.tldFilter.Update(msg)
+	if query := strings.TrimSpace(m.tldFilter.Value()); query != "" {
+		m.tldMatches = matchTLDs(m.tlds, query)
+	} else {
+		m.tldMatches = nil
+	}
+	m.tldCursor = 0
+	return m, cmd
+}
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/james-see/gofindadomain/internal/rank"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+// splitKeywords reads the keyword input: one keyword or several separated
+// by commas. Unicode keywords are kept in punycode, the form domains are
+// checked in.
+func splitKeywords(input string) []string {
+	var keywords []string
+	seen := make(map[string]bool)
+	for _, k := range strings.Split(input, ",") {
+		k, err := tld.ToASCII(strings.TrimSpace(k))
+		if err == nil && k != "" && !seen[k] {
+			seen[k] = true
+			keywords = append(keywords, k)
+		}
+	}
+	return keywords
+}
+
+// gridEntry returns the result in the grid cell under the cursor
+func (m Model) gridEntry() (rank.Entry, bool) {
+	if m.gridRow >= len(m.keywords) || m.gridCol >= len(m.gridTLDs) {
+		return rank.Entry{}, false
+	}
+	domain := m.keywords[m.gridRow] + m.gridTLDs[m.gridCol]
+	for _, r := range m.results {
+		if r.Domain == domain {
+			return m.entry(r), true
+		}
+	}
+	return rank.Entry{}, false
+}
+
+// updateGrid moves the grid cursor
+func (m Model) updateGrid(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.gridRow = max(0, m.gridRow-1)
+	case "down", "j":
+		m.gridRow = max(0, min(len(m.keywords)-1, m.gridRow+1))
+	case "left", "h":
+		m.gridCol = max(0, m.gridCol-1)
+	case "right", "l":
+		m.gridCol = max(0, min(len(m.gridTLDs)-1, m.gridCol+1))
+	case "home":
+		m.gridCol = 0
+	case "end":
+		m.gridCol = max(0, len(m.gridTLDs)-1)
+	}
+	return m, nil
+}
+
+// gridView renders keywords as rows and TLDs as columns, each cell marking
+// the domain's availability. Only the columns and rows around the cursor
+// that fit the screen are drawn.
+func (m Model) gridView() string {
+	byDomain := make(map[string]int, len(m.results))
+	for i, r := range m.results {
+		byDomain[r.Domain] = i
+	}
+
+	labelWidth := 0
+	for _, k := range m.keywords {
+		labelWidth = max(labelWidth, lipgloss.Width(tld.ToUnicode(k)))
+	}
+	labelWidth = min(labelWidth, 24) + 2
+	colWidth := 4
+	for _, t := range m.gridTLDs {
+		colWidth = max(colWidth, lipgloss.Width(tld.ToUnicode(t))+2)
+	}
+	colWidth = min(colWidth, 16)
+
+	cols := max(1, (m.width-labelWidth)/colWidth)
+	rows := max(1, m.resultsTableHeight()-2)
+	colStart := max(0, m.gridCol-cols+1)
+	colEnd := min(len(m.gridTLDs), colStart+cols)
+	rowStart := max(0, m.gridRow-rows+1)
+	rowEnd := min(len(m.keywords), rowStart+rows)
+
+	label := lipgloss.NewStyle().Width(labelWidth).MaxWidth(labelWidth)
+	cell := lipgloss.NewStyle().Width(colWidth).MaxWidth(colWidth).Align(lipgloss.Center)
+	header := lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
+
+	var s strings.Builder
+	s.WriteString(label.Render(""))
+	for c := colStart; c < colEnd; c++ {
+		s.WriteString(header.Render(cell.Render(tld.ToUnicode(m.gridTLDs[c]))))
+	}
+	s.WriteString("\n")
+
+	for r := rowStart; r < rowEnd; r++ {
+		keyword := m.keywords[r]
+		s.WriteString(header.Render(label.Render(tld.ToUnicode(keyword))))
+		for c := colStart; c < colEnd; c++ {
+			mark, style := "·", helpStyle
+			if i, ok := byDomain[keyword+m.gridTLDs[c]]; ok {
+				res := m.results[i]
+				switch {
+				case res.Error != nil:
+					mark, style = "!", expiryStyle
+				case res.Available:
+					mark, style = "✓", availableStyle
+				case res.Premium:
+					mark, style = "+", expiryStyle
+				case res.Reserved:
+					mark, style = "r", helpStyle
+				case res.ForSale:
+					mark, style = "$", expiryStyle
+				default:
+					mark, style = "✗", takenStyle
+				}
+			}
+			if r == m.gridRow && c == m.gridCol {
+				style = style.Reverse(true)
+			}
+			s.WriteString(cell.Render(style.Render(" " + mark + " ")))
+		}
+		s.WriteString("\n")
+	}
+
+	if colStart > 0 || colEnd < len(m.gridTLDs) {
+		s.WriteString(helpStyle.Render(fmt.Sprintf("%sTLDs %d-%d of %d (←/→ to scroll)",
+			strings.Repeat(" ", labelWidth), colStart+1, colEnd, len(m.gridTLDs))))
+		s.WriteString("\n")
+	}
+	s.WriteString(helpStyle.Render("✓ available • + premium • r reserved • ✗ taken • $ for sale • ! failed • · not checked"))
+	s.WriteString("\n")
+	if e, ok := m.gridEntry(); ok {
+		s.WriteString(m.formatResult(e, false))
+	}
+	return s.String()
+}
@@ -0,0 +1,385 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/rank"
+	"github.com/james-see/gofindadomain/internal/score"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+// jobStatus is where a job is in the queue
+type jobStatus int
+
+const (
+	jobQueued jobStatus = iota
+	jobRunning
+	jobDone
+	jobCancelled
+)
+
+// job is a search in the queue. Jobs run one at a time, in the order they
+// were queued.
+type job struct {
+	id       int
+	keywords []string
+	tlds     []string
+	// domains are checked next; results of domains already in previous
+	// replace them there
+	domains  []string
+	previous []checker.Result
+	status   jobStatus
+	results  *asyncResults
+	throttle *checker.Throttle
+	cancel   context.CancelFunc
+	started  time.Time
+	finished time.Time
+}
+
+// checkDoneMsg reports that a job's run finished or was cancelled
+type checkDoneMsg struct {
+	job *job
+}
+
+// label describes a job in the jobs list
+func (j *job) label() string {
+	return search{Keywords: j.keywords, TLDs: j.tlds}.label()
+}
+
+// progress returns the domains checked and found available in the job's
+// current run
+func (j *job) progress() (checked, available int) {
+	if j.results == nil {
+		return 0, 0
+	}
+	j.results.mu.Lock()
+	defer j.results.mu.Unlock()
+	for _, r := range j.results.results {
+		if r.Available && r.Error == nil {
+			available++
+		}
+	}
+	return j.results.checked, available
+}
+
+// domainsFor lists every keyword and TLD combination, leaving out those
+// shorter than the TLD's registry registers
+func domainsFor(keywords, tlds []string) []string {
+	var domains []string
+	for _, k := range keywords {
+		for _, t := range tlds {
+			if tld.CheckMinLength(k+t) == nil {
+				domains = append(domains, k+t)
+			}
+		}
+	}
+	return domains
+}
+
+// enqueue adds a search to the queue, starting it when nothing is running
+func (m *Model) enqueue(keywords, tlds []string) (*job, tea.Cmd) {
+	m.jobSeq++
+	j := &job{id: m.jobSeq, keywords: keywords, tlds: tlds, domains: domainsFor(keywords, tlds)}
+	m.jobs = append(m.jobs, j)
+	return j, m.startNext()
+}
+
+// running returns the job being checked, if any
+func (m Model) running() *job {
+	for _, j := range m.jobs {
+		if j.status == jobRunning {
+			return j
+		}
+	}
+	return nil
+}
+
+// startNext starts the first queued job unless one is running
+func (m *Model) startNext() tea.Cmd {
+	if m.running() != nil {
+		return nil
+	}
+	for _, j := range m.jobs {
+		if j.status == jobQueued {
+			return m.start(j)
+		}
+	}
+	return nil
+}
+
+// start checks a job's domains in the background. The job's results start
+// from previous, and its throttle becomes the one 'p' and +/- control.
+func (m *Model) start(j *job) tea.Cmd {
+	ctx, cancel := context.WithCancel(m.ctx)
+	j.cancel = cancel
+	j.status = jobRunning
+	j.started = time.Now()
+	j.finished = time.Time{}
+	j.throttle = checker.NewRunThrottle(m.concurrency)
+	m.throttle = j.throttle
+
+	results := &asyncResults{
+		results: make([]checker.Result, len(j.previous), len(j.previous)+len(j.domains)),
+		ranks:   make(map[string]rank.Entry, len(j.previous)+len(j.domains)),
+		index:   make(map[string]int, len(j.previous)),
+	}
+	copy(results.results, j.previous)
+	for i, r := range j.previous {
+		results.index[r.Domain] = i
+		results.ranks[r.Domain] = m.entry(r)
+	}
+	j.results = results
+
+	backend := m.backend
+	ranker := m.ranker
+	domains := j.domains
+	throttle := j.throttle
+
+	return func() tea.Msg {
+		resultChan := make(chan checker.Result, len(domains))
+
+		go func() {
+			checker.CheckDomainsThrottled(ctx, backend, domains, throttle, resultChan)
+			close(resultChan)
+		}()
+
+		for result := range resultChan {
+			entry := ranker.Rank(ctx, result, score.Score(result.Domain))
+			results.mu.Lock()
+			if i, ok := results.index[result.Domain]; ok {
+				results.results[i] = result
+			} else {
+				results.results = append(results.results, result)
+			}
+			results.ranks[result.Domain] = entry
+			results.checked++
+			results.recent = append(results.recent, result)
+			if len(results.recent) > 5 {
+				results.recent = results.recent[1:]
+			}
+			results.mu.Unlock()
+		}
+
+		return checkDoneMsg{job: j}
+	}
+}
+
+// finishJob records a finished run and starts the next queued job. A
+// viewed job that was being checked moves on to its results.
+func (m Model) finishJob(j *job) (Model, tea.Cmd) {
+	j.cancel()
+	j.finished = time.Now()
+	if j.status == jobRunning {
+		j.status = jobDone
+	}
+	if m.throttle == j.throttle {
+		m.throttle = nil
+	}
+	cmd := m.startNext()
+
+	if j == m.viewing && m.state == stateChecking {
+		m = m.view(j)
+	} else if j.status == jobDone {
+		_, available := j.progress()
+		m.status = availableStyle.Render(fmt.Sprintf("Search #%d (%s) finished: %d available", j.id, tld.ToUnicode(strings.Join(j.keywords, ",")), available))
+	}
+	if cmd != nil {
+		cmd = tea.Batch(cmd, m.startTicking())
+	}
+	return m, cmd
+}
+
+// view shows a job: its progress while it is checked, its results after
+func (m Model) view(j *job) Model {
+	m.viewing = j
+	m.keywords = j.keywords
+	m.keyword = tld.ToUnicode(strings.Join(j.keywords, ","))
+	m.gridTLDs = j.tlds
+	m.gridRow, m.gridCol = 0, 0
+	m.totalCount = len(j.domains)
+	m.startTime = j.started
+	m.finished = j.finished
+	m.syncResults()
+
+	m.checking = j.status == jobRunning
+	if m.checking {
+		m.state = stateChecking
+		return m
+	}
+	m.state = stateResults
+	m.refreshTable()
+	m.table.GotoTop()
+	return m
+}
+
+// syncResults copies the viewed job's results so far
+func (m *Model) syncResults() {
+	m.results, m.ranks, m.recent, m.checkedCount = nil, make(map[string]rank.Entry), nil, 0
+	if m.viewing == nil || m.viewing.results == nil {
+		return
+	}
+	shared := m.viewing.results
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	m.results = append([]checker.Result(nil), shared.results...)
+	for d, e := range shared.ranks {
+		m.ranks[d] = e
+	}
+	m.recent = append([]checker.Result(nil), shared.recent...)
+	m.checkedCount = shared.checked
+}
+
+// startTicking refreshes progress until no job is running; it starts no
+// second tick loop while one is going
+func (m *Model) startTicking() tea.Cmd {
+	if m.ticking {
+		return nil
+	}
+	m.ticking = true
+	return tea.Batch(tickEvery(), m.spinner.Tick)
+}
+
+// resume returns to a screen left for another, catching up on a job that
+// finished or went on meanwhile
+func (m Model) resume(s state) (Model, tea.Cmd) {
+	m.state = s
+	switch {
+	case s == stateChecking && m.viewing != nil:
+		m = m.view(m.viewing)
+	case s != stateJobs:
+		return m, nil
+	}
+	cmd := m.startTicking()
+	return m, cmd
+}
+
+// openJobs shows the jobs screen with the cursor on the viewed job
+func (m Model) openJobs() (Model, tea.Cmd) {
+	m.state = stateJobs
+	m.keywordInput.Blur()
+	for i, j := range m.jobs {
+		if j == m.viewing {
+			m.jobCursor = i
+		}
+	}
+	cmd := m.startTicking()
+	return m, cmd
+}
+
+// newSearch goes to the keyword screen, leaving any running job going
+func (m Model) newSearch() (Model, tea.Cmd) {
+	m.state = stateInput
+	m.status = ""
+	m.keywordInput.SetValue("")
+	m.searchIndex = -1
+	m.keywordInput.Focus()
+	return m, textinput.Blink
+}
+
+// updateJobs handles keys on the jobs screen
+func (m Model) updateJobs(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.jobCursor = max(0, m.jobCursor-1)
+	case "down", "j":
+		m.jobCursor = max(0, min(len(m.jobs)-1, m.jobCursor+1))
+	case "enter":
+		if m.jobCursor >= len(m.jobs) {
+			return m, nil
+		}
+		j := m.jobs[m.jobCursor]
+		if j.status == jobQueued {
+			m.status = helpStyle.Render(fmt.Sprintf("Search #%d has not started yet", j.id))
+			return m, nil
+		}
+		m.status = ""
+		m = m.view(j)
+		cmd := m.startTicking()
+		return m, cmd
+	case "x":
+		if m.jobCursor >= len(m.jobs) {
+			return m, nil
+		}
+		j := m.jobs[m.jobCursor]
+		switch j.status {
+		case jobQueued:
+			j.status = jobCancelled
+		case jobRunning:
+			// The run stops and reports through checkDoneMsg
+			j.status = jobCancelled
+			j.cancel()
+		}
+	case "n":
+		return m.newSearch()
+	case "esc":
+		if m.viewing == nil {
+			return m.newSearch()
+		}
+		m = m.view(m.viewing)
+		cmd := m.startTicking()
+		return m, cmd
+	}
+	return m, nil
+}
+
+// jobsView lists the jobs with their progress
+func (m Model) jobsView() string {
+	var s strings.Builder
+	s.WriteString(titleStyle.Render("Searches:"))
+	s.WriteString("\n\n")
+
+	height := max(3, m.height-10)
+	if banner != "" {
+		height = max(3, height-strings.Count(banner, "\n")-1)
+	}
+	start := max(0, m.jobCursor-height+1)
+	labelWidth := 0
+	for _, j := range m.jobs {
+		labelWidth = max(labelWidth, lipgloss.Width(j.label()))
+	}
+	for i := start; i < min(len(m.jobs), start+height); i++ {
+		j := m.jobs[i]
+		checked, available := j.progress()
+		var state string
+		switch j.status {
+		case jobQueued:
+			state = helpStyle.Render("queued")
+		case jobRunning:
+			state = fmt.Sprintf("%s %d/%d", m.spinner.View(), checked, len(j.domains))
+			if _, _, paused := j.throttle.State(); paused {
+				state = expiryStyle.Render(fmt.Sprintf("paused %d/%d", checked, len(j.domains)))
+			}
+		case jobDone:
+			state = fmt.Sprintf("done %d/%d", checked, len(j.domains))
+		case jobCancelled:
+			state = takenStyle.Render(fmt.Sprintf("cancelled %d/%d", checked, len(j.domains)))
+		}
+		if j.status != jobQueued {
+			state += availableStyle.Render(fmt.Sprintf(" %d available", available))
+		}
+
+		cursor := "  "
+		if i == m.jobCursor {
+			cursor = "> "
+		}
+		line := fmt.Sprintf("%s#%-3d %-*s", cursor, j.id, labelWidth, j.label())
+		if i == m.jobCursor {
+			line = titleStyle.UnsetPadding().Render(line)
+		}
+		s.WriteString(line + "  " + state + "\n")
+	}
+
+	s.WriteString("\n")
+	if m.status != "" {
+		s.WriteString(m.status + "\n\n")
+	}
+	s.WriteString(helpStyle.Render("↑/↓ to move • Enter to view • 'x' to cancel • 'n' for a new search • Esc to go back • 'q' to quit"))
+	return s.String()
+}
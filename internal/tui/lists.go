@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/james-see/gofindadomain/internal/store"
+)
+
+// allTags returns the tags used across the saved lists
+func (m Model) allTags() []string {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, l := range m.lists {
+		for _, t := range l.Tags() {
+			if key := strings.ToLower(t); !seen[key] {
+				seen[key] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	return tags
+}
+
+// nextTag cycles through no filter and each tag in turn
+func nextTag(tags []string, current string) string {
+	if current == "" {
+		if len(tags) == 0 {
+			return ""
+		}
+		return tags[0]
+	}
+	for i, t := range tags {
+		if t == current && i+1 < len(tags) {
+			return tags[i+1]
+		}
+	}
+	return ""
+}
+
+// tagged reports whether domain carries tag on any saved list
+func (m Model) tagged(domain, tag string) bool {
+	for _, l := range m.lists {
+		if e, ok := l.Get(domain); ok && e.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// savedLabel describes the lists, tags and notes a domain is saved with
+func (m Model) savedLabel(domain string) string {
+	var parts []string
+	for _, l := range m.lists {
+		e, ok := l.Get(domain)
+		if !ok {
+			continue
+		}
+		label := l.Name
+		if len(e.Tags) > 0 {
+			label += ": " + strings.Join(e.Tags, ", ")
+		}
+		part := "[" + label + "]"
+		if e.Note != "" {
+			part += " " + e.Note
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// shortlist returns the loaded shortlist, or nil
+func (m Model) shortlist() *store.List {
+	for _, l := range m.lists {
+		if l.Name == store.Shortlist {
+			return l
+		}
+	}
+	return nil
+}
+
+// starred reports whether domain is on the shortlist
+func (m Model) starred(domain string) bool {
+	if l := m.shortlist(); l != nil {
+		_, ok := l.Get(domain)
+		return ok
+	}
+	return false
+}
+
+// toggleStar adds domain to the shortlist or removes it, saving the list
+// right away, and reports the outcome in the status line
+func (m *Model) toggleStar(domain string) {
+	if m.store == nil {
+		m.status = takenStyle.Render("The shortlist is unavailable")
+		return
+	}
+	star := !m.starred(domain)
+	err := store.Update(m.store, store.Shortlist, func(l *store.List) error {
+		if star {
+			l.Add(domain)
+		} else {
+			l.Remove(domain)
+		}
+		return nil
+	})
+	if err != nil {
+		m.status = takenStyle.Render(fmt.Sprintf("Failed to save the shortlist: %v", err))
+		return
+	}
+
+	// Reload so changes made elsewhere show up too
+	l, err := store.Open(m.store, store.Shortlist)
+	if err != nil {
+		m.status = takenStyle.Render(fmt.Sprintf("Failed to load the shortlist: %v", err))
+		return
+	}
+	if i := m.listIndex(store.Shortlist); i >= 0 {
+		m.lists[i] = l
+	} else {
+		m.lists = append(m.lists, l)
+	}
+	if star {
+		m.status = availableStyle.Render("★ Starred " + domain)
+	} else {
+		m.status = helpStyle.Render("Unstarred " + domain)
+	}
+}
+
+func (m Model) listIndex(name string) int {
+	for i, l := range m.lists {
+		if l.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/james-see/gofindadomain/internal/pricing"
+	"github.com/james-see/gofindadomain/internal/rank"
+)
+
+// defaultRegistrar is used when no registrar URL is configured
+const defaultRegistrar = "namecheap"
+
+// registrarURL returns the registrar page for domain. registrar is a preset
+// name or a URL template containing {domain}.
+func registrarURL(registrar, domain string) (string, error) {
+	if registrar == "" {
+		registrar = defaultRegistrar
+	}
+	if u := pricing.BuyURL(strings.ToLower(registrar), domain); u != "" {
+		return u, nil
+	}
+	if !strings.Contains(registrar, "{domain}") {
+		names := make([]string, 0, len(pricing.RegistrarURLs))
+		for name := range pricing.RegistrarURLs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("registrar URL %q is neither a preset (%s) nor a URL containing {domain}", registrar, strings.Join(names, ", "))
+	}
+
+	u := strings.ReplaceAll(registrar, "{domain}", url.QueryEscape(domain))
+	if parsed, err := url.Parse(u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return "", fmt.Errorf("registrar URL %q is not an http(s) URL", registrar)
+	}
+	return u, nil
+}
+
+// openedMsg reports the outcome of opening a page in the browser
+type openedMsg struct {
+	url string
+	err error
+}
+
+// openBrowser opens u in the default browser
+func openBrowser(u string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", u)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+		default:
+			cmd = exec.Command("xdg-open", u)
+		}
+		if err := cmd.Start(); err != nil {
+			return openedMsg{url: u, err: fmt.Errorf("failed to open a browser: %w", err)}
+		}
+		go cmd.Wait()
+		return openedMsg{url: u}
+	}
+}
+
+// openEntry opens the page to acquire a result: the checkout page of the
+// registrar that priced an available domain, else the configured registrar,
+// or the sale page of a domain for sale
+func (m Model) openEntry(e rank.Entry) (Model, tea.Cmd) {
+	r := e.Result
+	switch {
+	case r.Error != nil:
+		m.status = takenStyle.Render(fmt.Sprintf("%s was not checked; retry it first", r.Domain))
+		return m, nil
+	case r.ForSale && r.SaleURL != "":
+		// The sale URL comes from the domain's own page; open only web links
+		if u, err := url.Parse(r.SaleURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			m.status = takenStyle.Render(fmt.Sprintf("%s is for sale at %s", r.Domain, r.SaleURL))
+			return m, nil
+		}
+		return m, openBrowser(r.SaleURL)
+	case !r.Available:
+		m.status = takenStyle.Render(fmt.Sprintf("%s is taken", r.Domain))
+		return m, nil
+	case e.BuyURL != "":
+		return m, openBrowser(e.BuyURL)
+	}
+
+	u, err := registrarURL(m.registrar, r.Domain)
+	if err != nil {
+		m.status = takenStyle.Render(err.Error())
+		return m, nil
+	}
+	return m, openBrowser(u)
+}
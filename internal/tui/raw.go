@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// rawWhoisMsg carries a domain's whois response, fetched for the detail view
+type rawWhoisMsg struct {
+	domain    string
+	servers   []string
+	text      string
+	indicator string
+	err       error
+}
+
+// fetchRawWhois looks up a domain's whois response. Results do not keep the
+// response they were parsed from, so it is queried again on demand.
+func fetchRawWhois(ctx context.Context, domain string) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := checker.LookupWhois(ctx, domain)
+		if err != nil {
+			return rawWhoisMsg{domain: domain, err: err}
+		}
+		text, _ := checker.Decode(domain, resp.Raw)
+		_, indicator := checker.Classify(domain, text)
+		return rawWhoisMsg{domain: domain, servers: resp.Servers, text: text, indicator: indicator}
+	}
+}
+
+// rawHeader summarizes where a whois response came from and how the
+// classifier read it
+func (r rawWhoisMsg) header() string {
+	if r.err != nil {
+		return takenStyle.Render(fmt.Sprintf("whois lookup for %s failed: %v", r.domain, r.err))
+	}
+	indicator := "none matched (assumed available)"
+	if r.indicator != "" {
+		indicator = fmt.Sprintf("%q", r.indicator)
+	}
+	return fmt.Sprintf("%-13s %s\n%-13s %s", "Servers:", strings.Join(r.servers, " -> "), "Indicator:", indicator)
+}
+
+// rawViewHeight is the number of lines left for the whois response after
+// the banner, title, header and help
+func (m Model) rawViewHeight() int {
+	used := 8
+	if banner != "" {
+		used += strings.Count(banner, "\n") + 1
+	}
+	return max(5, m.height-used)
+}
+
+// showRawWhois opens the whois response pane for the selected result,
+// fetching the response unless it is already loaded
+func (m Model) showRawWhois() (Model, tea.Cmd) {
+	e, ok := m.selectedEntry()
+	if !ok {
+		return m, nil
+	}
+	m.showRaw = true
+	if m.raw != nil && m.raw.domain == e.Domain {
+		return m, nil
+	}
+	m.raw = &rawWhoisMsg{domain: e.Domain}
+	m.rawLoading = true
+	m.rawView = viewport.New(m.width, m.rawViewHeight())
+	return m, tea.Batch(fetchRawWhois(m.ctx, e.Domain), m.spinner.Tick)
+}
+
+// setRawWhois stores a fetched whois response, ignoring responses for a
+// result that is no longer shown
+func (m Model) setRawWhois(msg rawWhoisMsg) Model {
+	if m.raw == nil || m.raw.domain != msg.domain {
+		return m
+	}
+	m.raw = &msg
+	m.rawLoading = false
+	m.rawView = viewport.New(m.width, m.rawViewHeight())
+	m.rawView.SetContent(msg.text)
+	return m
+}
+
+// rawWhoisView renders the whois response pane
+func (m Model) rawWhoisView() string {
+	var s strings.Builder
+	s.WriteString(titleStyle.Render(m.raw.domain + " whois response"))
+	s.WriteString("\n\n")
+	if m.rawLoading {
+		s.WriteString(m.spinner.View() + " Querying whois...\n")
+		return s.String()
+	}
+	s.WriteString(m.raw.header())
+	s.WriteString("\n\n")
+	if m.raw.err == nil {
+		s.WriteString(m.rawView.View())
+		s.WriteString("\n")
+	}
+	return s.String()
+}
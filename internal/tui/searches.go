@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/config"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+// maxSearches is the number of recent searches kept
+const maxSearches = 50
+
+// search is a keyword search with the TLDs it was checked against
+type search struct {
+	Keywords []string  `json:"keywords"`
+	TLDs     []string  `json:"tlds"`
+	Searched time.Time `json:"searched"`
+}
+
+// label describes a search in the recent searches list
+func (s search) label() string {
+	tlds := tld.ToUnicode(strings.Join(s.TLDs, " "))
+	if len(s.TLDs) > 6 {
+		tlds = tld.ToUnicode(strings.Join(s.TLDs[:6], " ")) + fmt.Sprintf(" +%d", len(s.TLDs)-6)
+	}
+	return tld.ToUnicode(strings.Join(s.Keywords, ",")) + "  " + tlds
+}
+
+// searchesPath returns where recent searches are kept
+// (~/.config/gofindadomain/searches.json)
+func searchesPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "searches.json"), nil
+}
+
+// loadSearches reads the recent searches, newest first
+func loadSearches() ([]search, error) {
+	path, err := searchesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recent searches: %w", err)
+	}
+	var searches []search
+	if err := json.Unmarshal(data, &searches); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return searches, nil
+}
+
+// saveSearches writes the recent searches
+func saveSearches(searches []search) error {
+	path, err := searchesPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to save recent searches: %w", err)
+	}
+	data, err := json.MarshalIndent(searches, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to save recent searches: %w", err)
+	}
+	return nil
+}
+
+// addSearch puts s first among searches, dropping an earlier identical
+// search and the oldest beyond maxSearches
+func addSearch(searches []search, s search) []search {
+	searches = slices.DeleteFunc(slices.Clone(searches), func(o search) bool {
+		return slices.Equal(o.Keywords, s.Keywords) && slices.Equal(o.TLDs, s.TLDs)
+	})
+	searches = append([]search{s}, searches...)
+	if len(searches) > maxSearches {
+		searches = searches[:maxSearches]
+	}
+	return searches
+}
+
+// recallSearch fills the keyword input with a recent search; index -1
+// clears it
+func (m *Model) recallSearch(index int) {
+	m.searchIndex = index
+	if index < 0 {
+		m.keywordInput.SetValue("")
+		return
+	}
+	m.keywordInput.SetValue(tld.ToUnicode(strings.Join(m.searches[index].Keywords, ",")))
+	m.keywordInput.CursorEnd()
+}
+
+// recalledTLDs selects the TLDs of the recalled search, when the keyword
+// input still holds it
+func (m *Model) recalledTLDs() {
+	if m.searchIndex < 0 || m.searchIndex >= len(m.searches) {
+		return
+	}
+	s := m.searches[m.searchIndex]
+	if !slices.Equal(splitKeywords(m.keywordInput.Value()), s.Keywords) {
+		return
+	}
+	m.selectedTLDs = make(map[int]bool)
+	for i, t := range m.tlds {
+		if slices.Contains(s.TLDs, t) {
+			m.selectedTLDs[i] = true
+		}
+	}
+}
+
+// rememberSearch records the search being started
+func (m *Model) rememberSearch() {
+	m.searches = addSearch(m.searches, search{Keywords: m.keywords, TLDs: m.gridTLDs, Searched: time.Now().UTC()})
+	m.searchIndex = -1
+	if err := saveSearches(m.searches); err != nil {
+		m.status = takenStyle.Render(err.Error())
+	}
+}
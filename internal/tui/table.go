@@ -0,0 +1,225 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/rank"
+	"github.com/james-see/gofindadomain/internal/tld"
+)
+
+// sortKey orders the results table
+type sortKey int
+
+const (
+	sortChecked sortKey = iota
+	sortRank
+	sortDomain
+	sortAvailability
+	sortExpiry
+	sortPrice
+)
+
+var sortNames = []string{"check order", "rank", "domain", "availability", "expiry", "price"}
+
+// resultColumn is a column of the results table
+type resultColumn struct {
+	title string
+	// maxWidth caps the column; narrower content shrinks it
+	maxWidth int
+	value    func(m Model, e rank.Entry) string
+}
+
+// resultColumns are the results table columns; number keys toggle all but
+// the domain
+var resultColumns = []resultColumn{
+	{"Domain", 42, func(m Model, e rank.Entry) string {
+		if m.starred(e.Domain) {
+			return "★ " + tld.ToUnicode(e.Domain)
+		}
+		return "  " + tld.ToUnicode(e.Domain)
+	}},
+	{"Status", 16, func(m Model, e rank.Entry) string { return statusText(e.Result) }},
+	{"Score", 5, func(m Model, e rank.Entry) string { return fmt.Sprint(e.Score) }},
+	{"Price", 14, func(m Model, e rank.Entry) string {
+		if !e.HasPrice {
+			return ""
+		}
+		return e.FormatPrice()
+	}},
+	{"Expiry", 20, func(m Model, e rank.Entry) string {
+		// Marked up front, where the column width cannot cut it off
+		if m.expiringNote(e.Result) != "" {
+			return "! " + e.Result.ExpiryDate
+		}
+		return e.Result.ExpiryDate
+	}},
+	{"Age", 10, func(m Model, e rank.Entry) string { return checker.Age(e.Result.CreatedDate, time.Now()) }},
+	{"Registrar", 30, func(m Model, e rank.Entry) string { return e.Result.Registrar }},
+	{"Saved", 40, func(m Model, e rank.Entry) string { return m.savedLabel(e.Domain) }},
+	{"Confidence", 10, func(m Model, e rank.Entry) string { return e.Result.Confidence.String() }},
+	{"Listing", 24, func(m Model, e rank.Entry) string {
+		if e.Result.Listing == nil {
+			return ""
+		}
+		return e.Result.Listing.String()
+	}},
+}
+
+// listingColumn is the index of the aftermarket Listing column
+const listingColumn = 9
+
+// defaultHiddenColumns are toggled off until shown
+var defaultHiddenColumns = map[int]bool{6: true, 8: true, listingColumn: true}
+
+// statusText is the results table status of a result
+func statusText(r checker.Result) string {
+	switch {
+	case r.Error != nil:
+		return "error"
+	case r.Available:
+		return "available"
+	case r.Restriction() != "":
+		return r.Restriction()
+	case r.Dropping():
+		return "taken (dropping)"
+	case r.ForSale:
+		return "taken (for sale)"
+	case r.Parked:
+		return "taken (parked)"
+	}
+	return "taken"
+}
+
+// sortEntries orders entries by key; ties keep their order
+func sortEntries(entries []rank.Entry, key sortKey) {
+	switch key {
+	case sortRank:
+		rank.Sort(entries)
+	case sortPrice:
+		rank.SortByPrice(entries)
+	case sortDomain:
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Domain < entries[j].Domain })
+	case sortAvailability:
+		order := func(r checker.Result) int {
+			switch {
+			case r.Error != nil:
+				return 3
+			case r.Available:
+				return 0
+			case r.Premium || r.Dropping() || r.ForSale || r.Parked:
+				return 1
+			}
+			return 2
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return order(entries[i].Result) < order(entries[j].Result) })
+	case sortExpiry:
+		// Soonest expiry first; results without a date go last
+		expiry := func(e rank.Entry) (time.Time, bool) { return checker.ParseDate(e.Result.ExpiryDate) }
+		sort.SliceStable(entries, func(i, j int) bool {
+			a, okA := expiry(entries[i])
+			b, okB := expiry(entries[j])
+			if okA != okB {
+				return okA
+			}
+			return okA && a.Before(b)
+		})
+	}
+}
+
+// resultsTableStyles styles the results table from the palette
+func resultsTableStyles() table.Styles {
+	styles := table.DefaultStyles()
+	styles.Header = styles.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(dimColor).
+		BorderBottom(true).
+		Foreground(primaryColor).
+		Bold(true)
+	styles.Selected = styles.Selected.Foreground(accentColor).Bold(true)
+	if activeTheme.mono {
+		styles.Selected = styles.Selected.Reverse(true)
+	}
+	return styles
+}
+
+func newResultsTable() table.Model {
+	return table.New(table.WithStyles(resultsTableStyles()), table.WithFocused(true))
+}
+
+// resultsTableHeight is the number of lines left for the results table
+// after the banner, title, totals and help
+func (m Model) resultsTableHeight() int {
+	used := 8
+	if banner != "" {
+		used += strings.Count(banner, "\n") + 1
+	}
+	return max(5, m.height-used)
+}
+
+// refreshTable rebuilds the results table from the visible entries, keeping
+// the cursor in range
+func (m *Model) refreshTable() {
+	entries := m.visibleEntries()
+
+	var cols []table.Column
+	var rows []table.Row
+	for range entries {
+		rows = append(rows, make(table.Row, 0, len(resultColumns)))
+	}
+	for i, c := range resultColumns {
+		if m.hiddenColumns[i] {
+			continue
+		}
+		width := len(c.title)
+		for j, e := range entries {
+			v := c.value(*m, e)
+			rows[j] = append(rows[j], v)
+			width = max(width, lipgloss.Width(v))
+		}
+		cols = append(cols, table.Column{Title: c.title, Width: min(width, c.maxWidth)})
+	}
+
+	cursor := m.table.Cursor()
+	// Columns must change before rows so no row outgrows them
+	m.table.SetRows(nil)
+	m.table.SetColumns(cols)
+	m.table.SetRows(rows)
+	m.table.SetWidth(m.width)
+	m.table.SetHeight(m.resultsTableHeight())
+	m.table.SetCursor(min(cursor, len(rows)-1))
+}
+
+// selectedEntry returns the result under the table or grid cursor
+func (m Model) selectedEntry() (rank.Entry, bool) {
+	if m.showGrid {
+		return m.gridEntry()
+	}
+	entries := m.visibleEntries()
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(entries) {
+		return rank.Entry{}, false
+	}
+	return entries[cursor], true
+}
+
+// columnHelp lists the column toggle keys, marking the shown columns
+func (m Model) columnHelp() string {
+	var parts []string
+	for i, c := range resultColumns {
+		if i == 0 {
+			continue
+		}
+		mark := " "
+		if !m.hiddenColumns[i] {
+			mark = "✓"
+		}
+		parts = append(parts, fmt.Sprintf("'%d' %s%s", i, mark, c.title))
+	}
+	return "Columns: " + strings.Join(parts, " ")
+}
@@ -0,0 +1,280 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// theme is a TUI palette
+type theme struct {
+	name        string
+	description string
+	primary     lipgloss.TerminalColor
+	secondary   lipgloss.TerminalColor
+	accent      lipgloss.TerminalColor
+	dim         lipgloss.TerminalColor
+	available   lipgloss.TerminalColor
+	// mono marks the cursor with reverse video instead of color
+	mono bool
+}
+
+// themes are selectable from config or the settings screen; the first is
+// the default
+var themes = []theme{
+	{
+		name:        "default",
+		description: "bright colors for dark terminals",
+		primary:     lipgloss.Color("#00D4AA"),
+		secondary:   lipgloss.Color("#FF6B6B"),
+		accent:      lipgloss.Color("#FFE66D"),
+		dim:         lipgloss.Color("#666666"),
+		available:   lipgloss.Color("#00FF00"),
+	},
+	{
+		name:        "light",
+		description: "darker colors for light terminals",
+		primary:     lipgloss.Color("#00735C"),
+		secondary:   lipgloss.Color("#B3261E"),
+		accent:      lipgloss.Color("#8A5A00"),
+		dim:         lipgloss.Color("#5F5F5F"),
+		available:   lipgloss.Color("#1A7F37"),
+	},
+	{
+		name:        "monochrome",
+		description: "no colors; bold and reverse video only",
+		primary:     lipgloss.NoColor{},
+		secondary:   lipgloss.NoColor{},
+		accent:      lipgloss.NoColor{},
+		dim:         lipgloss.NoColor{},
+		available:   lipgloss.NoColor{},
+		mono:        true,
+	},
+	{
+		name:        "high-contrast",
+		description: "bright basic colors readable on most backgrounds",
+		primary:     lipgloss.Color("14"),
+		secondary:   lipgloss.Color("9"),
+		accent:      lipgloss.Color("11"),
+		dim:         lipgloss.Color("15"),
+		available:   lipgloss.Color("10"),
+	},
+}
+
+// activeTheme is the palette in use
+var activeTheme = themes[0]
+
+// brandColors override the theme's colors, except in monochrome
+var brandColors config.BrandColors
+
+// noColor reports whether NO_COLOR (https://no-color.org) disables colors
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// findTheme returns the theme named name
+func findTheme(name string) (theme, bool) {
+	for _, t := range themes {
+		if strings.EqualFold(t.name, name) {
+			return t, true
+		}
+	}
+	return theme{}, false
+}
+
+// applyTheme makes t the palette, with any brand colors on top, and
+// rebuilds the styles from it
+func applyTheme(t theme) {
+	activeTheme = t
+	primaryColor, secondaryColor, accentColor, dimColor = t.primary, t.secondary, t.accent, t.dim
+	available := t.available
+	if !t.mono {
+		override := func(c *lipgloss.TerminalColor, hex string) {
+			if hex != "" {
+				*c = lipgloss.Color(hex)
+			}
+		}
+		override(&primaryColor, brandColors.Primary)
+		override(&secondaryColor, brandColors.Secondary)
+		override(&accentColor, brandColors.Accent)
+		override(&dimColor, brandColors.Dim)
+		override(&available, brandColors.Available)
+	}
+
+	titleStyle = titleStyle.Foreground(primaryColor)
+	availableStyle = availableStyle.Foreground(available)
+	takenStyle = takenStyle.Foreground(secondaryColor)
+	expiryStyle = expiryStyle.Foreground(accentColor)
+	inputStyle = inputStyle.BorderForeground(primaryColor)
+	helpStyle = helpStyle.Foreground(dimColor)
+	bannerStyle = bannerStyle.Foreground(primaryColor)
+	spinnerStyle = spinnerStyle.Foreground(primaryColor)
+}
+
+// resolveTheme picks the starting theme: monochrome under NO_COLOR, else the
+// one chosen on the settings screen, else the configured one
+func resolveTheme(configured string) (theme, error) {
+	if noColor() {
+		t, _ := findTheme("monochrome")
+		return t, nil
+	}
+	name := configured
+	saved, err := loadSettings()
+	if saved.Theme != "" {
+		name = saved.Theme
+	}
+	if name == "" {
+		return themes[0], err
+	}
+	t, ok := findTheme(name)
+	if !ok {
+		names := make([]string, len(themes))
+		for i, t := range themes {
+			names[i] = t.name
+		}
+		return themes[0], fmt.Errorf("unknown theme %q (use %s)", name, strings.Join(names, ", "))
+	}
+	return t, err
+}
+
+// settings are the TUI choices remembered between runs
+type settings struct {
+	Theme string `json:"theme,omitempty"`
+}
+
+// settingsPath returns where TUI settings are kept
+// (~/.config/gofindadomain/tui.json)
+func settingsPath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tui.json"), nil
+}
+
+// loadSettings reads the TUI settings; a missing file is no settings
+func loadSettings() (settings, error) {
+	var s settings
+	path, err := settingsPath()
+	if err != nil {
+		return s, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to read TUI settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return settings{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// saveSettings writes the TUI settings
+func saveSettings(s settings) error {
+	path, err := settingsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to save TUI settings: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to save TUI settings: %w", err)
+	}
+	return nil
+}
+
+// openSettings shows the settings screen with the cursor on the theme in use
+func (m Model) openSettings() Model {
+	m.settingsReturn = m.state
+	m.state = stateSettings
+	m.themeCursor = 0
+	for i, t := range themes {
+		if t.name == activeTheme.name {
+			m.themeCursor = i
+		}
+	}
+	m.themeBefore = activeTheme
+	return m
+}
+
+// previewTheme applies a theme to the running TUI, restyling the widgets
+// that copied the old styles
+func (m *Model) previewTheme(t theme) {
+	applyTheme(t)
+	m.table.SetStyles(resultsTableStyles())
+	m.spinner.Style = spinnerStyle
+}
+
+// updateSettings moves through the themes, previewing each; Enter keeps
+// and remembers the theme, Esc restores the previous one
+func (m Model) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if !noColor() && m.themeCursor > 0 {
+			m.themeCursor--
+			m.previewTheme(themes[m.themeCursor])
+		}
+	case "down", "j":
+		if !noColor() && m.themeCursor < len(themes)-1 {
+			m.themeCursor++
+			m.previewTheme(themes[m.themeCursor])
+		}
+	case "enter":
+		if !noColor() {
+			if err := saveSettings(settings{Theme: activeTheme.name}); err != nil {
+				m.status = takenStyle.Render(err.Error())
+			} else {
+				m.status = availableStyle.Render("Theme: " + activeTheme.name)
+			}
+		}
+		return m.resume(m.settingsReturn)
+	case "esc":
+		m.previewTheme(m.themeBefore)
+		return m.resume(m.settingsReturn)
+	}
+	return m, nil
+}
+
+// settingsView lists the themes with a sample of the one under the cursor
+func (m Model) settingsView() string {
+	var s strings.Builder
+	s.WriteString(titleStyle.Render("Settings"))
+	s.WriteString("\n\n")
+	s.WriteString("Theme:\n")
+	for i, t := range themes {
+		cursor := "  "
+		if i == m.themeCursor {
+			cursor = "> "
+		}
+		name := fmt.Sprintf("%-14s", t.name)
+		if i == m.themeCursor {
+			name = titleStyle.UnsetPadding().Render(name)
+		}
+		s.WriteString(cursor + name + " " + helpStyle.Render(t.description) + "\n")
+	}
+	s.WriteString("\n")
+	s.WriteString(availableStyle.Render("example.com available") + "  ")
+	s.WriteString(takenStyle.Render("example.net taken") + "  ")
+	s.WriteString(expiryStyle.Render("expires 2027-01-01") + "\n\n")
+	if noColor() {
+		s.WriteString(helpStyle.Render("NO_COLOR is set, so colors are off") + "\n\n")
+		s.WriteString(helpStyle.Render("Enter or Esc to go back • 'q' to quit"))
+		return s.String()
+	}
+	s.WriteString(helpStyle.Render("↑/↓ to preview • Enter to keep • Esc to cancel • 'q' to quit"))
+	return s.String()
+}
@@ -2,24 +2,31 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/rank"
+	"github.com/james-see/gofindadomain/internal/score"
+	"github.com/james-see/gofindadomain/internal/store"
+	"github.com/james-see/gofindadomain/internal/tld"
 )
 
 var (
-	// Colors
-	primaryColor   = lipgloss.Color("#00D4AA")
-	secondaryColor = lipgloss.Color("#FF6B6B")
-	accentColor    = lipgloss.Color("#FFE66D")
-	dimColor       = lipgloss.Color("#666666")
+	// Colors, set by the theme
+	primaryColor   lipgloss.TerminalColor = lipgloss.Color("#00D4AA")
+	secondaryColor lipgloss.TerminalColor = lipgloss.Color("#FF6B6B")
+	accentColor    lipgloss.TerminalColor = lipgloss.Color("#FFE66D")
+	dimColor       lipgloss.TerminalColor = lipgloss.Color("#666666")
 
 	// Styles
 	titleStyle = lipgloss.NewStyle().
@@ -53,7 +60,7 @@ var (
 	spinnerStyle = lipgloss.NewStyle().Foreground(primaryColor)
 )
 
-const banner = `
+var banner = `
   ___      ___ _         _   _   ___                  _      
  / __|___ | __(_)_ _  __| | /_\ |   \ ___ _ __  __ _(_)_ _  
 | (_ / _ \| _|| | ' \/ _' |/ _ \| |) / _ \ '  \/ _' | | ' \ 
@@ -68,64 +75,225 @@ const (
 	stateSelectTLDs
 	stateChecking
 	stateResults
+	stateDetail
+	stateSettings
+	stateJobs
 )
 
-// Shared state for async results
+// asyncResults are a job's results, filled in as its checks finish
 type asyncResults struct {
-	mu       sync.Mutex
-	results  []checker.Result
-	done     bool
+	mu      sync.Mutex
+	results []checker.Result
+	ranks   map[string]rank.Entry
+	// index locates rechecked domains in results, so their new results
+	// replace the old ones in place
+	index   map[string]int
+	checked int
+	// recent holds the last few results checked
+	recent []checker.Result
 }
 
-var sharedResults *asyncResults
+// Options configures the TUI
+type Options struct {
+	Backend checker.Backend
+	// Ranker scores and prices results; nil uses quality score only
+	Ranker *rank.Ranker
+	// Lists are saved domain lists whose notes and tags annotate results
+	Lists []*store.List
+	// Store keeps the lists; starring a result adds it to its shortlist
+	Store store.Backend
+	// Registrar is the registrar page opened for available domains: a
+	// preset name (namecheap, porkbun, ...) or a URL containing {domain}
+	Registrar string
+	// Theme is the configured palette (default, light, monochrome,
+	// high-contrast); one chosen on the settings screen wins
+	Theme string
+	// Concurrency is the number of concurrent checks a run starts with;
+	// zero uses defaultConcurrency
+	Concurrency int
+	// ExpiringWithin flags taken domains expiring this soon and sorts the
+	// results by expiry; zero flags none
+	ExpiringWithin time.Duration
+	// Listings shows the Listing column from the start, for backends that
+	// look up aftermarket listings
+	Listings bool
+}
 
 type Model struct {
-	state         state
-	keywordInput  textinput.Model
-	spinner       spinner.Model
-	keyword       string
-	tlds          []string
-	selectedTLDs  map[int]bool
-	tldCursor     int
+	state   state
+	backend checker.Backend
+	ranker  *rank.Ranker
+	ranks   map[string]rank.Entry
+	sortBy  sortKey
+	// expiringWithin flags taken domains expiring this soon
+	expiringWithin time.Duration
+	lists          []*store.List
+	store          store.Backend
+	registrar      string
+	// starredOnly lists only the results on the shortlist
+	starredOnly bool
+	tagFilter   string
+	// table lists the results; hiddenColumns are its toggled-off columns
+	table         table.Model
+	hiddenColumns map[int]bool
+	// exporting shows the export path prompt on the results screen
+	exporting   bool
+	exportInput textinput.Model
+	// showRaw shows the selected result's whois response in the detail
+	// view; raw is the last response fetched and rawView scrolls it
+	showRaw    bool
+	raw        *rawWhoisMsg
+	rawLoading bool
+	rawView    viewport.Model
+	// settingsReturn is the screen the settings screen returns to;
+	// themeCursor is the theme under its cursor and themeBefore the theme
+	// to restore on Esc
+	settingsReturn state
+	themeCursor    int
+	themeBefore    theme
+	// status reports the outcome of the last results screen action
+	status       string
+	keywordInput textinput.Model
+	spinner      spinner.Model
+	// keyword is the keyword input; keywords are the keywords it lists
+	keyword  string
+	keywords []string
+	// searches are the recent searches, newest first; searchIndex is the
+	// one recalled into the keyword input, -1 for none
+	searches    []search
+	searchIndex int
+	// gridTLDs are the TLDs of the current check, in list order; showGrid
+	// shows the results as a keyword by TLD grid with a cell cursor
+	gridTLDs     []string
+	showGrid     bool
+	gridRow      int
+	gridCol      int
+	tlds         []string
+	selectedTLDs map[int]bool
+	tldCursor    int
+	// tldFilter narrows the TLD list to fuzzy matches while filtering;
+	// tldMatches holds the matching indexes, nil without a filter
+	tldFilter     textinput.Model
+	filtering     bool
+	tldMatches    []int
 	results       []checker.Result
+	recent        []checker.Result
 	showOnlyAvail bool
 	ctx           context.Context
 	cancel        context.CancelFunc
 	checking      bool
-	checkedCount  int
-	totalCount    int
-	startTime     time.Time
-	err           error
-	width         int
-	height        int
+	// jobs are the queued searches; viewing is the one whose progress or
+	// results are shown and jobCursor the one selected on the jobs screen
+	jobs      []*job
+	jobSeq    int
+	viewing   *job
+	jobCursor int
+	// ticking is set while a tick loop refreshes progress
+	ticking bool
+	// concurrency is the number of checks in flight; throttle applies it
+	// to the running check and pauses it
+	concurrency  int
+	throttle     *checker.Throttle
+	checkedCount int
+	totalCount   int
+	startTime    time.Time
+	finished     time.Time
+	err          error
+	width        int
+	height       int
 }
 
+// defaultConcurrency is the number of concurrent checks a run starts with
+const defaultConcurrency = 30
+
+// maxConcurrency caps the concurrency set with '+'
+const maxConcurrency = 200
+
 type tickMsg time.Time
-type checkDoneMsg struct{}
 
-func NewModel(tlds []string) Model {
+func NewModel(tlds []string, opts Options) Model {
+	t, themeErr := resolveTheme(opts.Theme)
+	applyTheme(t)
+
 	ti := textinput.New()
-	ti.Placeholder = "Enter keyword (e.g., mycompany)"
+	ti.Placeholder = "Enter keywords (e.g., mycompany or foo,bar)"
 	ti.Focus()
-	ti.CharLimit = 63
+	ti.CharLimit = 255
 	ti.Width = 40
 
+	ei := textinput.New()
+	ei.Placeholder = "results.csv or results.json"
+	ei.CharLimit = 255
+	ei.Width = 50
+
+	fi := textinput.New()
+	fi.Placeholder = "filter TLDs"
+	fi.Prompt = "/"
+	fi.CharLimit = 63
+	fi.Width = 30
+
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = spinnerStyle
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Recent searches are a convenience; a damaged file starts a new one
+	searches, err := loadSearches()
+	status := ""
+	if err := errors.Join(themeErr, err); err != nil {
+		status = takenStyle.Render(err.Error())
+	}
+
+	hidden := make(map[int]bool, len(defaultHiddenColumns))
+	for i := range defaultHiddenColumns {
+		hidden[i] = true
+	}
+	if opts.Listings {
+		delete(hidden, listingColumn)
+	}
+
+	concurrency := defaultConcurrency
+	if opts.Concurrency > 0 {
+		concurrency = min(opts.Concurrency, maxConcurrency)
+	}
+
+	ranker := opts.Ranker
+	if ranker == nil {
+		ranker = rank.New(1, 0, nil)
+	}
+
+	var sortBy sortKey
+	if opts.ExpiringWithin > 0 {
+		sortBy = sortExpiry
+	}
+
 	return Model{
-		state:        stateInput,
-		keywordInput: ti,
-		spinner:      s,
-		tlds:         tlds,
-		selectedTLDs: make(map[int]bool),
-		ctx:          ctx,
-		cancel:       cancel,
-		width:        80,
-		height:       24,
+		state:          stateInput,
+		backend:        opts.Backend,
+		ranker:         ranker,
+		ranks:          make(map[string]rank.Entry),
+		sortBy:         sortBy,
+		expiringWithin: opts.ExpiringWithin,
+		lists:          opts.Lists,
+		store:          opts.Store,
+		registrar:      opts.Registrar,
+		searches:       searches,
+		searchIndex:    -1,
+		status:         status,
+		keywordInput:   ti,
+		exportInput:    ei,
+		tldFilter:      fi,
+		table:          newResultsTable(),
+		hiddenColumns:  hidden,
+		spinner:        s,
+		tlds:           tlds,
+		selectedTLDs:   make(map[int]bool),
+		ctx:            ctx,
+		cancel:         cancel,
+		concurrency:    concurrency,
+		width:          80,
+		height:         24,
 	}
 }
 
@@ -146,19 +314,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.refreshTable()
+		m.rawView.Width = m.width
+		m.rawView.Height = m.rawViewHeight()
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.exporting {
+			return m.updateExport(msg)
+		}
+		if m.filtering {
+			return m.updateTLDFilter(msg)
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
+			// q is typed into the keyword input rather than quitting
+			if msg.String() == "q" && m.state == stateInput {
+				break
+			}
 			if m.cancel != nil {
 				m.cancel()
 			}
 			return m, tea.Quit
 
+		case "J":
+			if len(m.jobs) > 0 && m.state != stateInput && m.state != stateSettings && m.state != stateJobs {
+				return m.openJobs()
+			}
+
+		case "ctrl+t":
+			if m.state != stateSettings {
+				return m.openSettings(), nil
+			}
+			return m, nil
+
 		case "tab":
 			if m.state == stateResults {
 				m.showOnlyAvail = !m.showOnlyAvail
+				m.refreshTable()
+				m.table.GotoTop()
 			}
 			return m, nil
 
@@ -167,7 +362,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Restart
 				m.state = stateInput
 				m.results = nil
+				m.recent = nil
 				m.checkedCount = 0
+				m.refreshTable()
+				m.status = ""
 				m.keywordInput.Focus()
 				return m, textinput.Blink
 			}
@@ -176,9 +374,33 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch m.state {
 		case stateInput:
 			switch msg.String() {
+			case "up":
+				if m.searchIndex+1 < len(m.searches) {
+					m.recallSearch(m.searchIndex + 1)
+				}
+				return m, nil
+			case "down":
+				if m.searchIndex >= 0 {
+					m.recallSearch(m.searchIndex - 1)
+				}
+				return m, nil
+			case "esc":
+				if len(m.jobs) > 0 {
+					return m.openJobs()
+				}
+				return m, nil
 			case "enter":
-				m.keyword = m.keywordInput.Value()
-				if m.keyword != "" {
+				m.keywords = splitKeywords(m.keywordInput.Value())
+				m.keyword = tld.ToUnicode(strings.Join(m.keywords, ","))
+				for _, k := range m.keywords {
+					if err := tld.ValidateLabel(k); err != nil {
+						m.status = takenStyle.Render("Invalid keyword: " + err.Error())
+						return m, nil
+					}
+				}
+				m.status = ""
+				if len(m.keywords) > 0 {
+					m.recalledTLDs()
 					m.state = stateSelectTLDs
 				}
 				return m, nil
@@ -187,140 +409,379 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 
 		case stateSelectTLDs:
+			return m.updateTLDList(msg)
+
+		case stateChecking:
 			switch msg.String() {
-			case "up", "k":
-				if m.tldCursor > 0 {
-					m.tldCursor--
+			case "p":
+				if m.throttle != nil {
+					_, _, paused := m.throttle.State()
+					m.throttle.SetPaused(!paused)
 				}
-			case "down", "j":
-				if m.tldCursor < len(m.tlds)-1 {
-					m.tldCursor++
+			case "+", "=":
+				m.setConcurrency(stepConcurrency(m.concurrency, 1))
+			case "-", "_":
+				m.setConcurrency(stepConcurrency(m.concurrency, -1))
+			case "n":
+				return m.newSearch()
+			}
+			return m, nil
+
+		case stateResults:
+			switch msg.String() {
+			case "g":
+				m.showGrid = !m.showGrid
+				return m, nil
+			case "enter":
+				if _, ok := m.selectedEntry(); ok {
+					m.state = stateDetail
 				}
-			case " ":
-				m.selectedTLDs[m.tldCursor] = !m.selectedTLDs[m.tldCursor]
-			case "a":
-				allSelected := len(m.selectedTLDs) == len(m.tlds)
-				m.selectedTLDs = make(map[int]bool)
-				if !allSelected {
-					for i := range m.tlds {
-						m.selectedTLDs[i] = true
-					}
+			case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+				if n := int(msg.String()[0] - '0'); n < len(resultColumns) {
+					m.hiddenColumns[n] = !m.hiddenColumns[n]
+					m.refreshTable()
 				}
-			case "p":
-				popular := []string{".com", ".net", ".org", ".io", ".dev", ".co", ".app", ".ai"}
-				for i, tld := range m.tlds {
-					for _, p := range popular {
-						if tld == p {
-							m.selectedTLDs[i] = true
-						}
-					}
+			case "s":
+				if e, ok := m.selectedEntry(); ok {
+					m.toggleStar(e.Domain)
+					m.refreshTable()
 				}
-			case "enter":
-				if len(m.selectedTLDs) > 0 {
-					m.state = stateChecking
-					m.checking = true
-					m.totalCount = len(m.selectedTLDs)
-					m.startTime = time.Now()
-					return m, tea.Batch(m.startChecking(), m.spinner.Tick, tickEvery())
+			case "o":
+				if e, ok := m.selectedEntry(); ok {
+					return m.openEntry(e)
 				}
-			case "backspace", "esc":
-				m.state = stateInput
-				m.keywordInput.Focus()
+			case "c":
+				if e, ok := m.selectedEntry(); ok {
+					m.copyDomains([]string{e.Domain})
+				}
+			case "C":
+				m.copyDomains(m.availableDomains())
+			case "*":
+				m.starredOnly = !m.starredOnly
+				m.refreshTable()
+				m.table.GotoTop()
+			case "S":
+				m.sortBy = (m.sortBy + 1) % sortKey(len(sortNames))
+				m.refreshTable()
+				m.table.GotoTop()
+			case "T":
+				m.tagFilter = nextTag(m.allTags(), m.tagFilter)
+				m.refreshTable()
+				m.table.GotoTop()
+			case "R":
+				return m.retryFailed()
+			case "e":
+				m.exporting = true
+				m.status = ""
+				m.exportInput.SetValue(strings.Join(m.keywords, "-") + "-results.csv")
+				m.exportInput.CursorEnd()
+				m.exportInput.Focus()
 				return m, textinput.Blink
+			default:
+				if m.showGrid {
+					return m.updateGrid(msg)
+				}
+				m.table, cmd = m.table.Update(msg)
+				return m, cmd
 			}
 			return m, nil
 
-		case stateChecking:
+		case stateDetail:
+			switch msg.String() {
+			case "w":
+				if m.showRaw {
+					m.showRaw = false
+					return m, nil
+				}
+				return m.showRawWhois()
+			case "enter", "backspace", "esc":
+				if m.showRaw {
+					m.showRaw = false
+				} else {
+					m.state = stateResults
+				}
+			default:
+				if m.showRaw && !m.rawLoading {
+					m.rawView, cmd = m.rawView.Update(msg)
+					return m, cmd
+				}
+			}
 			return m, nil
 
-		case stateResults:
-			return m, nil
+		case stateSettings:
+			return m.updateSettings(msg)
+
+		case stateJobs:
+			return m.updateJobs(msg)
+		}
+
+	case rawWhoisMsg:
+		return m.setRawWhois(msg), nil
+
+	case openedMsg:
+		if msg.err != nil {
+			m.status = takenStyle.Render(msg.err.Error())
+		} else {
+			m.status = helpStyle.Render("Opened " + msg.url)
 		}
+		return m, nil
 
 	case spinner.TickMsg:
-		if m.state == stateChecking {
+		if m.state == stateChecking || m.state == stateJobs || m.rawLoading {
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
 		}
 		return m, nil
 
 	case tickMsg:
-		if m.state != stateChecking || sharedResults == nil {
+		if (m.state != stateChecking && m.state != stateJobs) || m.running() == nil {
+			m.ticking = false
 			return m, nil
 		}
+		if m.state == stateChecking {
+			m.syncResults()
+		}
+		return m, tickEvery()
 
-		sharedResults.mu.Lock()
-		m.results = make([]checker.Result, len(sharedResults.results))
-		copy(m.results, sharedResults.results)
-		m.checkedCount = len(m.results)
-		done := sharedResults.done
-		sharedResults.mu.Unlock()
+	case checkDoneMsg:
+		return m.finishJob(msg.job)
+	}
 
-		if done {
-			m.checking = false
-			m.state = stateResults
+	return m, nil
+}
+
+// updateTLDList handles keys on the TLD selection screen. The cursor
+// indexes the listed TLDs, so selections are kept by TLD across filters.
+func (m Model) updateTLDList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	visible := m.visibleTLDs()
+	switch msg.String() {
+	case "up", "k":
+		if m.tldCursor > 0 {
+			m.tldCursor--
+		}
+	case "down", "j":
+		if m.tldCursor < len(visible)-1 {
+			m.tldCursor++
+		}
+	case " ":
+		if m.tldCursor < len(visible) {
+			i := visible[m.tldCursor]
+			if m.selectedTLDs[i] {
+				delete(m.selectedTLDs, i)
+			} else {
+				m.selectedTLDs[i] = true
+			}
+		}
+	case "a":
+		allSelected := true
+		for _, i := range visible {
+			if !m.selectedTLDs[i] {
+				allSelected = false
+				break
+			}
+		}
+		for _, i := range visible {
+			if allSelected {
+				delete(m.selectedTLDs, i)
+			} else {
+				m.selectedTLDs[i] = true
+			}
+		}
+	case "p":
+		m.togglePreset(tld.Categories[0])
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if n := int(msg.String()[0] - '1'); n < len(tld.Categories) {
+			m.togglePreset(tld.Categories[n])
+		}
+	case "/":
+		m.filtering = true
+		m.tldFilter.Focus()
+		return m, textinput.Blink
+	case "enter":
+		if len(m.selectedTLDs) > 0 {
+			m.gridTLDs = m.selectedTLDList()
+			m.rememberSearch()
+			j, cmd := m.enqueue(m.keywords, m.gridTLDs)
+			var notes []string
+			if n := len(m.keywords)*len(m.gridTLDs) - len(j.domains); n > 0 {
+				notes = append(notes, fmt.Sprintf("Skipped %d domains shorter than their registry allows", n))
+			}
+			if j.status != jobRunning {
+				// Another search is running; this one waits its turn
+				m, tick := m.openJobs()
+				m.jobCursor = len(m.jobs) - 1
+				notes = append([]string{fmt.Sprintf("Queued search #%d", j.id)}, notes...)
+				m.status = helpStyle.Render(strings.Join(notes, " • "))
+				return m, tea.Batch(cmd, tick)
+			}
+			m.status = ""
+			if len(notes) > 0 {
+				m.status = helpStyle.Render(notes[0])
+			}
+			m = m.view(j)
+			tick := m.startTicking()
+			return m, tea.Batch(cmd, tick)
+		}
+	case "esc":
+		if m.tldMatches != nil {
+			m.clearTLDFilter()
 			return m, nil
 		}
+		m.state = stateInput
+		m.keywordInput.Focus()
+		return m, textinput.Blink
+	case "backspace":
+		m.state = stateInput
+		m.keywordInput.Focus()
+		return m, textinput.Blink
+	}
+	return m, nil
+}
 
-		return m, tea.Batch(tickEvery(), m.spinner.Tick)
-
-	case checkDoneMsg:
-		m.checking = false
-		m.state = stateResults
-		if sharedResults != nil {
-			sharedResults.mu.Lock()
-			m.results = sharedResults.results
-			m.checkedCount = len(m.results)
-			sharedResults.mu.Unlock()
+// togglePreset selects the TLDs of a preset category, or deselects them
+// when all are already selected
+func (m *Model) togglePreset(c tld.Category) {
+	var members []int
+	allSelected := true
+	for i, t := range m.tlds {
+		if c.Contains(t) {
+			members = append(members, i)
+			allSelected = allSelected && m.selectedTLDs[i]
+		}
+	}
+	for _, i := range members {
+		if allSelected {
+			delete(m.selectedTLDs, i)
+		} else {
+			m.selectedTLDs[i] = true
 		}
-		return m, nil
 	}
+}
 
-	return m, nil
+// presetHelp lists the preset keys with the number of listed TLDs in each
+func (m Model) presetHelp() string {
+	var parts []string
+	for n, c := range tld.Categories {
+		if n >= 9 {
+			break
+		}
+		count := 0
+		for _, t := range m.tlds {
+			if c.Contains(t) {
+				count++
+			}
+		}
+		parts = append(parts, fmt.Sprintf("'%d': %s (%d)", n+1, c.Name, count))
+	}
+	return "Presets: " + strings.Join(parts, " • ")
 }
 
-func (m Model) startChecking() tea.Cmd {
-	var domains []string
-	for i, selected := range m.selectedTLDs {
-		if selected {
-			domains = append(domains, m.keyword+m.tlds[i])
+// updateExport handles keys while the export path prompt is open
+func (m Model) updateExport(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		if m.cancel != nil {
+			m.cancel()
+		}
+		return m, tea.Quit
+	case "esc":
+		m.exporting = false
+		m.exportInput.Blur()
+		return m, nil
+	case "enter":
+		path := strings.TrimSpace(m.exportInput.Value())
+		if path == "" {
+			return m, nil
 		}
+		m.exporting = false
+		m.exportInput.Blur()
+		entries := m.visibleEntries()
+		if err := exportResults(path, entries); err != nil {
+			m.status = takenStyle.Render(err.Error())
+		} else {
+			m.status = availableStyle.Render(fmt.Sprintf("Exported %d results to %s", len(entries), path))
+		}
+		return m, nil
 	}
 
-	ctx := m.ctx
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
+}
 
-	// Initialize shared results
-	sharedResults = &asyncResults{
-		results: make([]checker.Result, 0, len(domains)),
+// selectedTLDList returns the selected TLDs in list order
+func (m Model) selectedTLDList() []string {
+	var tlds []string
+	for i, t := range m.tlds {
+		if m.selectedTLDs[i] {
+			tlds = append(tlds, t)
+		}
 	}
+	return tlds
+}
 
-	return func() tea.Msg {
-		resultChan := make(chan checker.Result, len(domains))
+// stepConcurrency raises or lowers concurrency a step: by one up to 5,
+// by five above
+func stepConcurrency(n, dir int) int {
+	if dir > 0 {
+		if n < 5 {
+			return n + 1
+		}
+		return min(n+5, maxConcurrency)
+	}
+	if n <= 5 {
+		return max(1, n-1)
+	}
+	return n - 5
+}
 
-		go func() {
-			checker.CheckDomains(ctx, domains, 30, resultChan)
-			close(resultChan)
-		}()
+// setConcurrency changes the concurrency, applying it to a running check
+func (m *Model) setConcurrency(n int) {
+	m.concurrency = n
+	if m.throttle != nil {
+		m.throttle.SetLimit(n)
+	}
+}
 
-		for result := range resultChan {
-			sharedResults.mu.Lock()
-			sharedResults.results = append(sharedResults.results, result)
-			sharedResults.mu.Unlock()
+// retryFailed rechecks the domains whose check failed, keeping the other
+// results
+func (m Model) retryFailed() (tea.Model, tea.Cmd) {
+	var failed []string
+	for _, r := range m.results {
+		if r.Error != nil {
+			failed = append(failed, r.Domain)
 		}
+	}
+	if len(failed) == 0 {
+		m.status = helpStyle.Render("No failed checks to retry")
+		return m, nil
+	}
 
-		sharedResults.mu.Lock()
-		sharedResults.done = true
-		sharedResults.mu.Unlock()
-
-		return checkDoneMsg{}
+	j := m.viewing
+	if j == nil {
+		return m, nil
+	}
+	j.domains = failed
+	j.previous = m.results
+	j.status = jobQueued
+	m.status = ""
+	cmd := m.startNext()
+	if j.status != jobRunning {
+		m.status = helpStyle.Render(fmt.Sprintf("Retry of search #%d queued", j.id))
+		return m, cmd
 	}
+	m = m.view(j)
+	tick := m.startTicking()
+	return m, tea.Batch(cmd, tick)
 }
 
 func (m Model) View() string {
 	var s strings.Builder
 
-	s.WriteString(bannerStyle.Render(banner))
-	s.WriteString("\n")
+	if banner != "" {
+		s.WriteString(bannerStyle.Render(banner))
+		s.WriteString("\n")
+	}
 
 	switch m.state {
 	case stateInput:
@@ -328,43 +789,92 @@ func (m Model) View() string {
 		s.WriteString("\n\n")
 		s.WriteString(inputStyle.Render(m.keywordInput.View()))
 		s.WriteString("\n\n")
-		s.WriteString(helpStyle.Render("Press Enter to continue • Ctrl+C to quit"))
+		if len(m.searches) > 0 {
+			s.WriteString(helpStyle.Render("Recent searches:"))
+			s.WriteString("\n")
+			start := max(0, m.searchIndex-4)
+			for i := start; i < min(len(m.searches), start+5); i++ {
+				line := "  " + m.searches[i].label()
+				if i == m.searchIndex {
+					line = availableStyle.Render("▸ " + m.searches[i].label())
+				}
+				s.WriteString(line + "\n")
+			}
+			s.WriteString("\n")
+		}
+		if m.status != "" {
+			s.WriteString(m.status + "\n\n")
+		}
+		help := "Press Enter to continue • ↑/↓ for recent searches • Ctrl+T for themes • Ctrl+C to quit"
+		if len(m.jobs) > 0 {
+			help = "Press Enter to continue • ↑/↓ for recent searches • Esc for searches • Ctrl+T for themes • Ctrl+C to quit"
+		}
+		s.WriteString(helpStyle.Render(help))
 
 	case stateSelectTLDs:
 		s.WriteString(titleStyle.Render(fmt.Sprintf("Select TLDs for '%s':", m.keyword)))
 		s.WriteString("\n\n")
 
-		visibleCount := min(m.height-12, len(m.tlds))
+		if m.filtering || m.tldMatches != nil {
+			s.WriteString(m.tldFilter.View())
+			s.WriteString(helpStyle.Render(fmt.Sprintf(" (%d matches)", len(m.visibleTLDs()))))
+			s.WriteString("\n\n")
+		}
+
+		visible := m.visibleTLDs()
+		visibleCount := min(m.height-14, len(visible))
 		start := max(0, m.tldCursor-visibleCount/2)
-		end := min(len(m.tlds), start+visibleCount)
+		end := min(len(visible), start+visibleCount)
 		if end-start < visibleCount && start > 0 {
 			start = max(0, end-visibleCount)
 		}
 
-		for i := start; i < end; i++ {
+		for pos := start; pos < end; pos++ {
+			i := visible[pos]
 			cursor := "  "
-			if i == m.tldCursor {
+			if pos == m.tldCursor {
 				cursor = "▸ "
 			}
 			checked := "[ ]"
 			if m.selectedTLDs[i] {
 				checked = "[✓]"
 			}
-			line := fmt.Sprintf("%s%s %s", cursor, checked, m.tlds[i])
+			// IDN TLDs show their Unicode form, then the punycode checked
+			name := tld.ToUnicode(m.tlds[i])
+			line := fmt.Sprintf("%s%s %s", cursor, checked, name)
 			if m.selectedTLDs[i] {
 				s.WriteString(availableStyle.Render(line))
 			} else {
 				s.WriteString(line)
 			}
+			if name != m.tlds[i] {
+				s.WriteString(helpStyle.Render(" " + m.tlds[i]))
+			}
 			s.WriteString("\n")
 		}
 
 		s.WriteString("\n")
-		s.WriteString(helpStyle.Render(fmt.Sprintf("Selected: %d • Space: toggle • 'a': all • 'p': popular • Enter: check", len(m.selectedTLDs))))
+		if m.filtering {
+			s.WriteString(helpStyle.Render(fmt.Sprintf("Selected: %d • Type to filter • ↑/↓ and Space: select • Enter: done • Esc: clear filter", len(m.selectedTLDs))))
+		} else {
+			s.WriteString(helpStyle.Render(fmt.Sprintf("Selected: %d • Space: toggle • 'a': all • 'p': popular • '/': filter • Enter: check", len(m.selectedTLDs))))
+			s.WriteString("\n")
+			s.WriteString(helpStyle.Render(m.presetHelp()))
+		}
 
 	case stateChecking:
-		s.WriteString(m.spinner.View())
-		s.WriteString(titleStyle.Render(" Checking domains..."))
+		limit, active, paused := m.concurrency, 0, false
+		if m.throttle != nil {
+			limit, active, paused = m.throttle.State()
+		}
+		if paused {
+			s.WriteString(expiryStyle.Render("❚❚"))
+			s.WriteString(titleStyle.Render(" Paused"))
+			s.WriteString(helpStyle.Render(fmt.Sprintf(" (%d checks finishing)", active)))
+		} else {
+			s.WriteString(m.spinner.View())
+			s.WriteString(titleStyle.Render(" Checking domains..."))
+		}
 		s.WriteString("\n\n")
 
 		// Progress bar
@@ -377,63 +887,190 @@ func (m Model) View() string {
 		bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 		elapsed := time.Since(m.startTime).Round(time.Second)
 
-		s.WriteString(fmt.Sprintf("Progress: [%s] %d/%d (%d%%) - %s\n\n", bar, m.checkedCount, m.totalCount, pct, elapsed))
+		s.WriteString(fmt.Sprintf("Progress: [%s] %d/%d (%d%%) - %s\n", bar, m.checkedCount, m.totalCount, pct, elapsed))
+		s.WriteString(fmt.Sprintf("Concurrency: %d (%d in flight)\n\n", limit, active))
 
 		// Show last few results
-		if len(m.results) > 0 {
+		if len(m.recent) > 0 {
 			s.WriteString(helpStyle.Render("Recent results:\n"))
-			start := max(0, len(m.results)-5)
-			for _, r := range m.results[start:] {
-				s.WriteString(formatResult(r, false))
+			for _, r := range m.recent {
+				s.WriteString(m.formatResult(m.entry(r), false))
 			}
 		}
 
 		s.WriteString("\n")
-		s.WriteString(helpStyle.Render("Press Ctrl+C to cancel"))
+		s.WriteString(helpStyle.Render("'p' to pause/resume • +/- to change concurrency • 'n' to queue a new search • 'J' for searches • Ctrl+C to cancel"))
 
 	case stateResults:
-		elapsed := time.Since(m.startTime).Round(time.Second)
+		elapsed := m.finished.Sub(m.startTime).Round(time.Second)
 		s.WriteString(titleStyle.Render(fmt.Sprintf("Results (completed in %s):", elapsed)))
 		if m.showOnlyAvail {
 			s.WriteString(helpStyle.Render(" (showing available only)"))
 		}
+		if m.sortBy != sortChecked {
+			s.WriteString(helpStyle.Render(" (sorted by " + sortNames[m.sortBy] + ")"))
+		}
+		if m.tagFilter != "" {
+			s.WriteString(helpStyle.Render(fmt.Sprintf(" (tagged %s)", m.tagFilter)))
+		}
+		if m.starredOnly {
+			s.WriteString(helpStyle.Render(" (starred only)"))
+		}
 		s.WriteString("\n\n")
 
-		availCount := 0
+		if m.showGrid {
+			s.WriteString(m.gridView())
+		} else {
+			s.WriteString(m.table.View())
+			s.WriteString("\n")
+		}
+
+		availCount, failedCount := 0, 0
 		for _, r := range m.results {
-			if r.Available {
+			switch {
+			case r.Error != nil:
+				failedCount++
+			case r.Available:
 				availCount++
 			}
-			s.WriteString(formatResult(r, m.showOnlyAvail))
 		}
-
 		s.WriteString("\n")
-		s.WriteString(fmt.Sprintf("Total: %d checked • %d available • %d taken\n",
-			len(m.results), availCount, len(m.results)-availCount))
+		s.WriteString(fmt.Sprintf("Total: %d checked • %d available • %d taken",
+			len(m.results), availCount, len(m.results)-availCount-failedCount))
+		if failedCount > 0 {
+			s.WriteString(fmt.Sprintf(" • %d failed", failedCount))
+		}
+		s.WriteString("\n")
+		if m.status != "" {
+			s.WriteString(m.status + "\n")
+		}
 		s.WriteString("\n")
-		s.WriteString(helpStyle.Render("Tab to toggle filter • 'r' to restart • 'q' to quit"))
+		if m.exporting {
+			s.WriteString(fmt.Sprintf("Export %d results to (.csv or .json):\n", len(m.visibleEntries())))
+			s.WriteString(inputStyle.Render(m.exportInput.View()))
+			s.WriteString("\n")
+			s.WriteString(helpStyle.Render("Enter to export • Esc to cancel"))
+			break
+		}
+		if m.showGrid {
+			s.WriteString(helpStyle.Render("Arrows to move • Enter for details • 'g' for the table • 's' to star • 'o' to open registrar • 'c'/'C' to copy one/all available • 'e' to export • 'R' to retry failed • 'J' for searches • 'r' to restart • 'q' to quit"))
+			break
+		}
+		s.WriteString(helpStyle.Render("↑/↓/PgUp/PgDn to scroll • Enter for details • Tab to toggle filter • 'S' to change sort • 'T' to filter by tag • 'g' for the grid • 's' to star • 'o' to open registrar • 'c'/'C' to copy one/all available • '*' starred only • 'e' to export • 'R' to retry failed • 'J' for searches • 'r' to restart • 'q' to quit"))
+		s.WriteString("\n")
+		s.WriteString(helpStyle.Render(m.columnHelp()))
+
+	case stateDetail:
+		if m.showRaw {
+			s.WriteString(m.rawWhoisView())
+			s.WriteString("\n")
+			s.WriteString(helpStyle.Render("↑/↓/PgUp/PgDn to scroll • 'w' or Esc for the parsed record • 'q' to quit"))
+			break
+		}
+		if e, ok := m.selectedEntry(); ok {
+			s.WriteString(m.formatDetail(e))
+		}
+		s.WriteString("\n")
+		s.WriteString(helpStyle.Render("'w' for the raw whois response • Enter or Esc to go back • 'q' to quit"))
+
+	case stateSettings:
+		s.WriteString(m.settingsView())
+
+	case stateJobs:
+		s.WriteString(m.jobsView())
 	}
 
 	return s.String()
 }
 
-func formatResult(r checker.Result, showOnlyAvail bool) string {
+// entry returns the ranked entry for a result, scoring it if it was not ranked
+func (m Model) entry(r checker.Result) rank.Entry {
+	if e, ok := m.ranks[r.Domain]; ok {
+		return e
+	}
+	return rank.Entry{Result: r, Score: score.Score(r.Domain)}
+}
+
+// expiringNote flags a taken domain expiring within --expiring-within, or
+// returns ""
+func (m Model) expiringNote(r checker.Result) string {
+	if m.expiringWithin <= 0 || r.Error != nil || r.Available {
+		return ""
+	}
+	days, ok := checker.Expiring(r.ExpiryDate, m.expiringWithin, time.Now())
+	if !ok {
+		return ""
+	}
+	return checker.DaysLeft(days)
+}
+
+func (m Model) formatResult(e rank.Entry, showOnlyAvail bool) string {
+	r := e.Result
 	if r.Error != nil {
-		return fmt.Sprintf("[error] %s - %v\n", r.Domain, r.Error)
+		return fmt.Sprintf("[error] %s - %v\n", tld.ToUnicode(r.Domain), r.Error)
 	}
 
 	if r.Available {
-		return availableStyle.Render("[avail]") + " " + r.Domain + "\n"
+		line := availableStyle.Render("[avail]") + " " + tld.ToUnicode(r.Domain) + helpStyle.Render(fmt.Sprintf(" - Score: %d", e.Score))
+		if r.Confidence == checker.ConfidenceLow {
+			line += " " + expiryStyle.Render("(low confidence)")
+		}
+		if e.HasPrice {
+			line += " - " + expiryStyle.Render(e.FormatPrice())
+		}
+		if r.Certs != nil && r.Certs.Count > 0 {
+			line += " - " + expiryStyle.Render("certs: "+r.Certs.String())
+		}
+		if r.Archive != nil && r.Archive.Months > 0 {
+			line += " - " + expiryStyle.Render("wayback: "+r.Archive.String())
+		}
+		if r.Reputation != nil && !r.Reputation.Clean() {
+			line += " - " + takenStyle.Render(r.Reputation.String())
+		}
+		if len(r.Trademarks) > 0 {
+			line += " - " + takenStyle.Render("trademark: "+r.Trademarks[0].String())
+		}
+		return line + "\n"
+	}
+
+	if restriction := r.Restriction(); restriction != "" {
+		if showOnlyAvail && !r.Premium {
+			return ""
+		}
+		return expiryStyle.Render("["+restriction+"]") + " " + tld.ToUnicode(r.Domain) + helpStyle.Render(fmt.Sprintf(" - Score: %d", e.Score)) + "\n"
 	}
 
-	if showOnlyAvail {
+	if showOnlyAvail && !r.Parked && !r.ForSale && !r.Dropping() {
 		return ""
 	}
 
+	status := takenStyle.Render("[taken]")
+	if r.Parked {
+		status = takenStyle.Render("[taken (parked — possibly for sale)]")
+	}
+	if r.Dropping() {
+		status += " " + availableStyle.Render("(dropping soon)")
+	}
+
+	line := status + " " + tld.ToUnicode(r.Domain)
 	if r.ExpiryDate != "" {
-		return takenStyle.Render("[taken]") + " " + r.Domain + " - Exp: " + expiryStyle.Render(r.ExpiryDate) + "\n"
+		line += " - Exp: " + expiryStyle.Render(r.ExpiryDate)
+	}
+	if note := m.expiringNote(r); note != "" {
+		line += " " + takenStyle.Render("("+note+")")
+	}
+	if age := checker.Age(r.CreatedDate, time.Now()); age != "" {
+		line += " - Age: " + age
+	}
+	if r.Listing != nil {
+		line += " - " + availableStyle.Render("listed: "+r.Listing.String()) + " " + helpStyle.Render(r.Listing.URL)
+	} else if r.ForSale {
+		line += " - " + availableStyle.Render("for sale") + " " + helpStyle.Render(r.SaleURL)
+	}
+	if r.Usage != nil {
+		line += " - " + helpStyle.Render(r.Usage.String())
 	}
-	return takenStyle.Render("[taken]") + " " + r.Domain + "\n"
+	return line + "\n"
 }
 
 func min(a, b int) int {
@@ -451,8 +1088,8 @@ func max(a, b int) int {
 }
 
 // Run starts the TUI
-func Run(tlds []string) error {
-	p := tea.NewProgram(NewModel(tlds), tea.WithAltScreen())
+func Run(tlds []string, opts Options) error {
+	p := tea.NewProgram(NewModel(tlds, opts), tea.WithAltScreen())
 	_, err := p.Run()
 	return err
 }
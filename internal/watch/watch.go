@@ -0,0 +1,142 @@
+// Package watch tracks taken domains through the deletion lifecycle
+// (redemptionPeriod, pendingDelete) between runs, so drops can be caught.
+package watch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+	"github.com/james-see/gofindadomain/internal/config"
+)
+
+// Lifecycle phases, in the order a dropping domain passes through them
+const (
+	Registered       = "registered"
+	AutoRenewPeriod  = "autoRenewPeriod"
+	RedemptionPeriod = "redemptionPeriod"
+	PendingRestore   = "pendingRestore"
+	PendingDelete    = "pendingDelete"
+	Available        = "available"
+	// Premium and Reserved are unregistered names the registry does not
+	// sell at its base price; a release moves them to Available
+	Premium  = "premium"
+	Reserved = "reserved"
+)
+
+// phaseStatuses maps normalized EPP status codes to phases, most advanced
+// first. RDAP spells the same codes as lower-case words ("pending delete").
+var phaseStatuses = []struct {
+	status string
+	phase  string
+}{
+	{"pendingdelete", PendingDelete},
+	{"pendingrestore", PendingRestore},
+	{"redemptionperiod", RedemptionPeriod},
+	{"autorenewperiod", AutoRenewPeriod},
+}
+
+// Phase returns the lifecycle phase of a successful check
+func Phase(r checker.Result) string {
+	if r.Available {
+		return Available
+	}
+	if restriction := r.Restriction(); restriction != "" {
+		return restriction
+	}
+	for _, ps := range phaseStatuses {
+		if r.HasStatus(ps.status) {
+			return ps.phase
+		}
+	}
+	return Registered
+}
+
+// DomainState is what the last successful check of a domain found
+type DomainState struct {
+	Phase      string    `json:"phase"`
+	Status     []string  `json:"status,omitempty"`
+	ExpiryDate string    `json:"expiry_date,omitempty"`
+	Checked    time.Time `json:"checked"`
+	// Since is when the domain entered its phase
+	Since time.Time `json:"since"`
+}
+
+// State is the last known state of every watched domain
+type State map[string]DomainState
+
+// Transition is a change of lifecycle phase between two checks
+type Transition struct {
+	Domain string
+	From   string
+	To     string
+}
+
+// Update records a successful check and returns the phase transition it
+// reveals; ok is false for the first check of a domain or an unchanged phase
+func (s State) Update(r checker.Result, now time.Time) (t Transition, ok bool) {
+	phase := Phase(r)
+	prev, seen := s[r.Domain]
+
+	since := now
+	if seen && prev.Phase == phase {
+		since = prev.Since
+	}
+	s[r.Domain] = DomainState{Phase: phase, Status: r.Status, ExpiryDate: r.ExpiryDate, Checked: now, Since: since}
+
+	if !seen || prev.Phase == phase {
+		return Transition{}, false
+	}
+	return Transition{Domain: r.Domain, From: prev.Phase, To: phase}, true
+}
+
+// Path returns where the watch state is kept (~/.config/gofindadomain/watch.json)
+func Path() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "watch.json"), nil
+}
+
+// Load reads the watch state, which is empty before the first run
+func Load() (State, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return make(State), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch state: %w", err)
+	}
+	s := make(State)
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the watch state
+func (s State) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to save watch state: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to save watch state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+func TestPhase(t *testing.T) {
+	tests := []struct {
+		name   string
+		result checker.Result
+		want   string
+	}{
+		{"available", checker.Result{Available: true}, Available},
+		{"no status", checker.Result{}, Registered},
+		{"ok status", checker.Result{Status: []string{"clientTransferProhibited"}}, Registered},
+		{"auto-renew", checker.Result{Status: []string{"autoRenewPeriod"}}, AutoRenewPeriod},
+		{"redemption", checker.Result{Status: []string{"redemptionPeriod"}}, RedemptionPeriod},
+		{"rdap spelling", checker.Result{Status: []string{"redemption period"}}, RedemptionPeriod},
+		{"pending restore", checker.Result{Status: []string{"pendingRestore", "redemptionPeriod"}}, PendingRestore},
+		{"most advanced wins", checker.Result{Status: []string{"redemptionPeriod", "pendingDelete"}}, PendingDelete},
+		{"case ignored", checker.Result{Status: []string{"PENDINGDELETE"}}, PendingDelete},
+		{"premium", checker.Result{Premium: true}, Premium},
+		{"reserved", checker.Result{Reserved: true}, Reserved},
+	}
+	for _, tt := range tests {
+		if got := Phase(tt.result); got != tt.want {
+			t.Errorf("%s: Phase(%v) = %q, want %q", tt.name, tt.result.Status, got, tt.want)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	taken := checker.Result{Domain: "example.com"}
+	redemption := checker.Result{Domain: "example.com", Status: []string{"redemptionPeriod"}}
+	deleting := checker.Result{Domain: "example.com", Status: []string{"pendingDelete"}}
+	free := checker.Result{Domain: "example.com", Available: true}
+
+	steps := []struct {
+		result   checker.Result
+		wantOK   bool
+		wantFrom string
+		wantTo   string
+		// wantSince is the day, after start, the domain entered its phase
+		wantSince int
+	}{
+		{taken, false, "", "", 0},
+		{taken, false, "", "", 0},
+		{redemption, true, Registered, RedemptionPeriod, 2},
+		{redemption, false, "", "", 2},
+		{deleting, true, RedemptionPeriod, PendingDelete, 4},
+		{free, true, PendingDelete, Available, 5},
+		{taken, true, Available, Registered, 6},
+	}
+	s := make(State)
+	for day, step := range steps {
+		now := start.AddDate(0, 0, day)
+		tr, ok := s.Update(step.result, now)
+		if ok != step.wantOK || tr.From != step.wantFrom || tr.To != step.wantTo {
+			t.Errorf("day %d: Update = %+v, %v, want %s -> %s, %v", day, tr, ok, step.wantFrom, step.wantTo, step.wantOK)
+		}
+		got := s["example.com"]
+		if want := start.AddDate(0, 0, step.wantSince); !got.Since.Equal(want) || !got.Checked.Equal(now) {
+			t.Errorf("day %d: state since %s checked %s, want since %s checked %s", day, got.Since, got.Checked, want, now)
+		}
+	}
+}
+
+func TestUpdateDomainsApart(t *testing.T) {
+	s := make(State)
+	now := time.Now()
+	s.Update(checker.Result{Domain: "a.com"}, now)
+	if _, ok := s.Update(checker.Result{Domain: "b.com", Available: true}, now); ok {
+		t.Error("first check of b.com reported a transition")
+	}
+	if tr, ok := s.Update(checker.Result{Domain: "a.com", Available: true}, now); !ok || tr.Domain != "a.com" || tr.From != Registered {
+		t.Errorf("a.com becoming available = %+v, %v, want a transition from registered", tr, ok)
+	}
+}
@@ -0,0 +1,134 @@
+// Package wayback looks up the Wayback Machine snapshots of domains through
+// the Internet Archive CDX API, which shows whether an available domain had
+// content before.
+package wayback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+const CDXURL = "https://web.archive.org/cdx/search/cdx"
+
+// maxConcurrent limits the queries in flight; the CDX API throttles
+// clients that send many at once
+const maxConcurrent = 2
+
+var (
+	client = &http.Client{Timeout: 60 * time.Second}
+	slots  = make(chan struct{}, maxConcurrent)
+)
+
+// History returns the snapshots of domain's home page that served content,
+// counted by month
+func History(ctx context.Context, domain string) (checker.ArchiveHistory, error) {
+	select {
+	case slots <- struct{}{}:
+		defer func() { <-slots }()
+	case <-ctx.Done():
+		return checker.ArchiveHistory{}, ctx.Err()
+	}
+
+	// One capture per month keeps the answer small for much archived domains
+	monthly, err := query(ctx, url.Values{"url": {domain}, "collapse": {"timestamp:6"}})
+	if err != nil {
+		return checker.ArchiveHistory{}, err
+	}
+	var h checker.ArchiveHistory
+	for _, date := range monthly {
+		h.Months++
+		if h.FirstSnapshot == "" {
+			h.FirstSnapshot = date
+		}
+		h.LastSnapshot = date
+	}
+	if h.Months == 0 {
+		return h, nil
+	}
+
+	// The monthly captures are the first of each month, so the latest
+	// snapshot is asked for separately
+	latest, err := query(ctx, url.Values{"url": {domain}, "limit": {"-1"}, "fastLatest": {"true"}})
+	if err != nil {
+		return checker.ArchiveHistory{}, err
+	}
+	if len(latest) > 0 {
+		h.LastSnapshot = latest[len(latest)-1]
+	}
+	return h, nil
+}
+
+// query asks the CDX API for the captures matching params that served
+// content, returning their dates in capture order
+func query(ctx context.Context, params url.Values) ([]string, error) {
+	params.Set("output", "json")
+	params.Set("fl", "timestamp")
+	params.Set("filter", "statuscode:200")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, CDXURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "gofindadomain")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the Wayback Machine: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query the Wayback Machine: HTTP %d", resp.StatusCode)
+	}
+
+	// The first row is the header; a domain never archived has no rows at all
+	var rows [][]string
+	if err := json.NewDecoder(resp.Body).Decode(&rows); err != nil {
+		return nil, fmt.Errorf("failed to parse Wayback Machine snapshots: %w", err)
+	}
+	var dates []string
+	for i, row := range rows {
+		if i == 0 || len(row) == 0 {
+			continue
+		}
+		if date, ok := snapshotDate(row[0]); ok {
+			dates = append(dates, date)
+		}
+	}
+	return dates, nil
+}
+
+// snapshotDate converts a CDX timestamp (20190411083015) to YYYY-MM-DD
+func snapshotDate(timestamp string) (string, bool) {
+	if len(timestamp) < 8 {
+		return "", false
+	}
+	t, err := time.Parse("20060102", timestamp[:8])
+	if err != nil {
+		return "", false
+	}
+	return t.Format("2006-01-02"), true
+}
+
+// Backend wraps a checker.Backend and looks up the snapshots of the domains
+// it finds available. A failed lookup leaves the history unset rather than
+// failing the check.
+type Backend struct {
+	checker.Backend
+}
+
+// Check implements checker.Backend
+func (b Backend) Check(ctx context.Context, domain string) checker.Result {
+	r := b.Backend.Check(ctx, domain)
+	if !r.Available || r.Error != nil {
+		return r
+	}
+	if h, err := History(ctx, domain); err == nil {
+		r.Archive = &h
+	}
+	return r
+}
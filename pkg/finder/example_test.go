@@ -0,0 +1,59 @@
+package finder_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/james-see/gofindadomain/pkg/finder"
+)
+
+// registry is a stand-in for a registrar API, treating names starting with
+// "free" as unregistered
+type registry struct{}
+
+func (registry) Check(ctx context.Context, domain string) finder.Result {
+	if strings.HasPrefix(domain, "free") {
+		return finder.Result{Domain: domain, Available: true, Confidence: "high"}
+	}
+	return finder.Result{Domain: domain, Registrar: "Example Registrar", Confidence: "high"}
+}
+
+func Example() {
+	f, err := finder.New(finder.WithCustomBackend(registry{}), finder.WithConcurrency(10))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, domain := range []string{"freeswiftpanda.com", "swiftpanda.com"} {
+		r := f.Check(context.Background(), domain)
+		if r.Err != nil {
+			fmt.Println(domain, r.Err)
+			continue
+		}
+		if r.Available {
+			fmt.Println(r.Domain, "is available")
+		} else {
+			fmt.Println(r.Domain, "is registered with", r.Registrar)
+		}
+	}
+	// Output:
+	// freeswiftpanda.com is available
+	// swiftpanda.com is registered with Example Registrar
+}
+
+func ExampleFinder_CheckAll() {
+	f, err := finder.New(finder.WithCustomBackend(registry{}))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	available := 0
+	f.CheckAll(context.Background(), []string{"freebird.com", "freebird.io", "bird.com"}, func(r finder.Result) {
+		if r.Available {
+			available++
+		}
+	})
+	fmt.Println(available, "available")
+	// Output: 2 available
+}
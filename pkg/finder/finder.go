@@ -0,0 +1,322 @@
+// Package finder checks domain availability from Go programs, using the same
+// whois and RDAP clients as the gofindadomain CLI.
+//
+//	f, err := finder.New(finder.WithBackend(finder.RDAP), finder.WithConcurrency(10))
+//	if err != nil {
+//		return err
+//	}
+//	f.CheckAll(ctx, []string{"example.com", "example.io"}, func(r finder.Result) {
+//		fmt.Println(r.Domain, r.Available)
+//	})
+package finder
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/james-see/gofindadomain/internal/checker"
+)
+
+// Result is the outcome of checking one domain. The registration fields
+// are only set for taken domains, where the registry publishes them.
+type Result struct {
+	Domain      string
+	Available   bool
+	ExpiryDate  string
+	CreatedDate string
+	UpdatedDate string
+	Registrar   string
+	Nameservers []string
+	// Status holds the EPP status codes (clientTransferProhibited, ...)
+	Status []string
+	DNSSEC bool
+	// Premium is set for unregistered names sold above the base price and
+	// Reserved for ones the registry withholds; neither is Available
+	Premium  bool
+	Reserved bool
+	// Confidence is "low" when availability was guessed (no whois pattern
+	// matched, or only DNS was asked), "medium" for a whois pattern match
+	// and "high" for authoritative or double-checked answers
+	Confidence string
+	Err        error
+}
+
+// Backend checks the availability of a single domain
+type Backend interface {
+	Check(ctx context.Context, domain string) Result
+}
+
+// BackendKind selects one of the built-in backends
+type BackendKind int
+
+const (
+	// Whois queries registry whois servers over port 43 and follows referrals
+	Whois BackendKind = iota
+	// SystemWhois runs the installed whois command
+	SystemWhois
+	// RDAP queries registries over RDAP, falling back to whois for TLDs
+	// without an RDAP server
+	RDAP
+	// DNS judges availability by NS records alone
+	DNS
+)
+
+// Finder checks domains with a backend, concurrency and timeout chosen
+// through options.
+//
+// Rate limits and availability patterns are shared by the whole process,
+// since every Finder queries the same servers: New applies those given by
+// WithRateLimit, WithServerRateLimits and WithPatterns to all Finders, so
+// the Finder created last decides them.
+type Finder struct {
+	backend     Backend
+	concurrency int
+	timeout     time.Duration
+	retries     int
+	retryDelay  time.Duration
+	patterns    string
+	verify      bool
+	// verifier replaces the built-in second method of verify
+	verifier    Backend
+	resolver    *net.Resolver
+	rateLimit   *float64
+	serverRates map[string]float64
+}
+
+// Option configures a Finder
+type Option func(*Finder)
+
+// WithBackend selects a built-in backend (default Whois)
+func WithBackend(kind BackendKind) Option {
+	return func(f *Finder) {
+		switch kind {
+		case SystemWhois:
+			f.backend = adapt(checker.SystemWhoisBackend{})
+		case RDAP:
+			f.backend = adapt(checker.RDAPBackend{})
+		case DNS:
+			f.backend = adapt(checker.DNSBackend{})
+		default:
+			f.backend = adapt(checker.WhoisBackend{})
+		}
+	}
+}
+
+// WithCustomBackend checks domains with b instead of a built-in backend
+func WithCustomBackend(b Backend) Option {
+	return func(f *Finder) { f.backend = b }
+}
+
+// WithConcurrency sets how many domains CheckAll checks at once (default 30)
+func WithConcurrency(n int) Option {
+	return func(f *Finder) { f.concurrency = max(1, n) }
+}
+
+// WithTimeout bounds each check, including retries (default no bound beyond
+// the clients' own timeouts)
+func WithTimeout(d time.Duration) Option {
+	return func(f *Finder) { f.timeout = d }
+}
+
+// WithRetries retries transient failures up to n times, waiting delay before
+// the first retry and doubling it for each further one
+func WithRetries(n int, delay time.Duration) Option {
+	return func(f *Finder) {
+		f.retries = n
+		f.retryDelay = delay
+	}
+}
+
+// WithRateLimit sets the queries per second sent to any one whois or RDAP
+// server; 0 disables the limit. Rate limits apply to the whole process.
+func WithRateLimit(perSecond float64) Option {
+	return func(f *Finder) { f.rateLimit = &perSecond }
+}
+
+// WithServerRateLimits overrides the rate limit of individual servers, keyed
+// by host name. Rate limits apply to the whole process.
+func WithServerRateLimits(rates map[string]float64) Option {
+	return func(f *Finder) { f.serverRates = rates }
+}
+
+// WithVerify double-checks available domains with a second method (an NS
+// lookup, then RDAP, or whois for the RDAP backend), so they come back with
+// high confidence or are corrected to taken
+func WithVerify() Option {
+	return func(f *Finder) { f.verify = true }
+}
+
+// WithVerifier double-checks available domains with b instead of RDAP or
+// whois, as WithVerify does
+func WithVerifier(b Backend) Option {
+	return func(f *Finder) {
+		f.verify = true
+		f.verifier = b
+	}
+}
+
+// WithResolver makes the NS lookups of the DNS backend and of verification
+// with r instead of net.DefaultResolver
+func WithResolver(r *net.Resolver) Option {
+	return func(f *Finder) { f.resolver = r }
+}
+
+// WithPatterns loads a JSON file of per-TLD availability patterns over the
+// embedded ones. Patterns apply to the whole process.
+func WithPatterns(path string) Option {
+	return func(f *Finder) { f.patterns = path }
+}
+
+// New returns a Finder configured by opts
+func New(opts ...Option) (*Finder, error) {
+	f := &Finder{
+		backend:     adapt(checker.WhoisBackend{}),
+		concurrency: 30,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if f.patterns != "" {
+		if err := checker.LoadPatterns(f.patterns); err != nil {
+			return nil, err
+		}
+	}
+	if f.rateLimit != nil {
+		checker.SetRateLimit(*f.rateLimit)
+	}
+	if f.serverRates != nil {
+		checker.SetServerRateLimits(f.serverRates)
+	}
+	return f, nil
+}
+
+// Check checks a single domain
+func (f *Finder) Check(ctx context.Context, domain string) Result {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+	return fromChecker(f.checkerBackend().Check(ctx, domain))
+}
+
+// CheckAll checks domains concurrently and calls fn with each result as it
+// arrives. fn is never called concurrently. It returns when all domains are
+// checked or ctx is done.
+func (f *Finder) CheckAll(ctx context.Context, domains []string, fn func(Result)) {
+	backend := checkerFunc(func(ctx context.Context, domain string) checker.Result {
+		if f.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, f.timeout)
+			defer cancel()
+		}
+		return f.checkerBackend().Check(ctx, domain)
+	})
+	checker.CheckDomainsWithCallback(ctx, backend, domains, f.concurrency, func(r checker.Result) {
+		fn(fromChecker(r))
+	})
+}
+
+// checkerBackend returns the configured backend as a checker.Backend,
+// wrapped with retries and verification
+func (f *Finder) checkerBackend() checker.Backend {
+	b := toCheckerBackend(f.backend)
+	if d, ok := b.(checker.DNSBackend); ok && f.resolver != nil {
+		d.Resolver = f.resolver
+		b = d
+	}
+	verifier := checker.VerifierFor(b)
+	if f.verifier != nil {
+		verifier = toCheckerBackend(f.verifier)
+	}
+	if f.retries > 0 {
+		b = checker.RetryBackend{Backend: b, Retries: f.retries, Delay: f.retryDelay}
+		verifier = checker.RetryBackend{Backend: verifier, Retries: f.retries, Delay: f.retryDelay}
+	}
+	if f.verify {
+		b = checker.VerifyBackend{Backend: b, Verifier: verifier, Resolver: f.resolver}
+	}
+	return b
+}
+
+// toCheckerBackend returns b as a checker.Backend
+func toCheckerBackend(b Backend) checker.Backend {
+	if a, ok := b.(adapter); ok {
+		return a.Backend
+	}
+	return checkerFunc(func(ctx context.Context, domain string) checker.Result {
+		return toChecker(b.Check(ctx, domain))
+	})
+}
+
+// adapter exposes a built-in checker backend as a Backend
+type adapter struct {
+	checker.Backend
+}
+
+func adapt(b checker.Backend) Backend {
+	return adapter{b}
+}
+
+// Check implements Backend
+func (a adapter) Check(ctx context.Context, domain string) Result {
+	return fromChecker(a.Backend.Check(ctx, domain))
+}
+
+// checkerFunc adapts a function to checker.Backend
+type checkerFunc func(ctx context.Context, domain string) checker.Result
+
+func (fn checkerFunc) Check(ctx context.Context, domain string) checker.Result {
+	return fn(ctx, domain)
+}
+
+func fromChecker(r checker.Result) Result {
+	return Result{
+		Domain:      r.Domain,
+		Available:   r.Available && r.Error == nil,
+		ExpiryDate:  r.ExpiryDate,
+		CreatedDate: r.CreatedDate,
+		UpdatedDate: r.UpdatedDate,
+		Registrar:   r.Registrar,
+		Nameservers: r.Nameservers,
+		Status:      r.Status,
+		DNSSEC:      r.DNSSEC,
+		Premium:     r.Premium,
+		Reserved:    r.Reserved,
+		Confidence:  r.Confidence.String(),
+		Err:         r.Error,
+	}
+}
+
+func toChecker(r Result) checker.Result {
+	return checker.Result{
+		Domain:      r.Domain,
+		Available:   r.Available,
+		ExpiryDate:  r.ExpiryDate,
+		CreatedDate: r.CreatedDate,
+		UpdatedDate: r.UpdatedDate,
+		Registrar:   r.Registrar,
+		Nameservers: r.Nameservers,
+		Status:      r.Status,
+		DNSSEC:      r.DNSSEC,
+		Premium:     r.Premium,
+		Reserved:    r.Reserved,
+		Confidence:  toConfidence(r.Confidence),
+		Error:       r.Err,
+	}
+}
+
+// toConfidence parses the String form of a checker.Confidence
+func toConfidence(s string) checker.Confidence {
+	switch s {
+	case "low":
+		return checker.ConfidenceLow
+	case "medium":
+		return checker.ConfidenceMedium
+	case "high":
+		return checker.ConfidenceHigh
+	}
+	return checker.ConfidenceNone
+}
@@ -0,0 +1,182 @@
+package finder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeBackend answers checks with check, counting the calls per domain
+type fakeBackend struct {
+	check func(domain string, call int) Result
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (b *fakeBackend) Check(ctx context.Context, domain string) Result {
+	b.mu.Lock()
+	if b.calls == nil {
+		b.calls = make(map[string]int)
+	}
+	b.calls[domain]++
+	call := b.calls[domain]
+	b.mu.Unlock()
+	return b.check(domain, call)
+}
+
+func (b *fakeBackend) callsFor(domain string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.calls[domain]
+}
+
+// noDNS fails every lookup, keeping verification away from the network
+var noDNS = &net.Resolver{
+	PreferGo: true,
+	Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+		return nil, errors.New("no DNS in tests")
+	},
+}
+
+func TestCheckAll(t *testing.T) {
+	backend := &fakeBackend{check: func(domain string, _ int) Result {
+		var n int
+		fmt.Sscanf(domain, "name%d.com", &n)
+		return Result{Domain: domain, Available: n%2 == 0, Confidence: "medium"}
+	}}
+	f, err := New(WithCustomBackend(backend), WithConcurrency(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var domains []string
+	for i := range 20 {
+		domains = append(domains, fmt.Sprintf("name%d.com", i))
+	}
+	var inCallback atomic.Int32
+	got := make(map[string]Result)
+	f.CheckAll(context.Background(), domains, func(r Result) {
+		if inCallback.Add(1) > 1 {
+			t.Error("fn called concurrently")
+		}
+		got[r.Domain] = r
+		inCallback.Add(-1)
+	})
+
+	if len(got) != len(domains) {
+		t.Fatalf("got %d results, want %d", len(got), len(domains))
+	}
+	for i, d := range domains {
+		r := got[d]
+		if r.Available != (i%2 == 0) {
+			t.Errorf("%s: Available = %v, want %v", d, r.Available, i%2 == 0)
+		}
+		if r.Confidence != "medium" {
+			t.Errorf("%s: Confidence = %q, want medium", d, r.Confidence)
+		}
+	}
+}
+
+func TestCheckRetries(t *testing.T) {
+	tests := []struct {
+		retries   int
+		wantErr   bool
+		wantCalls int
+	}{
+		{retries: 0, wantErr: true, wantCalls: 1},
+		{retries: 1, wantErr: true, wantCalls: 2},
+		{retries: 2, wantErr: false, wantCalls: 3},
+		{retries: 5, wantErr: false, wantCalls: 3},
+	}
+	for _, tt := range tests {
+		// The first two checks time out
+		backend := &fakeBackend{check: func(domain string, call int) Result {
+			if call <= 2 {
+				return Result{Domain: domain, Err: errors.New("read tcp: i/o timeout")}
+			}
+			return Result{Domain: domain, Available: true, Confidence: "medium"}
+		}}
+		f, err := New(WithCustomBackend(backend), WithRetries(tt.retries, time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := f.Check(context.Background(), "example.com")
+		if (r.Err != nil) != tt.wantErr {
+			t.Errorf("retries %d: Err = %v, want error %v", tt.retries, r.Err, tt.wantErr)
+		}
+		if n := backend.callsFor("example.com"); n != tt.wantCalls {
+			t.Errorf("retries %d: %d checks, want %d", tt.retries, n, tt.wantCalls)
+		}
+	}
+}
+
+func TestCheckRetriesOnlyTransient(t *testing.T) {
+	backend := &fakeBackend{check: func(domain string, _ int) Result {
+		return Result{Domain: domain, Err: errors.New("no whois server")}
+	}}
+	f, err := New(WithCustomBackend(backend), WithRetries(3, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := f.Check(context.Background(), "example.com"); r.Err == nil {
+		t.Error("Err = nil, want the backend's error")
+	}
+	if n := backend.callsFor("example.com"); n != 1 {
+		t.Errorf("%d checks, want 1", n)
+	}
+}
+
+func TestCheckVerify(t *testing.T) {
+	backend := &fakeBackend{check: func(domain string, _ int) Result {
+		return Result{Domain: domain, Available: true, Confidence: "low"}
+	}}
+	// The second method knows taken.com is registered
+	verifier := &fakeBackend{check: func(domain string, _ int) Result {
+		if domain == "taken.com" {
+			return Result{Domain: domain, Registrar: "Example Registrar", Confidence: "medium"}
+		}
+		return Result{Domain: domain, Available: true, Confidence: "medium"}
+	}}
+	f, err := New(WithCustomBackend(backend), WithVerifier(verifier), WithResolver(noDNS))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	free := f.Check(context.Background(), "free.com")
+	if !free.Available || free.Confidence != "high" {
+		t.Errorf("free.com: Available = %v, Confidence = %q, want true, high", free.Available, free.Confidence)
+	}
+	taken := f.Check(context.Background(), "taken.com")
+	if taken.Available || taken.Registrar != "Example Registrar" || taken.Confidence != "high" {
+		t.Errorf("taken.com: Available = %v, Registrar = %q, Confidence = %q, want false, Example Registrar, high",
+			taken.Available, taken.Registrar, taken.Confidence)
+	}
+	if n := verifier.callsFor("free.com"); n != 1 {
+		t.Errorf("free.com verified %d times, want 1", n)
+	}
+}
+
+func TestCheckVerifySkipsTaken(t *testing.T) {
+	backend := &fakeBackend{check: func(domain string, _ int) Result {
+		return Result{Domain: domain, Confidence: "medium"}
+	}}
+	verifier := &fakeBackend{check: func(domain string, _ int) Result {
+		return Result{Domain: domain, Available: true}
+	}}
+	f, err := New(WithCustomBackend(backend), WithVerifier(verifier), WithResolver(noDNS))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := f.Check(context.Background(), "taken.com"); r.Available {
+		t.Error("taken.com: Available = true, want false")
+	}
+	if n := verifier.callsFor("taken.com"); n != 0 {
+		t.Errorf("taken.com verified %d times, want 0", n)
+	}
+}